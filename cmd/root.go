@@ -9,21 +9,25 @@ import (
 	"github.com/achannarasappa/ticker/v5/internal/cli"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	"github.com/achannarasappa/ticker/v5/internal/print"
+	"github.com/achannarasappa/ticker/v5/internal/replay"
+	"github.com/achannarasappa/ticker/v5/internal/serve"
 	"github.com/achannarasappa/ticker/v5/internal/ui"
 )
 
 //nolint:gochecknoglobals
 var (
 	// Version is a placeholder that is replaced at build time with a linker flag (-ldflags)
-	Version      = "v0.0.0"
-	configPath   string
-	dep          c.Dependencies
-	ctx          c.Context
-	config       c.Config
-	options      cli.Options
-	optionsPrint print.Options
-	err          error
-	rootCmd      = &cobra.Command{
+	Version       = "v0.0.0"
+	configPath    string
+	dep           c.Dependencies
+	ctx           c.Context
+	config        c.Config
+	options       cli.Options
+	optionsPrint  print.Options
+	optionsReplay replay.Options
+	optionsServe  serve.Options
+	err           error
+	rootCmd       = &cobra.Command{
 		Version: Version,
 		Use:     "ticker",
 		Short:   "Terminal stock ticker and stock gain/loss tracker",
@@ -45,6 +49,27 @@ var (
 		Args:   cli.Validate(&config, &options, &err),
 		Run:    print.RunSummary(&dep, &ctx, &optionsPrint),
 	}
+	lotsCmd = &cobra.Command{
+		Use:    "lots",
+		Short:  "Prints each lot in the default group with its acquisition date and holding period",
+		PreRun: initContext,
+		Args:   cli.Validate(&config, &options, &err),
+		Run:    print.RunLots(&dep, &ctx, &optionsPrint),
+	}
+	serveCmd = &cobra.Command{
+		Use:    "serve",
+		Short:  "Gets quotes for all groups in the background and serves them as Prometheus metrics",
+		PreRun: initContext,
+		Args:   cli.Validate(&config, &options, &err),
+		Run:    serve.Run(&dep, &ctx, &optionsServe),
+	}
+	replayCmd = &cobra.Command{
+		Use:    "replay <file>",
+		Short:  "Replays a recorded quote history file in the UI",
+		PreRun: initContextReplay,
+		Args:   cobra.ExactArgs(1),
+		Run:    cli.Run(ui.StartReplay(&dep, &ctx, &optionsReplay, Version)),
+	}
 )
 
 // Execute starts the CLI or prints an error is there is one
@@ -66,14 +91,31 @@ func init() { //nolint: gochecknoinits
 	rootCmd.Flags().BoolVar(&options.ExtraInfoFundamentals, "show-fundamentals", false, "display open price, high, low, and volume for each quote")
 	rootCmd.Flags().BoolVar(&options.ShowSummary, "show-summary", false, "display summary of total gain and loss for positions")
 	rootCmd.Flags().BoolVar(&options.ShowPositions, "show-positions", false, "display average unit cost, quantity, portfolio weight")
+	rootCmd.Flags().BoolVar(&options.ShowSparkline, "show-sparkline", false, "display a chart of the intraday price for each quote")
+	rootCmd.Flags().BoolVar(&options.ShowReturns, "show-returns", false, "display the annualized return for each position and the portfolio")
 	rootCmd.Flags().BoolVar(&options.ShowHoldings, "show-holdings", false, "display average unit cost, quantity, portfolio weight (deprecated: use --show-positions)")
 	rootCmd.Flags().StringVar(&options.Sort, "sort", "", "sort quotes on the UI. Set \"alpha\" to sort by ticker name. Set \"value\" to sort by position value. Keep empty to sort according to change percent")
 
-	printCmd.PersistentFlags().StringVar(&optionsPrint.Format, "format", "", "output format for printing holdings. Set \"csv\" to print as a CSV or \"json\" for JSON. Defaults to JSON.")
+	printCmd.PersistentFlags().StringVar(&optionsPrint.Format, "format", "", "output format for printing holdings. Set \"csv\" to print as a CSV, \"json\" for JSON, \"table\" for an aligned and colored table, \"markdown\" for a Markdown table, or \"html\" for an HTML page. Defaults to JSON.")
+	printCmd.PersistentFlags().IntVar(&optionsPrint.SchemaVersion, "schema-version", 1, "JSON schema version. Set 2 for numeric values with all quote, position, currency, and exchange fields")
+	printCmd.Flags().BoolVar(&optionsPrint.IncludeWatchlist, "include-watchlist", false, "include watchlist symbols without a position")
+	printCmd.Flags().BoolVar(&optionsPrint.Watch, "watch", false, "keep running and print a line of JSON for each asset update and summary recalculation")
+	printCmd.PersistentFlags().StringVar(&optionsPrint.Group, "group", "", "name of the group to print. Defaults to the first group")
+	printCmd.PersistentFlags().BoolVar(&optionsPrint.Notify, "notify", false, "deliver notifications for notification rules met by the printed assets")
+	printCmd.PersistentFlags().BoolVar(&optionsPrint.AllGroups, "all-groups", false, "print every group with output nested by group and, for the summary, a total across groups")
 	printCmd.PersistentFlags().StringVar(&configPath, "config", "", "config file (default is $HOME/.ticker.yaml)")
 	printCmd.AddCommand(summaryCmd)
+	printCmd.AddCommand(lotsCmd)
+
+	serveCmd.Flags().StringVar(&optionsServe.MetricsAddress, "metrics", "", "address to serve Prometheus metrics on at /metrics (e.g. :9090)")
+	serveCmd.Flags().StringVar(&configPath, "config", "", "config file (default is $HOME/.ticker.yaml)")
+	replayCmd.Flags().StringVar(&configPath, "config", "", "config file (default is $HOME/.ticker.yaml)")
+	replayCmd.Flags().Float64Var(&optionsReplay.Speed, "speed", 1, "playback speed as a multiple of the recorded speed")
+	replayCmd.Flags().DurationVar(&optionsReplay.Start, "start", 0, "offset from the beginning of the recording to start playback from (e.g. 30m)")
 
 	rootCmd.AddCommand(printCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(serveCmd)
 }
 
 func initConfig() {
@@ -99,3 +141,16 @@ func initContext(_ *cobra.Command, _ []string) {
 	}
 
 }
+
+func initContextReplay(_ *cobra.Command, args []string) {
+
+	optionsReplay.Path = args[0]
+
+	ctx, err = cli.GetContextReplay(dep, config)
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+}
@@ -0,0 +1,173 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	u "github.com/achannarasappa/ticker/v5/internal/ui/util"
+)
+
+// Evaluator checks asset quotes against alert rules and returns the alerts which fire
+type Evaluator struct {
+	rulesBySymbol map[string][]c.ConfigAlert
+	cooldown      time.Duration
+	now           func() time.Time
+	lastFired     map[key]time.Time
+	mu            sync.Mutex
+}
+
+// Config contains the required configuration for the alert evaluator
+type Config struct {
+	Rules []c.ConfigAlert
+}
+
+// Option defines an option for configuring the alert evaluator
+type Option func(*Evaluator)
+
+type key struct {
+	symbol    string
+	alertType c.AlertType
+}
+
+// NewEvaluator creates an alert evaluator
+func NewEvaluator(config Config, opts ...Option) *Evaluator {
+
+	rulesBySymbol := make(map[string][]c.ConfigAlert)
+
+	for _, rule := range config.Rules {
+		symbol := strings.ToUpper(rule.Symbol)
+		rulesBySymbol[symbol] = append(rulesBySymbol[symbol], rule)
+	}
+
+	e := &Evaluator{
+		rulesBySymbol: rulesBySymbol,
+		cooldown:      15 * time.Minute,
+		now:           time.Now,
+		lastFired:     make(map[key]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// WithCooldown sets the minimum time before the same alert can fire again for a symbol
+func WithCooldown(cooldown time.Duration) Option {
+	return func(e *Evaluator) {
+		if cooldown > 0 {
+			e.cooldown = cooldown
+		}
+	}
+}
+
+// WithNow sets the function used to get the current time
+func WithNow(now func() time.Time) Option {
+	return func(e *Evaluator) {
+		e.now = now
+	}
+}
+
+// Evaluate returns the alerts for rules met by the asset quote which have not fired within the cooldown
+func (e *Evaluator) Evaluate(assetQuote c.AssetQuote) []c.Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	alerts := make([]c.Alert, 0)
+	rules := e.rulesBySymbol[strings.ToUpper(assetQuote.Symbol)]
+
+	if len(rules) == 0 || assetQuote.QuotePrice.Price == 0.0 {
+		return alerts
+	}
+
+	now := e.now()
+
+	for _, rule := range rules {
+		for _, alert := range getAlerts(rule, assetQuote) {
+			k := key{symbol: alert.Symbol, alertType: alert.Type}
+
+			if lastFired, exists := e.lastFired[k]; exists && now.Sub(lastFired) < e.cooldown {
+				continue
+			}
+
+			e.lastFired[k] = now
+			alert.Time = now
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts
+}
+
+// getAlerts returns an alert for each condition in the rule which is met by the asset quote
+func getAlerts(rule c.ConfigAlert, assetQuote c.AssetQuote) []c.Alert {
+
+	alerts := make([]c.Alert, 0)
+	price := assetQuote.QuotePrice.Price
+	changePercent := assetQuote.QuotePrice.ChangePercent
+	fiftyTwoWeekHigh := assetQuote.QuoteExtended.FiftyTwoWeekHigh
+	fiftyTwoWeekLow := assetQuote.QuoteExtended.FiftyTwoWeekLow
+	isVariablePrecision := assetQuote.Meta.IsVariablePrecision
+
+	newAlert := func(alertType c.AlertType, threshold float64, message string) c.Alert {
+		return c.Alert{
+			Symbol:    assetQuote.Symbol,
+			Type:      alertType,
+			Price:     price,
+			Threshold: threshold,
+			Message:   assetQuote.Symbol + " " + message,
+		}
+	}
+
+	if rule.PriceAbove > 0 && price >= rule.PriceAbove {
+		alerts = append(alerts, newAlert(
+			c.AlertTypePriceAbove,
+			rule.PriceAbove,
+			fmt.Sprintf("is above %s at %s", u.ConvertFloatToString(rule.PriceAbove, isVariablePrecision), u.ConvertFloatToString(price, isVariablePrecision)),
+		))
+	}
+
+	if rule.PriceBelow > 0 && price <= rule.PriceBelow {
+		alerts = append(alerts, newAlert(
+			c.AlertTypePriceBelow,
+			rule.PriceBelow,
+			fmt.Sprintf("is below %s at %s", u.ConvertFloatToString(rule.PriceBelow, isVariablePrecision), u.ConvertFloatToString(price, isVariablePrecision)),
+		))
+	}
+
+	if rule.ChangePercent > 0 && math.Abs(changePercent) >= rule.ChangePercent {
+		direction := "up"
+		if changePercent < 0 {
+			direction = "down"
+		}
+
+		alerts = append(alerts, newAlert(
+			c.AlertTypeChangePercent,
+			rule.ChangePercent,
+			fmt.Sprintf("is %s %s%% today", direction, u.ConvertFloatToString(math.Abs(changePercent), false)),
+		))
+	}
+
+	if rule.FiftyTwoWeekHigh && fiftyTwoWeekHigh > 0 && price >= fiftyTwoWeekHigh {
+		alerts = append(alerts, newAlert(
+			c.AlertTypeFiftyTwoWeekHigh,
+			fiftyTwoWeekHigh,
+			"reached a 52-week high of "+u.ConvertFloatToString(price, isVariablePrecision),
+		))
+	}
+
+	if rule.FiftyTwoWeekLow && fiftyTwoWeekLow > 0 && price <= fiftyTwoWeekLow {
+		alerts = append(alerts, newAlert(
+			c.AlertTypeFiftyTwoWeekLow,
+			fiftyTwoWeekLow,
+			"reached a 52-week low of "+u.ConvertFloatToString(price, isVariablePrecision),
+		))
+	}
+
+	return alerts
+}
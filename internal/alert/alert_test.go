@@ -0,0 +1,159 @@
+package alert_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+
+	"github.com/achannarasappa/ticker/v5/internal/alert"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+var _ = Describe("Alert", func() {
+
+	var (
+		start     = time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+		now       time.Time
+		evaluator *alert.Evaluator
+	)
+
+	newAssetQuote := func(symbol string, price float64, changePercent float64) c.AssetQuote {
+		return c.AssetQuote{
+			Symbol:     symbol,
+			QuotePrice: c.QuotePrice{Price: price, ChangePercent: changePercent},
+			QuoteExtended: c.QuoteExtended{
+				FiftyTwoWeekHigh: 200,
+				FiftyTwoWeekLow:  100,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		now = start
+	})
+
+	Describe("Evaluate", func() {
+
+		type Case struct {
+			Rule       c.ConfigAlert
+			AssetQuote c.AssetQuote
+			Assertion  types.GomegaMatcher
+		}
+
+		DescribeTable("alert rules",
+			func(tc Case) {
+				evaluator = alert.NewEvaluator(alert.Config{Rules: []c.ConfigAlert{tc.Rule}}, alert.WithNow(func() time.Time { return now }))
+
+				Expect(evaluator.Evaluate(tc.AssetQuote)).To(tc.Assertion)
+			},
+			Entry("price is above the threshold", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", PriceAbove: 150},
+				AssetQuote: newAssetQuote("AAPL", 151, 0.5),
+				Assertion: HaveExactElements(g.MatchAllFields(g.Fields{
+					"Symbol":    Equal("AAPL"),
+					"Type":      Equal(c.AlertTypePriceAbove),
+					"Price":     Equal(151.0),
+					"Threshold": Equal(150.0),
+					"Time":      Equal(start),
+					"Message":   Equal("AAPL is above 150.00 at 151.00"),
+				})),
+			}),
+			Entry("price is not above the threshold", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", PriceAbove: 150},
+				AssetQuote: newAssetQuote("AAPL", 149, 0.5),
+				Assertion:  BeEmpty(),
+			}),
+			Entry("price is below the threshold", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", PriceBelow: 150},
+				AssetQuote: newAssetQuote("AAPL", 149, 0.5),
+				Assertion: HaveExactElements(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Type":    Equal(c.AlertTypePriceBelow),
+					"Message": Equal("AAPL is below 150.00 at 149.00"),
+				})),
+			}),
+			Entry("day change is beyond the threshold in the negative direction", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", ChangePercent: 5},
+				AssetQuote: newAssetQuote("AAPL", 150, -5.25),
+				Assertion: HaveExactElements(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Type":    Equal(c.AlertTypeChangePercent),
+					"Message": Equal("AAPL is down 5.25% today"),
+				})),
+			}),
+			Entry("day change is within the threshold", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", ChangePercent: 5},
+				AssetQuote: newAssetQuote("AAPL", 150, 4.9),
+				Assertion:  BeEmpty(),
+			}),
+			Entry("price reaches the 52-week high", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", FiftyTwoWeekHigh: true},
+				AssetQuote: newAssetQuote("AAPL", 200, 1),
+				Assertion: HaveExactElements(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Type":      Equal(c.AlertTypeFiftyTwoWeekHigh),
+					"Threshold": Equal(200.0),
+					"Message":   Equal("AAPL reached a 52-week high of 200.00"),
+				})),
+			}),
+			Entry("price reaches the 52-week low", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", FiftyTwoWeekLow: true},
+				AssetQuote: newAssetQuote("AAPL", 99, -1),
+				Assertion: HaveExactElements(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Type":    Equal(c.AlertTypeFiftyTwoWeekLow),
+					"Message": Equal("AAPL reached a 52-week low of 99.00"),
+				})),
+			}),
+			Entry("multiple conditions in the rule are met", Case{
+				Rule:       c.ConfigAlert{Symbol: "aapl", PriceAbove: 150, ChangePercent: 5, FiftyTwoWeekHigh: true},
+				AssetQuote: newAssetQuote("AAPL", 210, 6),
+				Assertion: HaveExactElements(
+					HaveField("Type", c.AlertTypePriceAbove),
+					HaveField("Type", c.AlertTypeChangePercent),
+					HaveField("Type", c.AlertTypeFiftyTwoWeekHigh),
+				),
+			}),
+			Entry("there are no rules for the symbol", Case{
+				Rule:       c.ConfigAlert{Symbol: "MSFT", PriceAbove: 150},
+				AssetQuote: newAssetQuote("AAPL", 151, 0.5),
+				Assertion:  BeEmpty(),
+			}),
+			Entry("there is no price", Case{
+				Rule:       c.ConfigAlert{Symbol: "AAPL", PriceBelow: 150},
+				AssetQuote: newAssetQuote("AAPL", 0, 0),
+				Assertion:  BeEmpty(),
+			}),
+		)
+
+		When("an alert has fired within the cooldown", func() {
+
+			BeforeEach(func() {
+				evaluator = alert.NewEvaluator(
+					alert.Config{Rules: []c.ConfigAlert{{Symbol: "AAPL", PriceAbove: 150, PriceBelow: 100}}},
+					alert.WithNow(func() time.Time { return now }),
+					alert.WithCooldown(5*time.Minute),
+				)
+			})
+
+			It("should not fire the same alert again until the cooldown has passed", func() {
+				Expect(evaluator.Evaluate(newAssetQuote("AAPL", 151, 0))).To(HaveLen(1))
+
+				now = now.Add(4 * time.Minute)
+				Expect(evaluator.Evaluate(newAssetQuote("AAPL", 152, 0))).To(BeEmpty())
+
+				now = now.Add(time.Minute)
+				Expect(evaluator.Evaluate(newAssetQuote("AAPL", 153, 0))).To(HaveExactElements(HaveField("Price", 153.0)))
+			})
+
+			It("should fire other alerts for the symbol", func() {
+				Expect(evaluator.Evaluate(newAssetQuote("AAPL", 151, 0))).To(HaveLen(1))
+
+				now = now.Add(time.Minute)
+				Expect(evaluator.Evaluate(newAssetQuote("AAPL", 99, 0))).To(HaveExactElements(HaveField("Type", c.AlertTypePriceBelow)))
+			})
+
+		})
+
+	})
+
+})
@@ -2,6 +2,7 @@ package asset
 
 import (
 	"strings"
+	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 )
@@ -11,6 +12,7 @@ type AggregatedLot struct {
 	Symbol     string
 	Cost       float64
 	Quantity   float64
+	Realized   float64 // Gain or loss from units sold and fees
 	OrderIndex int
 }
 
@@ -20,16 +22,36 @@ type PositionSummary struct {
 	Cost        float64
 	TotalChange c.PositionChange
 	DayChange   c.PositionChange
+	Realized    float64
+	// AnnualizedReturn is the money-weighted annual return as a percent of positions with acquisition dates
+	AnnualizedReturn float64
+	Benchmark        Benchmark
+}
+
+// Benchmark represents the day change of the benchmark symbol for an asset group and how the positions performed relative to it
+type Benchmark struct {
+	Symbol          string
+	ChangePercent   float64
+	RelativePercent float64 // Day change percent of the positions less the day change percent of the benchmark
 }
 
 // GetAssets returns assets from an asset group quote
 func GetAssets(ctx c.Context, assetGroupQuote c.AssetGroupQuote) ([]c.Asset, PositionSummary) {
 
 	lots := assetGroupQuote.AssetGroup.ConfigAssetGroup.Lots
+	transactions := assetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions
+	benchmarkSymbol := assetGroupQuote.AssetGroup.ConfigAssetGroup.Benchmark
 
 	var positionSummary PositionSummary
+	var benchmark Benchmark
 	assets := make([]c.Asset, 0)
-	lotsBySymbol := getLots(lots)
+	// Transactions which can not be matched are reported when the config is validated so the error is ignored here
+	transactionLots, _ := GetTransactionLots(transactions, ctx.Config.CostBasisMethod)
+	lotsBySymbol := mergeLots(getLots(lots), transactionLots)
+	cashFlowsBySymbol := getCashFlowsBySymbol(lots, transactions)
+	summaryCashFlows := make([]cashFlow, 0)
+	summaryCashFlowsValue := 0.0
+	now := time.Now()
 	orderIndex := make(map[string]int)
 
 	for i, lot := range lots {
@@ -44,13 +66,37 @@ func GetAssets(ctx c.Context, assetGroupQuote c.AssetGroupQuote) ([]c.Asset, Pos
 		}
 	}
 
+	for i, transaction := range transactions {
+		if _, exists := orderIndex[strings.ToLower(transaction.Symbol)]; !exists {
+			orderIndex[strings.ToLower(transaction.Symbol)] = i + len(lots) + len(assetGroupQuote.AssetGroup.ConfigAssetGroup.Watchlist)
+		}
+	}
+
 	for _, assetQuote := range assetGroupQuote.AssetQuotes {
 
-		currencyRateByUse := getCurrencyRateByUse(ctx, assetQuote.Class, assetQuote.Currency.FromCurrencyCode, assetQuote.Currency.ToCurrencyCode, assetQuote.Currency.Rate)
+		if benchmarkSymbol != "" && strings.EqualFold(assetQuote.Symbol, benchmarkSymbol) {
+			benchmark = Benchmark{
+				Symbol:        assetQuote.Symbol,
+				ChangePercent: assetQuote.QuotePrice.ChangePercent,
+			}
+
+			// A benchmark which is not otherwise in the group is only fetched for comparison
+			if _, exists := orderIndex[strings.ToLower(assetQuote.Symbol)]; !exists {
+				continue
+			}
+		}
+
+		currencyRateByUse := getCurrencyRateByUse(ctx, assetQuote.Class, assetQuote.QuoteSource, assetQuote.Currency.FromCurrencyCode, assetQuote.Currency.ToCurrencyCode, assetQuote.Currency.Rate)
 
 		position := getPositionFromAssetQuote(assetQuote, lotsBySymbol, currencyRateByUse)
 		positionSummary = addPositionToPositionSummary(positionSummary, position, currencyRateByUse)
 
+		if cashFlows, ok := cashFlowsBySymbol[assetQuote.Symbol]; ok && assetQuote.QuoteSource != c.QuoteSourceCash {
+			position.AnnualizedReturn = getAnnualizedReturn(convertCashFlows(cashFlows, currencyRateByUse.PositionCost), position.Value, now)
+			summaryCashFlows = append(summaryCashFlows, convertCashFlows(cashFlows, currencyRateByUse.PositionCost*currencyRateByUse.SummaryCost)...)
+			summaryCashFlowsValue += position.Value * currencyRateByUse.SummaryValue
+		}
+
 		assets = append(assets, c.Asset{
 			Name:   assetQuote.Name,
 			Symbol: assetQuote.Symbol,
@@ -62,6 +108,7 @@ func GetAssets(ctx c.Context, assetGroupQuote c.AssetGroupQuote) ([]c.Asset, Pos
 			Position:      position,
 			QuotePrice:    convertAssetQuotePriceCurrency(currencyRateByUse, assetQuote.QuotePrice),
 			QuoteExtended: convertAssetQuoteExtendedCurrency(currencyRateByUse, assetQuote.QuoteExtended),
+			QuoteDepth:    convertAssetQuoteDepthCurrency(currencyRateByUse, assetQuote.QuoteDepth),
 			QuoteFutures:  assetQuote.QuoteFutures,
 			QuoteSource:   assetQuote.QuoteSource,
 			Exchange:      assetQuote.Exchange,
@@ -73,6 +120,13 @@ func GetAssets(ctx c.Context, assetGroupQuote c.AssetGroupQuote) ([]c.Asset, Pos
 
 	}
 
+	positionSummary.AnnualizedReturn = getAnnualizedReturn(summaryCashFlows, summaryCashFlowsValue, now)
+
+	if benchmark.Symbol != "" {
+		benchmark.RelativePercent = positionSummary.DayChange.Percent - benchmark.ChangePercent
+		positionSummary.Benchmark = benchmark
+	}
+
 	assets = updatePositionWeights(assets, positionSummary)
 
 	return assets, positionSummary
@@ -90,7 +144,11 @@ func calculateChangePercent(changeAmount float64, base float64) float64 {
 
 func addPositionToPositionSummary(positionSummary PositionSummary, position c.Position, currencyRateByUse currencyRateByUse) PositionSummary {
 
+	realized := positionSummary.Realized + (position.Realized * currencyRateByUse.SummaryCost)
+
 	if position.Value == 0 {
+		positionSummary.Realized = realized
+
 		return positionSummary
 	}
 
@@ -113,7 +171,28 @@ func addPositionToPositionSummary(positionSummary PositionSummary, position c.Po
 			Amount:  dayChange,
 			Percent: dayChangePercent,
 		},
+		Realized: realized,
+	}
+}
+
+// GetTotalPositionSummary returns the sum of position summaries which are in the same currency such as those of different asset groups
+// The annualized return and benchmark are not combined since they depend on the positions in each summary
+func GetTotalPositionSummary(positionSummaries []PositionSummary) PositionSummary {
+
+	var total PositionSummary
+
+	for _, positionSummary := range positionSummaries {
+		total.Value += positionSummary.Value
+		total.Cost += positionSummary.Cost
+		total.DayChange.Amount += positionSummary.DayChange.Amount
+		total.Realized += positionSummary.Realized
 	}
+
+	total.TotalChange.Amount = total.Value - total.Cost
+	total.TotalChange.Percent = calculateChangePercent(total.TotalChange.Amount, total.Cost)
+	total.DayChange.Percent = calculateChangePercent(total.DayChange.Amount, total.Value)
+
+	return total
 }
 
 func updatePositionWeights(assets []c.Asset, positionSummary PositionSummary) []c.Asset {
@@ -133,16 +212,7 @@ func updatePositionWeights(assets []c.Asset, positionSummary PositionSummary) []
 func getPositionFromAssetQuote(assetQuote c.AssetQuote, lotsBySymbol map[string]AggregatedLot, currencyRateByUse currencyRateByUse) c.Position {
 
 	if aggregatedLot, ok := lotsBySymbol[assetQuote.Symbol]; ok {
-		// For futures contracts, multiply price by contract size for PnL calculations
-		// The displayed price remains unchanged (uses QuotePrice.Price directly)
-		priceForPosition := assetQuote.QuotePrice.Price
-		changeForPosition := assetQuote.QuotePrice.Change
-
-		if assetQuote.Class == c.AssetClassFuturesContract {
-			contractSize := assetQuote.QuoteFutures.ContractSize
-			priceForPosition = assetQuote.QuotePrice.Price * contractSize
-			changeForPosition = assetQuote.QuotePrice.Change * contractSize
-		}
+		priceForPosition, changeForPosition := getPriceForPosition(assetQuote)
 
 		value := aggregatedLot.Quantity * priceForPosition * currencyRateByUse.QuotePrice
 		cost := aggregatedLot.Cost * currencyRateByUse.PositionCost
@@ -170,7 +240,8 @@ func getPositionFromAssetQuote(assetQuote c.AssetQuote, lotsBySymbol map[string]
 				Amount:  totalChangeAmount,
 				Percent: totalChangePercent,
 			},
-			Weight: 0,
+			Weight:   0,
+			Realized: aggregatedLot.Realized * currencyRateByUse.PositionCost,
 		}
 	}
 
@@ -178,6 +249,19 @@ func getPositionFromAssetQuote(assetQuote c.AssetQuote, lotsBySymbol map[string]
 
 }
 
+// getPriceForPosition returns the price and change of one unit of a position which for futures contracts is the price multiplied by the contract size
+// The displayed price remains unchanged (uses QuotePrice.Price directly)
+func getPriceForPosition(assetQuote c.AssetQuote) (float64, float64) {
+
+	if assetQuote.Class == c.AssetClassFuturesContract {
+		contractSize := assetQuote.QuoteFutures.ContractSize
+
+		return assetQuote.QuotePrice.Price * contractSize, assetQuote.QuotePrice.Change * contractSize
+	}
+
+	return assetQuote.QuotePrice.Price, assetQuote.QuotePrice.Change
+}
+
 func getLots(lots []c.Lot) map[string]AggregatedLot {
 
 	if lots == nil {
@@ -202,7 +286,7 @@ func getLots(lots []c.Lot) map[string]AggregatedLot {
 		} else {
 
 			aggregatedLot.Quantity += lot.Quantity
-			aggregatedLot.Cost += lot.Quantity * lot.UnitCost
+			aggregatedLot.Cost += (lot.Quantity * lot.UnitCost) + lot.FixedCost
 
 			aggregatedLots[lot.Symbol] = aggregatedLot
 
@@ -212,3 +296,25 @@ func getLots(lots []c.Lot) map[string]AggregatedLot {
 
 	return aggregatedLots
 }
+
+// mergeLots combines lots from the lots list with lots from transactions for the same symbol
+func mergeLots(lotsBySymbol map[string]AggregatedLot, transactionLots map[string]AggregatedLot) map[string]AggregatedLot {
+
+	for symbol, transactionLot := range transactionLots {
+		aggregatedLot, ok := lotsBySymbol[symbol]
+
+		if !ok {
+			lotsBySymbol[symbol] = transactionLot
+
+			continue
+		}
+
+		aggregatedLot.Quantity += transactionLot.Quantity
+		aggregatedLot.Cost += transactionLot.Cost
+		aggregatedLot.Realized += transactionLot.Realized
+
+		lotsBySymbol[symbol] = aggregatedLot
+	}
+
+	return lotsBySymbol
+}
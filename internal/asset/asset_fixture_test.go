@@ -1,9 +1,24 @@
 package asset_test
 
 import (
+	"slices"
+
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 )
 
+// getFixtureAssetGroupQuote returns a copy of the asset group quote fixture with its own slices so that a spec can change it without changing the fixture for other specs
+func getFixtureAssetGroupQuote() c.AssetGroupQuote {
+
+	assetGroupQuote := fixtureAssetGroupQuote
+	assetGroupQuote.AssetQuotes = slices.Clone(fixtureAssetGroupQuote.AssetQuotes)
+	assetGroupQuote.AssetGroup.SymbolsBySource = slices.Clone(fixtureAssetGroupQuote.AssetGroup.SymbolsBySource)
+	assetGroupQuote.AssetGroup.ConfigAssetGroup.Watchlist = slices.Clone(fixtureAssetGroupQuote.AssetGroup.ConfigAssetGroup.Watchlist)
+	assetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = slices.Clone(fixtureAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots)
+	assetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions = slices.Clone(fixtureAssetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions)
+
+	return assetGroupQuote
+}
+
 var fixtureAssetGroupQuote = c.AssetGroupQuote{
 	AssetGroup: c.AssetGroup{
 		ConfigAssetGroup: c.ConfigAssetGroup{
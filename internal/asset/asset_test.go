@@ -1,6 +1,8 @@
 package asset_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -13,7 +15,7 @@ var _ = Describe("Asset", func() {
 	Describe("GetAssets", func() {
 		It("should return assets", func() {
 			inputContext := c.Context{}
-			inputAssetGroupQuote := fixtureAssetGroupQuote
+			inputAssetGroupQuote := getFixtureAssetGroupQuote()
 
 			expectedAssets := fixtureAssets
 			expectedPositionSummary := PositionSummary{}
@@ -27,7 +29,7 @@ var _ = Describe("Asset", func() {
 		When("there are lots", func() {
 			It("should return assets with positions and a summary of positions", func() {
 				inputContext := c.Context{}
-				inputAssetGroupQuote := fixtureAssetGroupQuote
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
 				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
 					{
 						Symbol:    "TWKS",
@@ -78,6 +80,317 @@ var _ = Describe("Asset", func() {
 			})
 		})
 
+		When("there are multiple lots with a fixed cost for the same symbol", func() {
+			It("should include the fixed cost of each lot in the cost", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 100, Quantity: 10, FixedCost: 7},
+					{Symbol: "TWKS", UnitCost: 75, Quantity: 10, FixedCost: 3},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets[0].Position.Cost).To(Equal(1760.0))
+				Expect(outputPositionSummary.Cost).To(Equal(1760.0))
+			})
+		})
+
+		When("there are transactions", func() {
+
+			inputTransactions := []c.Transaction{
+				{Symbol: "TWKS", Type: c.TransactionTypeSell, Date: "2024-03-01", Quantity: 5, Price: 130, Fee: 5},
+				{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: "2024-01-01", Quantity: 10, Price: 100, Fee: 10},
+				{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: "2024-02-01", Quantity: 10, Price: 120},
+				{Symbol: "MSFT", Type: c.TransactionTypeFee, Date: "2024-02-15", Fee: 3},
+			}
+
+			DescribeTable("should return realized and unrealized gains for the cost basis method",
+				func(method c.CostBasisMethod, expectedCost float64, expectedRealized float64) {
+					inputContext := c.Context{Config: c.Config{CostBasisMethod: method}}
+					inputAssetGroupQuote := getFixtureAssetGroupQuote()
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions = inputTransactions
+
+					outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Position.Quantity).To(Equal(15.0))
+					Expect(outputAssets[0].Position.Value).To(Equal(1650.0))
+					Expect(outputAssets[0].Position.Cost).To(BeNumerically("~", expectedCost, 0.0001))
+					Expect(outputAssets[0].Position.TotalChange.Amount).To(BeNumerically("~", 1650.0-expectedCost, 0.0001))
+					Expect(outputAssets[0].Position.Realized).To(BeNumerically("~", expectedRealized, 0.0001))
+
+					Expect(outputAssets[1].Position.Quantity).To(Equal(0.0))
+					Expect(outputAssets[1].Position.Realized).To(Equal(-3.0))
+
+					Expect(outputPositionSummary.Value).To(Equal(1650.0))
+					Expect(outputPositionSummary.Cost).To(BeNumerically("~", expectedCost, 0.0001))
+					Expect(outputPositionSummary.Realized).To(BeNumerically("~", expectedRealized-3.0, 0.0001))
+				},
+				Entry("fifo", c.CostBasisMethodFIFO, 1705.0, 140.0),
+				Entry("default", c.CostBasisMethod(""), 1705.0, 140.0),
+				Entry("lifo", c.CostBasisMethodLIFO, 1610.0, 45.0),
+				Entry("average", c.CostBasisMethodAverage, 1657.5, 92.5),
+			)
+
+			When("and there are also lots for the same symbol", func() {
+				It("should combine the lots with the open units from transactions", func() {
+					inputContext := c.Context{}
+					inputAssetGroupQuote := getFixtureAssetGroupQuote()
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+						{Symbol: "TWKS", UnitCost: 90, Quantity: 5},
+					}
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions = inputTransactions
+
+					outputAssets, _ := GetAssets(inputContext, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Position.Quantity).To(Equal(20.0))
+					Expect(outputAssets[0].Position.Cost).To(Equal(2155.0))
+					Expect(outputAssets[0].Position.Realized).To(Equal(140.0))
+				})
+			})
+		})
+
+		When("there are lots with acquisition dates", func() {
+
+			dateYearsAgo := func(years int) string {
+				return time.Now().AddDate(-years, 0, 0).Format("2006-01-02")
+			}
+
+			It("should return the compound annual growth rate for a single lot", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 55, Quantity: 10, Date: dateYearsAgo(2)},
+					{Symbol: "MSFT", UnitCost: 200, Quantity: 10},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets[0].Position.AnnualizedReturn).To(BeNumerically("~", 41.42, 0.1))
+				Expect(outputAssets[1].Position.AnnualizedReturn).To(BeZero())
+				Expect(outputPositionSummary.AnnualizedReturn).To(Equal(outputAssets[0].Position.AnnualizedReturn))
+			})
+
+			It("should return the money-weighted rate of return for multiple lots", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 100, Quantity: 5, Date: dateYearsAgo(2)},
+					{Symbol: "TWKS", UnitCost: 100, Quantity: 5, Date: dateYearsAgo(1)},
+				}
+
+				outputAssets, _ := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets[0].Position.AnnualizedReturn).To(BeNumerically("~", 6.52, 0.1))
+			})
+
+			It("should include sells in the money-weighted rate of return", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions = []c.Transaction{
+					{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: dateYearsAgo(2), Quantity: 20, Price: 50},
+					{Symbol: "TWKS", Type: c.TransactionTypeSell, Date: dateYearsAgo(1), Quantity: 10, Price: 70},
+				}
+
+				outputAssets, _ := GetAssets(inputContext, inputAssetGroupQuote)
+
+				// -1000 at the start, 700 after one year, and 1100 after two years
+				Expect(outputAssets[0].Position.AnnualizedReturn).To(BeNumerically("~", 45.57, 0.1))
+			})
+
+			It("should distinguish the same gain over different holding periods", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 100, Quantity: 10, Date: dateYearsAgo(6)},
+					{Symbol: "MSFT", UnitCost: 200, Quantity: 10, Date: time.Now().AddDate(0, -6, 0).Format("2006-01-02")},
+				}
+
+				outputAssets, _ := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets[0].Position.TotalChange.Percent).To(BeNumerically("~", outputAssets[1].Position.TotalChange.Percent, 0.001))
+				Expect(outputAssets[0].Position.AnnualizedReturn).To(BeNumerically("~", 1.60, 0.1))
+				Expect(outputAssets[1].Position.AnnualizedReturn).To(BeNumerically("~", 21.0, 0.5))
+			})
+
+			It("should not annualize the return of a lot without a cost", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 0, Quantity: 10, Date: dateYearsAgo(2)},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets[0].Position.AnnualizedReturn).To(BeZero())
+				Expect(outputPositionSummary.AnnualizedReturn).To(BeZero())
+			})
+
+			It("should not annualize the return of a lot held for only a few days", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 55, Quantity: 10, Date: time.Now().AddDate(0, 0, -5).Format("2006-01-02")},
+				}
+
+				outputAssets, _ := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets[0].Position.TotalChange.Percent).To(BeNumerically("~", 100.0, 0.001))
+				Expect(outputAssets[0].Position.AnnualizedReturn).To(BeZero())
+			})
+		})
+
+		When("there is a benchmark", func() {
+
+			benchmarkAssetQuote := c.AssetQuote{
+				Name:       "SPDR S&P 500 ETF",
+				Symbol:     "SPY",
+				Class:      c.AssetClassStock,
+				Currency:   c.Currency{FromCurrencyCode: "USD"},
+				QuotePrice: c.QuotePrice{Price: 520.0, PricePrevClose: 500.0, Change: 20.0, ChangePercent: 4.0},
+			}
+
+			It("should compare the day change of the positions to the benchmark and exclude the benchmark from the assets and totals", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetQuotes = append(inputAssetGroupQuote.AssetQuotes, benchmarkAssetQuote)
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Benchmark = "SPY"
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "MSFT", UnitCost: 200, Quantity: 10},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets).To(HaveLen(3))
+				Expect(outputAssets[1].Position.Weight).To(Equal(100.0))
+				Expect(outputPositionSummary.Value).To(Equal(2200.0))
+				Expect(outputPositionSummary.Benchmark.Symbol).To(Equal("SPY"))
+				Expect(outputPositionSummary.Benchmark.ChangePercent).To(Equal(4.0))
+				Expect(outputPositionSummary.Benchmark.RelativePercent).To(BeNumerically("~", 5.09, 0.01))
+			})
+
+			When("the benchmark is also on the watchlist", func() {
+				It("should include the benchmark in the assets", func() {
+					inputContext := c.Context{}
+					inputAssetGroupQuote := getFixtureAssetGroupQuote()
+					inputAssetGroupQuote.AssetQuotes = append(inputAssetGroupQuote.AssetQuotes, benchmarkAssetQuote)
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Benchmark = "SPY"
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Watchlist = []string{"TWKS", "MSFT", "SOL1-USD", "SPY"}
+
+					outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+					Expect(outputAssets).To(HaveLen(4))
+					Expect(outputAssets[3].Symbol).To(Equal("SPY"))
+					Expect(outputPositionSummary.Benchmark.ChangePercent).To(Equal(4.0))
+				})
+			})
+
+			When("there is no quote for the benchmark", func() {
+				It("should not set the benchmark", func() {
+					inputContext := c.Context{}
+					inputAssetGroupQuote := getFixtureAssetGroupQuote()
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Benchmark = "SPY"
+
+					_, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+					Expect(outputPositionSummary.Benchmark).To(Equal(Benchmark{}))
+				})
+			})
+		})
+
+		When("there is a cash balance", func() {
+			It("should include the cash in the position summary value and weights", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetQuotes = append(inputAssetGroupQuote.AssetQuotes,
+					c.AssetQuote{
+						Name:        "Cash",
+						Symbol:      "USD.CASH",
+						Class:       c.AssetClassCash,
+						Currency:    c.Currency{FromCurrencyCode: "USD"},
+						QuotePrice:  c.QuotePrice{Price: 1.0, PricePrevClose: 1.0},
+						QuoteSource: c.QuoteSourceCash,
+					},
+					c.AssetQuote{
+						Name:        "Cash",
+						Symbol:      "EUR.CASH",
+						Class:       c.AssetClassCash,
+						Currency:    c.Currency{FromCurrencyCode: "EUR", ToCurrencyCode: "USD", Rate: 1.2},
+						QuotePrice:  c.QuotePrice{Price: 1.0, PricePrevClose: 1.0},
+						QuoteSource: c.QuoteSourceCash,
+					},
+				)
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "MSFT", UnitCost: 200, Quantity: 10},
+					{Symbol: "USD.CASH", UnitCost: 1, Quantity: 1000},
+					{Symbol: "EUR.CASH", UnitCost: 1, Quantity: 500},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets).To(HaveLen(5))
+				Expect(outputAssets[3].Position.Value).To(Equal(1000.0))
+				Expect(outputAssets[3].Position.TotalChange.Amount).To(BeZero())
+				Expect(outputAssets[3].Position.DayChange.Amount).To(BeZero())
+				Expect(outputAssets[3].Position.Weight).To(BeNumerically("~", 26.32, 0.01))
+				Expect(outputPositionSummary.Value).To(Equal(3800.0))
+				Expect(outputPositionSummary.Cost).To(Equal(3600.0))
+			})
+
+			When("and unit cost conversion is disabled", func() {
+				It("should convert the cost of the cash balance", func() {
+					inputContext := c.Context{Config: c.Config{Currency: "USD", CurrencyDisableUnitCostConversion: true}}
+					inputAssetGroupQuote := getFixtureAssetGroupQuote()
+					inputAssetGroupQuote.AssetQuotes = []c.AssetQuote{
+						{
+							Name:        "Cash",
+							Symbol:      "EUR.CASH",
+							Class:       c.AssetClassCash,
+							Currency:    c.Currency{FromCurrencyCode: "EUR", ToCurrencyCode: "USD", Rate: 1.2},
+							QuotePrice:  c.QuotePrice{Price: 1.0, PricePrevClose: 1.0},
+							QuoteSource: c.QuoteSourceCash,
+						},
+					}
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+						{Symbol: "EUR.CASH", UnitCost: 1, Quantity: 500},
+					}
+
+					outputAssets, _ := GetAssets(inputContext, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Position.Value).To(Equal(600.0))
+					Expect(outputAssets[0].Position.Cost).To(Equal(600.0))
+					Expect(outputAssets[0].Position.TotalChange.Amount).To(BeZero())
+				})
+			})
+		})
+
+		When("there are lots for a private security", func() {
+			It("should include the private security in the position summary and weights", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetQuotes = append(inputAssetGroupQuote.AssetQuotes, c.AssetQuote{
+					Name:        "Acme Corp Series A",
+					Symbol:      "ACME",
+					Class:       c.AssetClassPrivateSecurity,
+					QuotePrice:  c.QuotePrice{Price: 12.0, PricePrevClose: 10.0, Change: 2.0, ChangePercent: 20.0},
+					QuoteSource: c.QuoteSourceManual,
+				})
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "MSFT", UnitCost: 200, Quantity: 10},
+					{Symbol: "ACME", UnitCost: 10, Quantity: 100},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets).To(HaveLen(4))
+				Expect(outputAssets[3].Position.Value).To(Equal(1200.0))
+				Expect(outputAssets[3].Position.DayChange.Amount).To(Equal(200.0))
+				Expect(outputAssets[3].Position.Weight).To(BeNumerically("~", 35.29, 0.01))
+				Expect(outputPositionSummary.Value).To(Equal(3400.0))
+				Expect(outputPositionSummary.Cost).To(Equal(3000.0))
+			})
+		})
+
 		When("there is a currency to convert", func() {
 
 			inputContext := c.Context{
@@ -86,7 +399,7 @@ var _ = Describe("Asset", func() {
 				},
 				Reference: c.Reference{},
 			}
-			inputAssetGroupQuote := fixtureAssetGroupQuote
+			inputAssetGroupQuote := getFixtureAssetGroupQuote()
 			inputAssetGroupQuote.AssetQuotes = []c.AssetQuote{
 				{
 					Name:          "ThoughtWorks",
@@ -202,7 +515,7 @@ var _ = Describe("Asset", func() {
 				inputContext := c.Context{
 					Reference: c.Reference{},
 				}
-				inputAssetGroupQuote := fixtureAssetGroupQuote
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
 				inputAssetQuotes := make([]c.AssetQuote, len(fixtureAssetGroupQuote.AssetQuotes))
 				copy(inputAssetQuotes, fixtureAssetGroupQuote.AssetQuotes)
 				inputAssetQuotes[0].Currency.FromCurrencyCode = "EUR"
@@ -248,7 +561,7 @@ var _ = Describe("Asset", func() {
 			When("and fixed cost is also zero", func() {
 				It("should handle zero cost without division by zero", func() {
 					inputContext := c.Context{}
-					inputAssetGroupQuote := fixtureAssetGroupQuote
+					inputAssetGroupQuote := getFixtureAssetGroupQuote()
 					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
 						{
 							Symbol:    "TWKS",
@@ -295,7 +608,7 @@ var _ = Describe("Asset", func() {
 			When("but fixed cost is non-zero", func() {
 				It("should use fixed cost as the total cost", func() {
 					inputContext := c.Context{}
-					inputAssetGroupQuote := fixtureAssetGroupQuote
+					inputAssetGroupQuote := getFixtureAssetGroupQuote()
 					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
 						{
 							Symbol:    "TWKS",
@@ -328,7 +641,7 @@ var _ = Describe("Asset", func() {
 		When("unit cost is undefined (defaults to zero)", func() {
 			It("should handle undefined unit cost the same as zero", func() {
 				inputContext := c.Context{}
-				inputAssetGroupQuote := fixtureAssetGroupQuote
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
 				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
 					{
 						Symbol:    "TWKS",
@@ -358,7 +671,7 @@ var _ = Describe("Asset", func() {
 		When("aggregated quantity is zero", func() {
 			It("should handle a net zero position", func() {
 				inputContext := c.Context{}
-				inputAssetGroupQuote := fixtureAssetGroupQuote
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
 				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
 					{
 						Symbol:    "TWKS",
@@ -462,4 +775,39 @@ var _ = Describe("Asset", func() {
 		})
 
 	})
+
+	Describe("GetTotalPositionSummary", func() {
+		It("should sum the values and costs and recalculate the change percentages", func() {
+			output := GetTotalPositionSummary([]PositionSummary{
+				{
+					Value:       1100,
+					Cost:        1000,
+					DayChange:   c.PositionChange{Amount: 100, Percent: 9.09},
+					TotalChange: c.PositionChange{Amount: 100, Percent: 10},
+					Realized:    50,
+				},
+				{
+					Value:       900,
+					Cost:        600,
+					DayChange:   c.PositionChange{Amount: -20, Percent: -2.22},
+					TotalChange: c.PositionChange{Amount: 300, Percent: 50},
+					Realized:    -10,
+				},
+			})
+
+			Expect(output.Value).To(Equal(2000.0))
+			Expect(output.Cost).To(Equal(1600.0))
+			Expect(output.DayChange.Amount).To(Equal(80.0))
+			Expect(output.DayChange.Percent).To(Equal(4.0))
+			Expect(output.TotalChange.Amount).To(Equal(400.0))
+			Expect(output.TotalChange.Percent).To(Equal(25.0))
+			Expect(output.Realized).To(Equal(40.0))
+		})
+
+		When("there are no position summaries", func() {
+			It("should return an empty position summary", func() {
+				Expect(GetTotalPositionSummary(nil)).To(Equal(PositionSummary{}))
+			})
+		})
+	})
 })
@@ -14,7 +14,7 @@ type currencyRateByUse struct { //nolint:golint,revive
 }
 
 // getCurrencyRateByUse reads currency rates from the context and sets the conversion rate for each use case
-func getCurrencyRateByUse(ctx c.Context, assetClass c.AssetClass, fromCurrency string, toCurrency string, rate float64) currencyRateByUse {
+func getCurrencyRateByUse(ctx c.Context, assetClass c.AssetClass, quoteSource c.QuoteSource, fromCurrency string, toCurrency string, rate float64) currencyRateByUse {
 
 	// Skip currency conversion for currency pairs (e.g., KRW=X) when explicitly watched
 	if assetClass == c.AssetClassCurrency {
@@ -39,7 +39,8 @@ func getCurrencyRateByUse(ctx c.Context, assetClass c.AssetClass, fromCurrency s
 
 	currencyRateCost := rate
 
-	if ctx.Config.CurrencyDisableUnitCostConversion {
+	// Cash has no unit cost in another currency so its cost is always converted at the current rate
+	if ctx.Config.CurrencyDisableUnitCostConversion && quoteSource != c.QuoteSourceCash {
 		currencyRateCost = 1.0
 	}
 
@@ -95,3 +96,14 @@ func convertAssetQuoteExtendedCurrency(currencyRateByUse currencyRateByUse, quot
 		Volume:           quoteExtended.Volume,
 	}
 }
+
+func convertAssetQuoteDepthCurrency(currencyRateByUse currencyRateByUse, quoteDepth c.QuoteDepth) c.QuoteDepth {
+	return c.QuoteDepth{
+		BidPrice:      quoteDepth.BidPrice * currencyRateByUse.QuotePrice,
+		BidSize:       quoteDepth.BidSize,
+		AskPrice:      quoteDepth.AskPrice * currencyRateByUse.QuotePrice,
+		AskSize:       quoteDepth.AskSize,
+		Spread:        quoteDepth.Spread * currencyRateByUse.QuotePrice,
+		SpreadPercent: quoteDepth.SpreadPercent,
+	}
+}
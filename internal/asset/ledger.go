@@ -0,0 +1,193 @@
+package asset
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// quantityTolerance absorbs floating point error when a sell closes out all open units
+const quantityTolerance = 1e-9
+
+type openLot struct {
+	quantity float64
+	unitCost float64
+	date     string
+}
+
+type ledger struct {
+	symbol     string
+	lots       []openLot
+	realized   float64
+	orderIndex int
+}
+
+// GetTransactionLots replays transactions in date order, matching sells to buys with the cost basis method, and returns the open quantity, cost, and realized gain or loss by symbol
+func GetTransactionLots(transactions []c.Transaction, method c.CostBasisMethod) (map[string]AggregatedLot, error) {
+
+	aggregatedLots := map[string]AggregatedLot{}
+
+	ledgers, err := getLedgers(transactions, method)
+
+	for symbol, l := range ledgers {
+		aggregatedLot := AggregatedLot{
+			Symbol:     symbol,
+			Realized:   l.realized,
+			OrderIndex: l.orderIndex,
+		}
+
+		for _, lot := range l.lots {
+			aggregatedLot.Quantity += lot.quantity
+			aggregatedLot.Cost += lot.quantity * lot.unitCost
+		}
+
+		aggregatedLots[symbol] = aggregatedLot
+	}
+
+	return aggregatedLots, err
+}
+
+// GetTransactionOpenLots replays transactions in date order and returns the lots which are still open after sells are matched with the cost basis method
+func GetTransactionOpenLots(transactions []c.Transaction, method c.CostBasisMethod) ([]c.Lot, error) {
+
+	lots := make([]c.Lot, 0)
+
+	ledgers, err := getLedgers(transactions, method)
+
+	symbols := make([]string, 0, len(ledgers))
+	for symbol := range ledgers {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		return ledgers[symbols[i]].orderIndex < ledgers[symbols[j]].orderIndex
+	})
+
+	for _, symbol := range symbols {
+		for _, lot := range ledgers[symbol].lots {
+			lots = append(lots, c.Lot{
+				Symbol:   symbol,
+				UnitCost: lot.unitCost,
+				Quantity: lot.quantity,
+				Date:     lot.date,
+			})
+		}
+	}
+
+	return lots, err
+}
+
+// getLedgers applies transactions in date order to a ledger for each symbol and returns the first sell which could not be matched as an error
+func getLedgers(transactions []c.Transaction, method c.CostBasisMethod) (map[string]*ledger, error) {
+
+	var err error
+	ledgers := make(map[string]*ledger)
+	sortedTransactions := make([]c.Transaction, len(transactions))
+	copy(sortedTransactions, transactions)
+
+	sort.SliceStable(sortedTransactions, func(i, j int) bool {
+		return sortedTransactions[i].Date < sortedTransactions[j].Date
+	})
+
+	for i, transaction := range sortedTransactions {
+
+		l, ok := ledgers[transaction.Symbol]
+
+		if !ok {
+			l = &ledger{symbol: transaction.Symbol, orderIndex: i}
+			ledgers[transaction.Symbol] = l
+		}
+
+		if transactionErr := l.apply(transaction, method); transactionErr != nil && err == nil {
+			err = transactionErr
+		}
+	}
+
+	return ledgers, err
+}
+
+func (l *ledger) apply(transaction c.Transaction, method c.CostBasisMethod) error {
+
+	switch transaction.Type {
+	case c.TransactionTypeBuy:
+		l.buy(transaction, method)
+	case c.TransactionTypeSell:
+		return l.sell(transaction, method)
+	case c.TransactionTypeFee:
+		l.realized -= transaction.Fee
+	case c.TransactionTypeSplit:
+		for i := range l.lots {
+			l.lots[i].quantity *= transaction.Ratio
+			l.lots[i].unitCost /= transaction.Ratio
+		}
+	}
+
+	return nil
+}
+
+// buy opens a lot with the fee included in the cost of each unit
+func (l *ledger) buy(transaction c.Transaction, method c.CostBasisMethod) {
+
+	if transaction.Quantity == 0 {
+		return
+	}
+
+	lot := openLot{
+		quantity: transaction.Quantity,
+		unitCost: transaction.Price + (transaction.Fee / transaction.Quantity),
+		date:     transaction.Date,
+	}
+
+	// Average cost keeps a single lot with the cost of all units pooled together and the date of the earliest buy
+	if method == c.CostBasisMethodAverage && len(l.lots) > 0 {
+		pooled := l.lots[0]
+		quantity := pooled.quantity + lot.quantity
+		l.lots[0] = openLot{
+			quantity: quantity,
+			unitCost: ((pooled.quantity * pooled.unitCost) + (lot.quantity * lot.unitCost)) / quantity,
+			date:     pooled.date,
+		}
+
+		return
+	}
+
+	l.lots = append(l.lots, lot)
+}
+
+// sell closes units from open lots in the order of the cost basis method and realizes the difference between proceeds and cost
+func (l *ledger) sell(transaction c.Transaction, method c.CostBasisMethod) error {
+
+	remaining := transaction.Quantity
+	cost := 0.0
+
+	for remaining > quantityTolerance && len(l.lots) > 0 {
+		i := 0
+		if method == c.CostBasisMethodLIFO {
+			i = len(l.lots) - 1
+		}
+
+		matched := min(remaining, l.lots[i].quantity)
+		cost += matched * l.lots[i].unitCost
+		remaining -= matched
+		l.lots[i].quantity -= matched
+
+		if l.lots[i].quantity <= quantityTolerance {
+			l.lots = append(l.lots[:i], l.lots[i+1:]...)
+		}
+	}
+
+	sold := transaction.Quantity - max(remaining, 0)
+	l.realized += (sold * transaction.Price) - transaction.Fee - cost
+
+	if remaining > quantityTolerance {
+		return fmt.Errorf("sell of %s %s on %s exceeds the open quantity of %s", formatQuantity(transaction.Quantity), l.symbol, transaction.Date, formatQuantity(sold)) //nolint:goerr113
+	}
+
+	return nil
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
@@ -0,0 +1,119 @@
+package asset_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+
+	. "github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+var _ = Describe("Ledger", func() {
+
+	Describe("GetTransactionLots", func() {
+
+		When("there are no transactions", func() {
+			It("should return no lots", func() {
+				output, err := GetTransactionLots(nil, c.CostBasisMethodFIFO)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(BeEmpty())
+			})
+		})
+
+		DescribeTable("should match a sell to open units by the cost basis method",
+			func(method c.CostBasisMethod, expectedCost float64, expectedRealized float64) {
+				output, err := GetTransactionLots([]c.Transaction{
+					{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: "2024-01-01", Quantity: 10, Price: 100},
+					{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: "2024-02-01", Quantity: 10, Price: 120},
+					{Symbol: "TWKS", Type: c.TransactionTypeSell, Date: "2024-03-01", Quantity: 5, Price: 130},
+				}, method)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(HaveKeyWithValue("TWKS", g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Quantity": Equal(15.0),
+					"Cost":     BeNumerically("~", expectedCost, 0.0001),
+					"Realized": BeNumerically("~", expectedRealized, 0.0001),
+				})))
+			},
+			Entry("fifo sells the earliest units", c.CostBasisMethodFIFO, 1700.0, 150.0),
+			Entry("lifo sells the latest units", c.CostBasisMethodLIFO, 1600.0, 50.0),
+			Entry("average sells units at the pooled unit cost", c.CostBasisMethodAverage, 1650.0, 100.0),
+		)
+
+		When("there are fees on a buy and a sell", func() {
+			It("should include the buy fee in the cost and deduct the sell fee from the realized gain", func() {
+				output, err := GetTransactionLots([]c.Transaction{
+					{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: "2024-01-01", Quantity: 10, Price: 100, Fee: 10},
+					{Symbol: "TWKS", Type: c.TransactionTypeSell, Date: "2024-03-01", Quantity: 4, Price: 120, Fee: 6},
+				}, c.CostBasisMethodFIFO)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(HaveKeyWithValue("TWKS", g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Quantity": Equal(6.0),
+					"Cost":     BeNumerically("~", 606.0, 0.0001),
+					"Realized": BeNumerically("~", 70.0, 0.0001),
+				})))
+			})
+		})
+
+		When("there is a split", func() {
+			It("should multiply the open quantity without changing the cost", func() {
+				output, err := GetTransactionLots([]c.Transaction{
+					{Symbol: "NVDA", Type: c.TransactionTypeBuy, Date: "2024-01-01", Quantity: 10, Price: 500},
+					{Symbol: "NVDA", Type: c.TransactionTypeSplit, Date: "2024-06-10", Ratio: 10},
+					{Symbol: "NVDA", Type: c.TransactionTypeSell, Date: "2024-07-01", Quantity: 50, Price: 120},
+				}, c.CostBasisMethodFIFO)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(HaveKeyWithValue("NVDA", g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Quantity": Equal(50.0),
+					"Cost":     Equal(2500.0),
+					"Realized": Equal(3500.0),
+				})))
+			})
+		})
+
+		When("all units are sold", func() {
+			It("should return a lot with no quantity or cost and the realized gain", func() {
+				output, err := GetTransactionLots([]c.Transaction{
+					{Symbol: "AAPL", Type: c.TransactionTypeBuy, Date: "2024-01-01", Quantity: 0.1, Price: 150},
+					{Symbol: "AAPL", Type: c.TransactionTypeBuy, Date: "2024-01-02", Quantity: 0.2, Price: 150},
+					{Symbol: "AAPL", Type: c.TransactionTypeSell, Date: "2024-02-01", Quantity: 0.3, Price: 160},
+				}, c.CostBasisMethodLIFO)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output["AAPL"].Quantity).To(Equal(0.0))
+				Expect(output["AAPL"].Cost).To(Equal(0.0))
+				Expect(output["AAPL"].Realized).To(BeNumerically("~", 3.0, 0.0001))
+			})
+		})
+
+		When("a sell exceeds the open quantity", func() {
+			It("should return an error and realize the units which could be matched", func() {
+				output, err := GetTransactionLots([]c.Transaction{
+					{Symbol: "AAPL", Type: c.TransactionTypeBuy, Date: "2024-01-01", Quantity: 5, Price: 100},
+					{Symbol: "AAPL", Type: c.TransactionTypeSell, Date: "2024-02-01", Quantity: 8, Price: 110},
+				}, c.CostBasisMethodFIFO)
+
+				Expect(err).To(MatchError("sell of 8 AAPL on 2024-02-01 exceeds the open quantity of 5"))
+				Expect(output["AAPL"].Quantity).To(Equal(0.0))
+				Expect(output["AAPL"].Realized).To(Equal(50.0))
+			})
+		})
+
+		When("transactions are for multiple symbols", func() {
+			It("should order symbols by their first transaction", func() {
+				output, err := GetTransactionLots([]c.Transaction{
+					{Symbol: "MSFT", Type: c.TransactionTypeBuy, Date: "2024-02-01", Quantity: 1, Price: 400},
+					{Symbol: "AAPL", Type: c.TransactionTypeBuy, Date: "2024-01-01", Quantity: 1, Price: 150},
+				}, c.CostBasisMethodFIFO)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output["AAPL"].OrderIndex).To(Equal(0))
+				Expect(output["MSFT"].OrderIndex).To(Equal(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package asset
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// HoldingPeriod is the tax classification of a lot based on how long it has been held
+type HoldingPeriod string
+
+const (
+	HoldingPeriodShortTerm HoldingPeriod = "short-term"
+	HoldingPeriodLongTerm  HoldingPeriod = "long-term"
+)
+
+// LotPosition represents a single cost basis lot valued at the current price
+type LotPosition struct {
+	Symbol        string
+	Name          string
+	Currency      string
+	Date          string // Acquisition date which is empty when not set on the lot
+	Quantity      float64
+	UnitCost      float64
+	Cost          float64
+	Value         float64
+	TotalChange   c.PositionChange // Unrealized gain or loss of the lot
+	DaysHeld      int              // Days since the acquisition date or -1 when there is no date
+	HoldingPeriod HoldingPeriod    // Empty when there is no acquisition date
+}
+
+// GetLotPositions returns each lot and open transaction lot in an asset group individually rather than aggregated by symbol
+func GetLotPositions(ctx c.Context, assetGroupQuote c.AssetGroupQuote, now time.Time) []LotPosition {
+
+	assetQuotesBySymbol := make(map[string]c.AssetQuote)
+	for _, assetQuote := range assetGroupQuote.AssetQuotes {
+		assetQuotesBySymbol[strings.ToUpper(assetQuote.Symbol)] = assetQuote
+	}
+
+	// Transactions which can not be matched are reported when the config is validated so the error is ignored here
+	transactionLots, _ := GetTransactionOpenLots(assetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions, ctx.Config.CostBasisMethod)
+	lots := append(append([]c.Lot{}, assetGroupQuote.AssetGroup.ConfigAssetGroup.Lots...), transactionLots...)
+
+	lotPositions := make([]LotPosition, 0, len(lots))
+
+	for _, lot := range lots {
+
+		assetQuote, ok := assetQuotesBySymbol[strings.ToUpper(lot.Symbol)]
+
+		// Cash balances are not tax lots
+		if ok && assetQuote.QuoteSource == c.QuoteSourceCash {
+			continue
+		}
+
+		lotPositions = append(lotPositions, getLotPosition(ctx, lot, assetQuote, now))
+	}
+
+	return lotPositions
+}
+
+func getLotPosition(ctx c.Context, lot c.Lot, assetQuote c.AssetQuote, now time.Time) LotPosition {
+
+	currencyRateByUse := getCurrencyRateByUse(ctx, assetQuote.Class, assetQuote.QuoteSource, assetQuote.Currency.FromCurrencyCode, assetQuote.Currency.ToCurrencyCode, assetQuote.Currency.Rate)
+	priceForPosition, _ := getPriceForPosition(assetQuote)
+
+	value := lot.Quantity * priceForPosition * currencyRateByUse.QuotePrice
+	cost := ((lot.Quantity * lot.UnitCost) + lot.FixedCost) * currencyRateByUse.PositionCost
+	totalChangeAmount := value - cost
+
+	name := assetQuote.Name
+	if name == "" {
+		name = lot.Symbol
+	}
+
+	lotPosition := LotPosition{
+		Symbol:   lot.Symbol,
+		Name:     name,
+		Currency: currencyRateByUse.ToCurrencyCode,
+		Date:     lot.Date,
+		Quantity: lot.Quantity,
+		UnitCost: lot.UnitCost * currencyRateByUse.PositionCost,
+		Cost:     cost,
+		Value:    value,
+		TotalChange: c.PositionChange{
+			Amount:  totalChangeAmount,
+			Percent: calculateChangePercent(totalChangeAmount, cost),
+		},
+		DaysHeld: -1,
+	}
+
+	acquired, err := time.Parse("2006-01-02", lot.Date)
+	if err != nil {
+		return lotPosition
+	}
+
+	// Compare calendar dates so the time of day does not change the days held
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	lotPosition.DaysHeld = int(math.Round(today.Sub(acquired).Hours() / 24))
+	lotPosition.HoldingPeriod = getHoldingPeriod(acquired, today)
+
+	return lotPosition
+}
+
+// getHoldingPeriod returns long-term for lots held for more than one year and short-term otherwise
+func getHoldingPeriod(acquired time.Time, today time.Time) HoldingPeriod {
+
+	if today.After(acquired.AddDate(1, 0, 0)) {
+		return HoldingPeriodLongTerm
+	}
+
+	return HoldingPeriodShortTerm
+}
@@ -0,0 +1,95 @@
+package asset_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+
+	. "github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+var _ = Describe("Lots", func() {
+
+	Describe("GetLotPositions", func() {
+
+		now := time.Date(2025, 3, 1, 15, 30, 0, 0, time.UTC)
+
+		It("should return each lot individually with its value, gain, and holding period", func() {
+			inputContext := c.Context{}
+			inputAssetGroupQuote := getFixtureAssetGroupQuote()
+			inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+				{Symbol: "TWKS", UnitCost: 100, Quantity: 10, FixedCost: 7, Date: "2024-02-28"},
+				{Symbol: "TWKS", UnitCost: 75, Quantity: 10, Date: "2024-03-01"},
+				{Symbol: "MSFT", UnitCost: 400, Quantity: 10},
+			}
+
+			output := GetLotPositions(inputContext, inputAssetGroupQuote, now)
+
+			Expect(output).To(HaveLen(3))
+			Expect(output[0]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+				"Symbol":        Equal("TWKS"),
+				"Name":          Equal("ThoughtWorks"),
+				"Date":          Equal("2024-02-28"),
+				"Quantity":      Equal(10.0),
+				"Cost":          Equal(1007.0),
+				"Value":         Equal(1100.0),
+				"TotalChange":   g.MatchFields(g.IgnoreExtras, g.Fields{"Amount": Equal(93.0)}),
+				"DaysHeld":      Equal(367),
+				"HoldingPeriod": Equal(HoldingPeriodLongTerm),
+			}))
+			Expect(output[1]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+				"Cost":          Equal(750.0),
+				"Value":         Equal(1100.0),
+				"DaysHeld":      Equal(365),
+				"HoldingPeriod": Equal(HoldingPeriodShortTerm),
+			}))
+			Expect(output[2]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+				"Symbol":        Equal("MSFT"),
+				"Value":         Equal(2200.0),
+				"DaysHeld":      Equal(-1),
+				"HoldingPeriod": BeEmpty(),
+			}))
+		})
+
+		When("there are transactions", func() {
+			It("should return the lots which are still open with the date of the buy", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Transactions = []c.Transaction{
+					{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: "2024-01-02", Quantity: 10, Price: 100},
+					{Symbol: "TWKS", Type: c.TransactionTypeBuy, Date: "2025-01-02", Quantity: 10, Price: 120},
+					{Symbol: "TWKS", Type: c.TransactionTypeSell, Date: "2025-02-01", Quantity: 15, Price: 130},
+				}
+
+				output := GetLotPositions(inputContext, inputAssetGroupQuote, now)
+
+				Expect(output).To(HaveLen(1))
+				Expect(output[0]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol":        Equal("TWKS"),
+					"Date":          Equal("2025-01-02"),
+					"Quantity":      Equal(5.0),
+					"Cost":          Equal(600.0),
+					"HoldingPeriod": Equal(HoldingPeriodShortTerm),
+				}))
+			})
+		})
+
+		When("there is a cash balance", func() {
+			It("should not return the cash balance", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := getFixtureAssetGroupQuote()
+				inputAssetGroupQuote.AssetQuotes = []c.AssetQuote{
+					{Symbol: "USD.CASH", Class: c.AssetClassCash, QuotePrice: c.QuotePrice{Price: 1}, QuoteSource: c.QuoteSourceCash},
+				}
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "USD.CASH", UnitCost: 1, Quantity: 1000},
+				}
+
+				Expect(GetLotPositions(inputContext, inputAssetGroupQuote, now)).To(BeEmpty())
+			})
+		})
+	})
+})
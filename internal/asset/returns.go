@@ -0,0 +1,187 @@
+package asset
+
+import (
+	"math"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	daysPerYear = 365.0
+	xirrRateMin = -0.999999
+	xirrRateMax = 1e6
+	// Holding periods shorter than this compound to extreme rates when annualized
+	minDaysAnnualized = 30.0
+	// Annualized returns beyond this percent are not meaningful and do not fit in the return column
+	maxAnnualizedReturn = 10000.0
+)
+
+// cashFlow is an amount paid (negative) or received (positive) for a position on a date
+type cashFlow struct {
+	date   time.Time
+	amount float64
+}
+
+// getCashFlowsBySymbol returns the dated purchases and sales for each symbol from lots and transactions
+// Symbols with a lot without an acquisition date are omitted since their return can not be annualized
+func getCashFlowsBySymbol(lots []c.Lot, transactions []c.Transaction) map[string][]cashFlow {
+
+	cashFlowsBySymbol := make(map[string][]cashFlow)
+	undated := make(map[string]bool)
+
+	for _, lot := range lots {
+		date, err := time.Parse("2006-01-02", lot.Date)
+		if err != nil {
+			undated[lot.Symbol] = true
+
+			continue
+		}
+
+		cashFlowsBySymbol[lot.Symbol] = append(cashFlowsBySymbol[lot.Symbol], cashFlow{
+			date:   date,
+			amount: -((lot.Quantity * lot.UnitCost) + lot.FixedCost),
+		})
+	}
+
+	for _, transaction := range transactions {
+		date, err := time.Parse("2006-01-02", transaction.Date)
+		if err != nil {
+			undated[transaction.Symbol] = true
+
+			continue
+		}
+
+		var amount float64
+
+		switch transaction.Type {
+		case c.TransactionTypeBuy:
+			amount = -((transaction.Quantity * transaction.Price) + transaction.Fee)
+		case c.TransactionTypeSell:
+			amount = (transaction.Quantity * transaction.Price) - transaction.Fee
+		case c.TransactionTypeFee:
+			amount = -transaction.Fee
+		default:
+			continue
+		}
+
+		cashFlowsBySymbol[transaction.Symbol] = append(cashFlowsBySymbol[transaction.Symbol], cashFlow{
+			date:   date,
+			amount: amount,
+		})
+	}
+
+	for symbol := range undated {
+		delete(cashFlowsBySymbol, symbol)
+	}
+
+	return cashFlowsBySymbol
+}
+
+// convertCashFlows returns the cash flows multiplied by a currency rate
+func convertCashFlows(cashFlows []cashFlow, rate float64) []cashFlow {
+
+	converted := make([]cashFlow, len(cashFlows))
+
+	for i, flow := range cashFlows {
+		converted[i] = cashFlow{date: flow.date, amount: flow.amount * rate}
+	}
+
+	return converted
+}
+
+// getAnnualizedReturn returns the annual rate of return as a percent given the cash flows and the current value, using CAGR for a single purchase and money-weighted XIRR otherwise
+// Zero is returned when there are no cash flows, nothing was paid, the holding period is too short, or the rate can not be determined
+func getAnnualizedReturn(cashFlows []cashFlow, value float64, now time.Time) float64 {
+
+	if len(cashFlows) == 0 {
+		return 0
+	}
+
+	start := cashFlows[0].date
+	cost := 0.0
+
+	for _, flow := range cashFlows {
+		if flow.date.Before(start) {
+			start = flow.date
+		}
+
+		if flow.amount < 0 {
+			cost -= flow.amount
+		}
+	}
+
+	if cost <= 0 || now.Sub(start).Hours()/24 < minDaysAnnualized {
+		return 0
+	}
+
+	var annualizedReturn float64
+
+	if len(cashFlows) == 1 {
+		if value < 0 {
+			return 0
+		}
+
+		years := now.Sub(start).Hours() / 24 / daysPerYear
+		annualizedReturn = (math.Pow(value/cost, 1/years) - 1) * 100
+	} else {
+		rate, ok := xirr(append(cashFlows, cashFlow{date: now, amount: value}))
+		if !ok {
+			return 0
+		}
+
+		annualizedReturn = rate * 100
+	}
+
+	if math.IsNaN(annualizedReturn) || math.IsInf(annualizedReturn, 0) || annualizedReturn > maxAnnualizedReturn {
+		return 0
+	}
+
+	return annualizedReturn
+}
+
+// xirr returns the annual rate at which the net present value of the cash flows is zero found by bisection
+func xirr(cashFlows []cashFlow) (float64, bool) {
+
+	start := cashFlows[0].date
+	for _, flow := range cashFlows {
+		if flow.date.Before(start) {
+			start = flow.date
+		}
+	}
+
+	npv := func(rate float64) float64 {
+		total := 0.0
+		for _, flow := range cashFlows {
+			years := flow.date.Sub(start).Hours() / 24 / daysPerYear
+			total += flow.amount / math.Pow(1+rate, years)
+		}
+
+		return total
+	}
+
+	low, high := xirrRateMin, xirrRateMax
+	npvLow, npvHigh := npv(low), npv(high)
+
+	// There is no rate when the net present value has the same sign at both bounds (e.g. only purchases or only sales)
+	if math.IsNaN(npvLow) || math.IsNaN(npvHigh) || (npvLow > 0) == (npvHigh > 0) {
+		return 0, false
+	}
+
+	for range 200 {
+		mid := (low + high) / 2
+		npvMid := npv(mid)
+
+		if math.Abs(npvMid) < 1e-9 || (high-low) < 1e-12 {
+			return mid, true
+		}
+
+		if (npvMid > 0) == (npvLow > 0) {
+			low, npvLow = mid, npvMid
+		} else {
+			high = mid
+		}
+	}
+
+	return (low + high) / 2, true
+}
@@ -8,8 +8,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/achannarasappa/ticker/v5/internal/asset"
 	"github.com/achannarasappa/ticker/v5/internal/cli/symbol"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor"
+	monitorPriceCash "github.com/achannarasappa/ticker/v5/internal/monitor/cash/monitor-price"
 	"github.com/achannarasappa/ticker/v5/internal/ui/util"
 
 	"github.com/adrg/xdg"
@@ -30,6 +33,8 @@ type Options struct {
 	ShowSummary           bool
 	ShowHoldings          bool // Deprecated: use ShowPositions instead, kept for backwards compatibility
 	ShowPositions         bool // Preferred field name
+	ShowSparkline         bool
+	ShowReturns           bool
 	Sort                  string
 }
 
@@ -67,6 +72,153 @@ func validateLot(lot c.Lot, groupName string, lotIndex int) error {
 		return fmt.Errorf("invalid config: lot #%d for symbol '%s' in group '%s' has invalid fixed_cost (must be zero or positive, got %f)", lotIndex+1, lot.Symbol, groupName, lot.FixedCost) //nolint:goerr113
 	}
 
+	if _, err := time.Parse("2006-01-02", lot.Date); lot.Date != "" && err != nil {
+		return fmt.Errorf("invalid config: lot #%d for symbol '%s' in group '%s' has invalid date (must be YYYY-MM-DD, got '%s')", lotIndex+1, lot.Symbol, groupName, lot.Date) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validateTransaction validates a single transaction and returns an error if invalid
+func validateTransaction(transaction c.Transaction, groupName string, transactionIndex int) error {
+	if transaction.Symbol == "" {
+		return fmt.Errorf("invalid config: transaction #%d in group '%s' has empty symbol", transactionIndex+1, groupName) //nolint:goerr113
+	}
+
+	if _, err := time.Parse("2006-01-02", transaction.Date); err != nil {
+		return fmt.Errorf("invalid config: transaction #%d for symbol '%s' in group '%s' has invalid date (must be YYYY-MM-DD, got '%s')", transactionIndex+1, transaction.Symbol, groupName, transaction.Date) //nolint:goerr113
+	}
+
+	if transaction.Price < 0 || transaction.Fee < 0 {
+		return fmt.Errorf("invalid config: transaction #%d for symbol '%s' in group '%s' has a negative price or fee", transactionIndex+1, transaction.Symbol, groupName) //nolint:goerr113
+	}
+
+	switch transaction.Type {
+	case c.TransactionTypeBuy, c.TransactionTypeSell:
+		if transaction.Quantity <= 0 {
+			return fmt.Errorf("invalid config: transaction #%d for symbol '%s' in group '%s' has invalid quantity (must be positive, got %f)", transactionIndex+1, transaction.Symbol, groupName, transaction.Quantity) //nolint:goerr113
+		}
+	case c.TransactionTypeSplit:
+		if transaction.Ratio <= 0 {
+			return fmt.Errorf("invalid config: transaction #%d for symbol '%s' in group '%s' has invalid ratio (must be positive, got %f)", transactionIndex+1, transaction.Symbol, groupName, transaction.Ratio) //nolint:goerr113
+		}
+	case c.TransactionTypeFee:
+	default:
+		return fmt.Errorf("invalid config: transaction #%d for symbol '%s' in group '%s' has invalid type (must be buy, sell, fee, or split, got '%s')", transactionIndex+1, transaction.Symbol, groupName, transaction.Type) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validateTransactions validates each transaction and that no sell exceeds the units held at the time and returns an error if invalid
+func validateTransactions(transactions []c.Transaction, method c.CostBasisMethod, groupName string) error {
+	for i, transaction := range transactions {
+		if err := validateTransaction(transaction, groupName, i); err != nil {
+			return err
+		}
+	}
+
+	if _, err := asset.GetTransactionLots(transactions, method); err != nil {
+		return fmt.Errorf("invalid config: %w in group '%s'", err, groupName)
+	}
+
+	return nil
+}
+
+// validateCash validates a single cash balance and returns an error if invalid
+func validateCash(cash c.ConfigCash, groupName string, cashIndex int) error {
+	if len(cash.Currency) != 3 {
+		return fmt.Errorf("invalid config: cash #%d in group '%s' has invalid currency (must be a three letter currency code, got '%s')", cashIndex+1, groupName, cash.Currency) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validateSourceUserDefined validates a single user defined source and returns an error if invalid
+func validateSourceUserDefined(source c.ConfigSourceUserDefined, sourceIndex int) error {
+	if source.Name == "" {
+		return fmt.Errorf("invalid config: source #%d has empty name", sourceIndex+1) //nolint:goerr113
+	}
+
+	if !strings.Contains(source.URL, "{symbol}") {
+		return fmt.Errorf("invalid config: source '%s' has invalid url (must contain {symbol}, got '%s')", source.Name, source.URL) //nolint:goerr113
+	}
+
+	if len(source.Symbols) == 0 {
+		return fmt.Errorf("invalid config: source '%s' has no symbols", source.Name) //nolint:goerr113
+	}
+
+	if source.Fields.Price == "" {
+		return fmt.Errorf("invalid config: source '%s' has no field mapping for price", source.Name) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validateAlert validates a single alert rule and returns an error if invalid
+func validateAlert(rule c.ConfigAlert, alertIndex int) error {
+	if rule.Symbol == "" {
+		return fmt.Errorf("invalid config: alert #%d has empty symbol", alertIndex+1) //nolint:goerr113
+	}
+
+	if rule.PriceAbove < 0 || rule.PriceBelow < 0 || rule.ChangePercent < 0 {
+		return fmt.Errorf("invalid config: alert for symbol '%s' has a negative threshold", rule.Symbol) //nolint:goerr113
+	}
+
+	if rule.PriceAbove == 0 && rule.PriceBelow == 0 && rule.ChangePercent == 0 && !rule.FiftyTwoWeekHigh && !rule.FiftyTwoWeekLow {
+		return fmt.Errorf("invalid config: alert for symbol '%s' has no conditions", rule.Symbol) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validateNotifyRule validates a single notification rule and returns an error if invalid
+func validateNotifyRule(rule c.ConfigNotifyRule, ruleIndex int) error {
+	if rule.Symbol == "" {
+		return fmt.Errorf("invalid config: notify rule #%d has empty symbol", ruleIndex+1) //nolint:goerr113
+	}
+
+	if rule.PriceAbove < 0 || rule.PriceBelow < 0 || rule.ChangePercent < 0 {
+		return fmt.Errorf("invalid config: notify rule for symbol '%s' has a negative threshold", rule.Symbol) //nolint:goerr113
+	}
+
+	if rule.PriceAbove == 0 && rule.PriceBelow == 0 && rule.ChangePercent == 0 {
+		return fmt.Errorf("invalid config: notify rule for symbol '%s' has no thresholds", rule.Symbol) //nolint:goerr113
+	}
+
+	if rule.Webhook == "" && rule.Command == "" {
+		return fmt.Errorf("invalid config: notify rule for symbol '%s' has no webhook or command", rule.Symbol) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validatePrivateSecurity validates a single private security and its lots and returns an error if invalid
+func validatePrivateSecurity(security c.ConfigPrivateSecurity, groupName string, securityIndex int) error {
+	if security.Symbol == "" {
+		return fmt.Errorf("invalid config: private security #%d in group '%s' has empty symbol", securityIndex+1, groupName) //nolint:goerr113
+	}
+
+	if len(security.Valuations) == 0 {
+		return fmt.Errorf("invalid config: private security '%s' in group '%s' has no valuations", security.Symbol, groupName) //nolint:goerr113
+	}
+
+	for i, valuation := range security.Valuations {
+		if _, err := time.Parse("2006-01-02", valuation.Date); err != nil {
+			return fmt.Errorf("invalid config: valuation #%d for private security '%s' in group '%s' has invalid date (must be YYYY-MM-DD, got '%s')", i+1, security.Symbol, groupName, valuation.Date) //nolint:goerr113
+		}
+
+		if valuation.Price < 0 {
+			return fmt.Errorf("invalid config: valuation #%d for private security '%s' in group '%s' has invalid price (must be zero or positive, got %f)", i+1, security.Symbol, groupName, valuation.Price) //nolint:goerr113
+		}
+	}
+
+	for i, lot := range getPrivateSecurityLots(security) {
+		if err := validateLot(lot, groupName, i); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -78,10 +230,16 @@ func Validate(config *c.Config, options *Options, prevErr *error) func(*cobra.Co
 			return *prevErr
 		}
 
-		if len(config.Watchlist) == 0 && len(options.Watchlist) == 0 && len(config.Lots) == 0 && len(config.AssetGroup) == 0 {
+		if len(config.Watchlist) == 0 && len(options.Watchlist) == 0 && len(config.Lots) == 0 && len(config.AssetGroup) == 0 && len(config.PrivateSecurities) == 0 && len(config.Transactions) == 0 && len(config.Cash) == 0 {
 			return errors.New("invalid config: No watchlist provided") //nolint:goerr113
 		}
 
+		switch config.CostBasisMethod {
+		case "", c.CostBasisMethodFIFO, c.CostBasisMethodLIFO, c.CostBasisMethodAverage:
+		default:
+			return fmt.Errorf("invalid config: cost-basis-method must be fifo, lifo, or average, got '%s'", config.CostBasisMethod) //nolint:goerr113
+		}
+
 		// Validate lots in config.Lots (default group)
 		for i, lot := range config.Lots {
 			if err := validateLot(lot, "default", i); err != nil {
@@ -89,12 +247,32 @@ func Validate(config *c.Config, options *Options, prevErr *error) func(*cobra.Co
 			}
 		}
 
+		for i, security := range config.PrivateSecurities {
+			if err := validatePrivateSecurity(security, "default", i); err != nil {
+				return err
+			}
+		}
+
+		if err := validateTransactions(config.Transactions, config.CostBasisMethod, "default"); err != nil {
+			return err
+		}
+
+		for i, cash := range config.Cash {
+			if err := validateCash(cash, "default", i); err != nil {
+				return err
+			}
+		}
+
 		// Validate lots in config.AssetGroup
 		for _, assetGroup := range config.AssetGroup {
 			groupName := assetGroup.Name
 			if groupName == "" {
 				groupName = "unnamed"
 			}
+			// The total across all groups is printed as a row with this name
+			if strings.EqualFold(groupName, "total") {
+				return fmt.Errorf("invalid config: group name '%s' is reserved for the total across all groups", groupName) //nolint:goerr113
+			}
 			// Use Lots (preferred), otherwise fall back to Holdings for backwards compatibility
 			lots := assetGroup.Lots
 			if len(lots) == 0 {
@@ -105,6 +283,37 @@ func Validate(config *c.Config, options *Options, prevErr *error) func(*cobra.Co
 					return err
 				}
 			}
+			for i, security := range assetGroup.PrivateSecurities {
+				if err := validatePrivateSecurity(security, groupName, i); err != nil {
+					return err
+				}
+			}
+			if err := validateTransactions(assetGroup.Transactions, config.CostBasisMethod, groupName); err != nil {
+				return err
+			}
+			for i, cash := range assetGroup.Cash {
+				if err := validateCash(cash, groupName, i); err != nil {
+					return err
+				}
+			}
+		}
+
+		for i, source := range config.SourcesUserDefined {
+			if err := validateSourceUserDefined(source, i); err != nil {
+				return err
+			}
+		}
+
+		for i, rule := range config.Alerts {
+			if err := validateAlert(rule, i); err != nil {
+				return err
+			}
+		}
+
+		for i, rule := range config.Notify.Rules {
+			if err := validateNotifyRule(rule, i); err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -121,19 +330,50 @@ func GetDependencies() c.Dependencies {
 		MonitorYahooSessionCrumbURL:      "https://query2.finance.yahoo.com",
 		MonitorYahooSessionConsentURL:    "https://consent.yahoo.com",
 		MonitorPriceCoinbaseBaseURL:      "https://api.coinbase.com",
+		MonitorPriceCoingeckoBaseURL:     "https://api.coingecko.com",
+		MonitorPriceCoinCapBaseURL:       "https://api.coincap.io",
+		MonitorPriceCoinCapStreamingURL:  "wss://ws.coincap.io/prices",
 		MonitorPriceCoinbaseStreamingURL: "wss://ws-feed.exchange.coinbase.com",
 	}
 }
 
 // GetContext builds the context from the config and reference data
 func GetContext(d c.Dependencies, config c.Config) (c.Context, error) {
+
+	tickerSymbolToSourceSymbol, err := symbol.GetTickerSymbols(d.SymbolsURL)
+
+	if err != nil {
+		return c.Context{}, err
+	}
+
+	return getContext(d, config, tickerSymbolToSourceSymbol)
+}
+
+// GetContextReplay builds the context for replaying a recording without retrieving symbol reference data so that it can be used offline
+func GetContextReplay(d c.Dependencies, config c.Config) (c.Context, error) {
+
+	context, err := getContext(d, config, symbol.TickerSymbolToSourceSymbol{})
+
+	if err != nil {
+		return c.Context{}, err
+	}
+
+	// Replay all symbols in the recording when there are no groups configured
+	if len(context.Groups) == 0 {
+		context.Groups = []c.AssetGroup{{ConfigAssetGroup: c.ConfigAssetGroup{Name: "replay"}}}
+	}
+
+	return context, nil
+}
+
+func getContext(d c.Dependencies, config c.Config, tickerSymbolToSourceSymbol symbol.TickerSymbolToSourceSymbol) (c.Context, error) {
 	var (
 		reference c.Reference
 		groups    []c.AssetGroup
 		err       error
 	)
 
-	groups, err = getGroups(config, d)
+	groups, err = getGroups(config, tickerSymbolToSourceSymbol)
 
 	if err != nil {
 		return c.Context{}, err
@@ -213,6 +453,8 @@ func GetConfig(dep c.Dependencies, configPath string, options Options) (c.Config
 	config.ExtraInfoExchange = getBoolOption(options.ExtraInfoExchange, config.ExtraInfoExchange)
 	config.ExtraInfoFundamentals = getBoolOption(options.ExtraInfoFundamentals, config.ExtraInfoFundamentals)
 	config.ShowSummary = getBoolOption(options.ShowSummary, config.ShowSummary)
+	config.ShowSparkline = getBoolOption(options.ShowSparkline, config.ShowSparkline)
+	config.ShowReturns = getBoolOption(options.ShowReturns, config.ShowReturns)
 	// Merge ShowHoldings into ShowPositions with positions taking precedence
 	// First check if Positions is set (CLI or config), then fall back to Holdings if not
 	showPositionsFromCLI := options.ShowPositions
@@ -296,27 +538,39 @@ func getStringOption(cliValue string, configValue string) string {
 	return ""
 }
 
-func getGroups(config c.Config, d c.Dependencies) ([]c.AssetGroup, error) {
+func getGroups(config c.Config, tickerSymbolToSourceSymbol symbol.TickerSymbolToSourceSymbol) ([]c.AssetGroup, error) {
 
 	groups := make([]c.AssetGroup, 0)
 	var configAssetGroups []c.ConfigAssetGroup
 
-	tickerSymbolToSourceSymbol, err := symbol.GetTickerSymbols(d.SymbolsURL)
-
-	if err != nil {
-		return []c.AssetGroup{}, err
-	}
-
-	if len(config.Watchlist) > 0 || len(config.Lots) > 0 {
+	if len(config.Watchlist) > 0 || len(config.Lots) > 0 || len(config.PrivateSecurities) > 0 || len(config.Transactions) > 0 || len(config.Cash) > 0 {
 		configAssetGroups = append(configAssetGroups, c.ConfigAssetGroup{
-			Name:      "default",
-			Watchlist: config.Watchlist,
-			Lots:      config.Lots,
+			Name:              "default",
+			Watchlist:         config.Watchlist,
+			Lots:              config.Lots,
+			Transactions:      config.Transactions,
+			Cash:              config.Cash,
+			PrivateSecurities: config.PrivateSecurities,
+			Benchmark:         config.Benchmark,
 		})
 	}
 
 	configAssetGroups = append(configAssetGroups, config.AssetGroup...)
 
+	// Symbols listed on a user defined source are routed to that source
+	for _, source := range config.SourcesUserDefined {
+		for _, sourceSymbol := range source.Symbols {
+			tickerSymbolToSourceSymbol = addSymbolSource(tickerSymbolToSourceSymbol, sourceSymbol, c.QuoteSourceUserDefined)
+		}
+	}
+
+	// Private securities are valued from configuration rather than a remote source
+	for _, configAssetGroup := range configAssetGroups {
+		for _, security := range configAssetGroup.PrivateSecurities {
+			tickerSymbolToSourceSymbol = addSymbolSource(tickerSymbolToSourceSymbol, strings.ToUpper(security.Symbol), c.QuoteSourceManual)
+		}
+	}
+
 	for _, configAssetGroup := range configAssetGroups {
 
 		symbols := make(map[string]bool)
@@ -335,9 +589,23 @@ func getGroups(config c.Config, d c.Dependencies) ([]c.AssetGroup, error) {
 		mergedConfigAssetGroup := configAssetGroup
 		if len(lots) == 0 {
 			lots = configAssetGroup.Holdings
-			mergedConfigAssetGroup.Lots = lots
 		}
 
+		for _, security := range configAssetGroup.PrivateSecurities {
+			securitySymbol := strings.ToUpper(security.Symbol)
+			if !symbols[securitySymbol] {
+				symbols[securitySymbol] = true
+				symbolAndSource := getSymbolAndSource(securitySymbol, tickerSymbolToSourceSymbol)
+				symbolsUnique = appendSymbol(symbolsUnique, symbolAndSource)
+			}
+			lots = append(lots, getPrivateSecurityLots(security)...)
+		}
+
+		// Cash balances are positions in a cash symbol for the currency which is always priced at one unit of the currency
+		lots = append(lots, getCashLots(configAssetGroup.Cash)...)
+
+		mergedConfigAssetGroup.Lots = lots
+
 		for _, lot := range lots {
 			if !symbols[lot.Symbol] {
 				symbols[lot.Symbol] = true
@@ -346,6 +614,26 @@ func getGroups(config c.Config, d c.Dependencies) ([]c.AssetGroup, error) {
 			}
 		}
 
+		for _, transaction := range configAssetGroup.Transactions {
+			if !symbols[transaction.Symbol] {
+				symbols[transaction.Symbol] = true
+				symbolAndSource := getSymbolAndSource(transaction.Symbol, tickerSymbolToSourceSymbol)
+				symbolsUnique = appendSymbol(symbolsUnique, symbolAndSource)
+			}
+		}
+
+		// The benchmark is fetched with the other symbols in the group but is not shown unless it is also on the watchlist or in a lot
+		if configAssetGroup.Benchmark != "" {
+			benchmarkSymbol := strings.ToUpper(configAssetGroup.Benchmark)
+			mergedConfigAssetGroup.Benchmark = benchmarkSymbol
+
+			if !symbols[benchmarkSymbol] {
+				symbols[benchmarkSymbol] = true
+				symbolAndSource := getSymbolAndSource(benchmarkSymbol, tickerSymbolToSourceSymbol)
+				symbolsUnique = appendSymbol(symbolsUnique, symbolAndSource)
+			}
+		}
+
 		for _, symbolsBySource := range symbolsUnique {
 			assetGroupSymbolsBySource = append(assetGroupSymbolsBySource, symbolsBySource)
 		}
@@ -377,42 +665,79 @@ func getSymbolAndSource(symbol string, tickerSymbolToSourceSymbol symbol.TickerS
 
 	symbolUppercase := strings.ToUpper(symbol)
 
-	if strings.HasSuffix(symbolUppercase, ".CB") {
+	tickerSymbolToSource, exists := tickerSymbolToSourceSymbol[symbolUppercase]
 
-		symbol = strings.ToUpper(symbol)[:len(symbol)-3]
+	// Symbols from sources defined in configuration take precedence over the registered sources
+	if exists && (tickerSymbolToSource.Source == c.QuoteSourceUserDefined || tickerSymbolToSource.Source == c.QuoteSourceManual) {
 
-		// Futures contracts on Coinbase Derivatives Exchange are implicitly USD-denominated
-		if strings.HasSuffix(symbol, "-CDE") {
-			return symbolSource{
-				source: c.QuoteSourceCoinbase,
-				symbol: symbol,
-			}
+		return symbolSource{
+			source: tickerSymbolToSource.Source,
+			symbol: tickerSymbolToSource.SourceSymbol,
 		}
 
+	}
+
+	// Ticker specific symbols (.X suffix) are the only symbols looked up in the remote ticker symbol map
+	if exists && strings.HasSuffix(symbolUppercase, ".X") {
+
 		return symbolSource{
-			source: c.QuoteSourceCoinbase,
-			symbol: symbol + "-USD",
+			source: tickerSymbolToSource.Source,
+			symbol: tickerSymbolToSource.SourceSymbol,
 		}
+
 	}
 
-	if strings.HasSuffix(symbolUppercase, ".X") {
+	source, sourceSymbol := monitor.DefaultRegistry().MatchSymbol(symbol)
 
-		if tickerSymbolToSource, exists := tickerSymbolToSourceSymbol[symbolUppercase]; exists {
+	return symbolSource{
+		source: source,
+		symbol: sourceSymbol,
+	}
 
-			return symbolSource{
-				source: tickerSymbolToSource.Source,
-				symbol: tickerSymbolToSource.SourceSymbol,
-			}
+}
 
-		}
+// addSymbolSource routes a symbol to a source defined in configuration
+// The symbol is matched and shown in uppercase and the source is called with the symbol as configured since sources may be case sensitive
+func addSymbolSource(tickerSymbolToSourceSymbol symbol.TickerSymbolToSourceSymbol, sourceSymbol string, source c.QuoteSource) symbol.TickerSymbolToSourceSymbol {
 
+	tickerSymbol := strings.ToUpper(sourceSymbol)
+
+	tickerSymbolToSourceSymbol[tickerSymbol] = symbol.SymbolSourceMap{
+		TickerSymbol: tickerSymbol,
+		SourceSymbol: sourceSymbol,
+		Source:       source,
 	}
 
-	return symbolSource{
-		source: c.QuoteSourceYahoo,
-		symbol: symbolUppercase,
+	return tickerSymbolToSourceSymbol
+}
+
+// getPrivateSecurityLots returns the lots for a private security with the symbol of the security set on each lot
+func getPrivateSecurityLots(security c.ConfigPrivateSecurity) []c.Lot {
+
+	lots := make([]c.Lot, 0, len(security.Lots))
+
+	for _, lot := range security.Lots {
+		lot.Symbol = strings.ToUpper(security.Symbol)
+		lots = append(lots, lot)
+	}
+
+	return lots
+}
+
+// getCashLots returns a lot for each cash balance with a unit cost of one unit of the currency
+func getCashLots(cash []c.ConfigCash) []c.Lot {
+
+	lots := make([]c.Lot, 0, len(cash))
+
+	for _, balance := range cash {
+		lots = append(lots, c.Lot{
+			Symbol:   monitorPriceCash.GetSymbol(balance.Currency),
+			Quantity: balance.Amount,
+			UnitCost: 1.0,
+		})
 	}
 
+	return lots
 }
 
 func appendSymbol(symbolsUnique map[c.QuoteSource]c.AssetGroupSymbolsBySource, symbolAndSource symbolSource) map[c.QuoteSource]c.AssetGroupSymbolsBySource {
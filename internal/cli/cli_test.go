@@ -87,6 +87,7 @@ var _ = Describe("Cli", func() {
 "ETH.X","ETH-USD","cb"
 "SOL.X","SOL-USD","cb"
 "XRP.X","XRP-USD","cb"
+"DOGE","DOGE-USD","cb"
 `
 		server.RouteToHandler("GET", "/symbols.csv",
 			ghttp.CombineHandlers(
@@ -302,6 +303,7 @@ var _ = Describe("Cli", func() {
 						"  - ADA.CB",             // coinbase
 						"  - BIT-31JAN25-CDE.CB", // coinbase futures
 						"  - SOL.X",              // ticker
+						"  - PEPE.CG",            // coingecko
 					}, "\n"),
 					AssertionErr: BeNil(),
 					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
@@ -316,6 +318,12 @@ var _ = Describe("Cli", func() {
 										}),
 										"Source": Equal(c.QuoteSourceYahoo),
 									}),
+									"2": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("pepe"),
+										}),
+										"Source": Equal(c.QuoteSourceCoingecko),
+									}),
 									"5": g.MatchFields(g.IgnoreExtras, g.Fields{
 										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
 											"0": Equal("ADA-USD"),
@@ -329,6 +337,238 @@ var _ = Describe("Cli", func() {
 						}),
 					}),
 				}),
+				Entry("when a symbol is listed on a user defined source", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"watchlist:",
+						"  - TSLA",
+						"  - fund1",
+						"sources:",
+						"  - name: my-broker",
+						"    url: https://example.com/quote/{symbol}",
+						"    symbols:",
+						"      - FUND1",
+						"    fields:",
+						"      price: data.price",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"SymbolsBySource": g.MatchAllElements(func(element interface{}) string {
+									return strconv.FormatInt(int64(element.(c.AssetGroupSymbolsBySource).Source), 10)
+								}, g.Elements{
+									"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("TSLA"),
+										}),
+										"Source": Equal(c.QuoteSourceYahoo),
+									}),
+									"1": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("FUND1"),
+										}),
+										"Source": Equal(c.QuoteSourceUserDefined),
+									}),
+								}),
+							}),
+						}),
+					}),
+				}),
+				Entry("when a symbol is listed on a user defined source in lowercase", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"watchlist:",
+						"  - FUND1",
+						"sources:",
+						"  - name: my-broker",
+						"    url: https://example.com/quote/{symbol}",
+						"    symbols:",
+						"      - fund1",
+						"    fields:",
+						"      price: data.price",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"SymbolsBySource": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+									"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("fund1"),
+										}),
+										"Source": Equal(c.QuoteSourceUserDefined),
+									}),
+								}),
+							}),
+						}),
+					}),
+				}),
+				Entry("when a symbol without the .X suffix is in the ticker symbol map", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"watchlist:",
+						"  - DOGE",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"SymbolsBySource": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+									"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("DOGE"),
+										}),
+										"Source": Equal(c.QuoteSourceYahoo),
+									}),
+								}),
+							}),
+						}),
+					}),
+				}),
+				Entry("when transactions are set", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"cost-basis-method: lifo",
+						"transactions:",
+						"  - symbol: TSLA",
+						"    type: buy",
+						"    date: 2024-01-02",
+						"    quantity: 10",
+						"    price: 250",
+						"    fee: 1",
+						"  - symbol: TSLA",
+						"    type: sell",
+						"    date: 2024-03-01",
+						"    quantity: 5",
+						"    price: 200",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Config": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"CostBasisMethod": Equal(c.CostBasisMethodLIFO),
+						}),
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"ConfigAssetGroup": g.MatchFields(g.IgnoreExtras, g.Fields{
+									"Name": Equal("default"),
+									"Transactions": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+										"0": Equal(c.Transaction{Symbol: "TSLA", Type: c.TransactionTypeBuy, Date: "2024-01-02", Quantity: 10, Price: 250, Fee: 1}),
+										"1": Equal(c.Transaction{Symbol: "TSLA", Type: c.TransactionTypeSell, Date: "2024-03-01", Quantity: 5, Price: 200}),
+									}),
+								}),
+								"SymbolsBySource": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+									"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("TSLA"),
+										}),
+										"Source": Equal(c.QuoteSourceYahoo),
+									}),
+								}),
+							}),
+						}),
+					}),
+				}),
+				Entry("when cash balances are set", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"groups:",
+						"  - name: savings",
+						"    cash:",
+						"      - currency: eur",
+						"        amount: 2500",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"ConfigAssetGroup": g.MatchFields(g.IgnoreExtras, g.Fields{
+									"Name": Equal("savings"),
+									"Lots": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+										"0": Equal(c.Lot{Symbol: "EUR.CASH", Quantity: 2500, UnitCost: 1}),
+									}),
+								}),
+								"SymbolsBySource": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+									"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("EUR.CASH"),
+										}),
+										"Source": Equal(c.QuoteSourceCash),
+									}),
+								}),
+							}),
+						}),
+					}),
+				}),
+				Entry("when a benchmark is set", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"groups:",
+						"  - name: growth",
+						"    watchlist:",
+						"      - TSLA",
+						"    benchmark: spy",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"ConfigAssetGroup": g.MatchFields(g.IgnoreExtras, g.Fields{
+									"Name":      Equal("growth"),
+									"Benchmark": Equal("SPY"),
+								}),
+								"SymbolsBySource": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+									"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("TSLA"),
+											"1": Equal("SPY"),
+										}),
+										"Source": Equal(c.QuoteSourceYahoo),
+									}),
+								}),
+							}),
+						}),
+					}),
+				}),
+				Entry("when private securities are set", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"private-securities:",
+						"  - symbol: acme",
+						"    name: Acme Corp Series A",
+						"    valuations:",
+						"      - date: 2025-06-30",
+						"        price: 12.0",
+						"    lots:",
+						"      - quantity: 1000",
+						"        unit_cost: 1.5",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"ConfigAssetGroup": g.MatchFields(g.IgnoreExtras, g.Fields{
+									"Name": Equal("default"),
+									"Lots": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+										"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+											"Symbol":   Equal("ACME"),
+											"Quantity": Equal(1000.0),
+											"UnitCost": Equal(1.5),
+										}),
+									}),
+								}),
+								"SymbolsBySource": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+									"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("ACME"),
+										}),
+										"Source": Equal(c.QuoteSourceManual),
+									}),
+								}),
+							}),
+						}),
+					}),
+				}),
 			)
 
 		})
@@ -370,6 +610,47 @@ var _ = Describe("Cli", func() {
 
 	})
 
+	Describe("GetContextReplay", func() {
+
+		It("should build groups without retrieving ticker symbols", func() {
+			dep := c.Dependencies{
+				Fs:         afero.NewMemMapFs(),
+				SymbolsURL: "invalid-url",
+			}
+
+			outputCtx, outputErr := GetContextReplay(dep, c.Config{Watchlist: []string{"GME", "BTC.X"}})
+
+			Expect(outputErr).To(BeNil())
+			Expect(outputCtx.Groups).To(g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+				"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+					"ConfigAssetGroup": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Name":      Equal("default"),
+						"Watchlist": Equal([]string{"GME", "BTC.X"}),
+					}),
+				}),
+			}))
+		})
+
+		When("there are no groups configured", func() {
+
+			It("should add a group to replay all symbols in the recording", func() {
+				outputCtx, outputErr := GetContextReplay(c.Dependencies{Fs: afero.NewMemMapFs()}, c.Config{})
+
+				Expect(outputErr).To(BeNil())
+				Expect(outputCtx.Groups).To(g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+					"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ConfigAssetGroup": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Name":      Equal("replay"),
+							"Watchlist": BeEmpty(),
+						}),
+					}),
+				}))
+			})
+
+		})
+
+	})
+
 	Describe("GetConfig", func() {
 
 		Context("options and configuration", func() {
@@ -456,6 +737,42 @@ var _ = Describe("Cli", func() {
 					}),
 				}),
 
+				Entry("when show-sparkline is set in config file", Case{
+					InputOptions:            cli.Options{},
+					InputConfigFileContents: "show-sparkline: true",
+					AssertionErr:            BeNil(),
+					AssertionConfig: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ShowSparkline": Equal(true),
+					}),
+				}),
+
+				Entry("when show-sparkline is set in options", Case{
+					InputOptions:            cli.Options{ShowSparkline: true},
+					InputConfigFileContents: "",
+					AssertionErr:            BeNil(),
+					AssertionConfig: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ShowSparkline": Equal(true),
+					}),
+				}),
+
+				Entry("when show-returns is set in config file", Case{
+					InputOptions:            cli.Options{},
+					InputConfigFileContents: "show-returns: true",
+					AssertionErr:            BeNil(),
+					AssertionConfig: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ShowReturns": Equal(true),
+					}),
+				}),
+
+				Entry("when show-returns is set in options", Case{
+					InputOptions:            cli.Options{ShowReturns: true},
+					InputConfigFileContents: "",
+					AssertionErr:            BeNil(),
+					AssertionConfig: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ShowReturns": Equal(true),
+					}),
+				}),
+
 				// option: debug
 				Entry("when debug is set in config file", Case{
 					InputOptions:            cli.Options{},
@@ -724,6 +1041,23 @@ var _ = Describe("Cli", func() {
 				})
 			})
 
+			When("lot has an invalid date", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Lots: []c.Lot{
+							{
+								Symbol:   "SYM",
+								UnitCost: 1.0,
+								Quantity: 1.0,
+								Date:     "2024-13-01",
+							},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("invalid date (must be YYYY-MM-DD, got '2024-13-01')")))
+				})
+			})
+
 			When("lot has zero unit cost and zero fixed cost", func() {
 				It("should not return an error", func() {
 					config = c.Config{
@@ -802,6 +1136,21 @@ var _ = Describe("Cli", func() {
 				})
 			})
 
+			When("an asset group is named total", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						AssetGroup: []c.ConfigAssetGroup{
+							{
+								Name:      "Total",
+								Watchlist: []string{"SYM"},
+							},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: group name 'Total' is reserved for the total across all groups"))
+				})
+			})
+
 			When("multiple lots have validation errors", func() {
 				It("should return the first error", func() {
 					config = c.Config{
@@ -824,5 +1173,253 @@ var _ = Describe("Cli", func() {
 			})
 		})
 
+		Describe("user defined source validation", func() {
+
+			var source c.ConfigSourceUserDefined
+
+			BeforeEach(func() {
+				source = c.ConfigSourceUserDefined{
+					Name:    "my-broker",
+					URL:     "https://example.com/quote/{symbol}",
+					Symbols: []string{"FUND1"},
+					Fields: c.ConfigSourceUserDefinedFieldMapping{
+						Price: "data.price",
+					},
+				}
+			})
+
+			DescribeTable("invalid sources",
+				func(modify func(*c.ConfigSourceUserDefined), expectedErr string) {
+					modify(&source)
+					config = c.Config{
+						Watchlist:          []string{"FUND1"},
+						SourcesUserDefined: []c.ConfigSourceUserDefined{source},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring(expectedErr)))
+				},
+				Entry("empty name", func(s *c.ConfigSourceUserDefined) { s.Name = "" }, "source #1 has empty name"),
+				Entry("url without symbol placeholder", func(s *c.ConfigSourceUserDefined) { s.URL = "https://example.com" }, "source 'my-broker' has invalid url"),
+				Entry("no symbols", func(s *c.ConfigSourceUserDefined) { s.Symbols = nil }, "source 'my-broker' has no symbols"),
+				Entry("no price field", func(s *c.ConfigSourceUserDefined) { s.Fields.Price = "" }, "source 'my-broker' has no field mapping for price"),
+			)
+
+			When("the source is valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Watchlist:          []string{"FUND1"},
+						SourcesUserDefined: []c.ConfigSourceUserDefined{source},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("alert validation", func() {
+
+			var rule c.ConfigAlert
+
+			BeforeEach(func() {
+				rule = c.ConfigAlert{
+					Symbol:     "AAPL",
+					PriceAbove: 200,
+				}
+			})
+
+			DescribeTable("invalid alerts",
+				func(modify func(*c.ConfigAlert), expectedErr string) {
+					modify(&rule)
+					config = c.Config{
+						Watchlist: []string{"AAPL"},
+						Alerts:    []c.ConfigAlert{rule},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring(expectedErr)))
+				},
+				Entry("empty symbol", func(r *c.ConfigAlert) { r.Symbol = "" }, "alert #1 has empty symbol"),
+				Entry("negative threshold", func(r *c.ConfigAlert) { r.ChangePercent = -5 }, "alert for symbol 'AAPL' has a negative threshold"),
+				Entry("no conditions", func(r *c.ConfigAlert) { r.PriceAbove = 0 }, "alert for symbol 'AAPL' has no conditions"),
+			)
+
+			When("the alert is valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Watchlist: []string{"AAPL"},
+						Alerts:    []c.ConfigAlert{rule, {Symbol: "MSFT", FiftyTwoWeekLow: true}},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("notify rule validation", func() {
+
+			var rule c.ConfigNotifyRule
+
+			BeforeEach(func() {
+				rule = c.ConfigNotifyRule{
+					Symbol:     "AAPL",
+					PriceBelow: 150,
+					Webhook:    "https://example.com/hook",
+				}
+			})
+
+			DescribeTable("invalid notify rules",
+				func(modify func(*c.ConfigNotifyRule), expectedErr string) {
+					modify(&rule)
+					config = c.Config{
+						Watchlist: []string{"AAPL"},
+						Notify:    c.ConfigNotify{Rules: []c.ConfigNotifyRule{rule}},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring(expectedErr)))
+				},
+				Entry("empty symbol", func(r *c.ConfigNotifyRule) { r.Symbol = "" }, "notify rule #1 has empty symbol"),
+				Entry("negative threshold", func(r *c.ConfigNotifyRule) { r.PriceAbove = -1 }, "notify rule for symbol 'AAPL' has a negative threshold"),
+				Entry("no thresholds", func(r *c.ConfigNotifyRule) { r.PriceBelow = 0 }, "notify rule for symbol 'AAPL' has no thresholds"),
+				Entry("no webhook or command", func(r *c.ConfigNotifyRule) { r.Webhook = "" }, "notify rule for symbol 'AAPL' has no webhook or command"),
+			)
+
+			When("the notify rule is valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Watchlist: []string{"AAPL"},
+						Notify:    c.ConfigNotify{Rules: []c.ConfigNotifyRule{rule, {Symbol: "MSFT", ChangePercent: 5, Command: "true"}}},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("transaction validation", func() {
+
+			var transaction c.Transaction
+
+			BeforeEach(func() {
+				transaction = c.Transaction{
+					Symbol:   "AAPL",
+					Type:     c.TransactionTypeBuy,
+					Date:     "2024-01-02",
+					Quantity: 10,
+					Price:    150,
+				}
+			})
+
+			DescribeTable("invalid transactions",
+				func(modify func(*c.Transaction), expectedErr string) {
+					modify(&transaction)
+					config = c.Config{
+						AssetGroup: []c.ConfigAssetGroup{{Name: "trades", Transactions: []c.Transaction{transaction}}},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring(expectedErr)))
+				},
+				Entry("empty symbol", func(t *c.Transaction) { t.Symbol = "" }, "transaction #1 in group 'trades' has empty symbol"),
+				Entry("invalid date", func(t *c.Transaction) { t.Date = "01/02/2024" }, "transaction #1 for symbol 'AAPL' in group 'trades' has invalid date"),
+				Entry("negative fee", func(t *c.Transaction) { t.Fee = -1 }, "transaction #1 for symbol 'AAPL' in group 'trades' has a negative price or fee"),
+				Entry("zero quantity", func(t *c.Transaction) { t.Quantity = 0 }, "transaction #1 for symbol 'AAPL' in group 'trades' has invalid quantity"),
+				Entry("zero split ratio", func(t *c.Transaction) { t.Type = c.TransactionTypeSplit }, "transaction #1 for symbol 'AAPL' in group 'trades' has invalid ratio"),
+				Entry("unknown type", func(t *c.Transaction) { t.Type = "transfer" }, "transaction #1 for symbol 'AAPL' in group 'trades' has invalid type"),
+				Entry("sell without units held", func(t *c.Transaction) { t.Type = c.TransactionTypeSell }, "sell of 10 AAPL on 2024-01-02 exceeds the open quantity of 0 in group 'trades'"),
+			)
+
+			When("the cost basis method is not supported", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Transactions:    []c.Transaction{transaction},
+						CostBasisMethod: "hifo",
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: cost-basis-method must be fifo, lifo, or average, got 'hifo'"))
+				})
+			})
+
+			When("the transactions are valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Transactions: []c.Transaction{
+							{Symbol: "AAPL", Type: c.TransactionTypeSell, Date: "2024-06-01", Quantity: 15, Price: 180},
+							transaction,
+							{Symbol: "AAPL", Type: c.TransactionTypeSplit, Date: "2024-03-01", Ratio: 2},
+							{Symbol: "AAPL", Type: c.TransactionTypeFee, Date: "2024-04-01", Fee: 2},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("cash validation", func() {
+
+			When("the currency is not a three letter currency code", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Cash: []c.ConfigCash{{Currency: "EURO", Amount: 100}},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: cash #1 in group 'default' has invalid currency (must be a three letter currency code, got 'EURO')"))
+				})
+			})
+
+			When("only cash balances are set", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Cash: []c.ConfigCash{{Currency: "USD", Amount: 100}},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("private security validation", func() {
+
+			var security c.ConfigPrivateSecurity
+
+			BeforeEach(func() {
+				security = c.ConfigPrivateSecurity{
+					Symbol: "ACME",
+					Valuations: []c.ConfigPrivateSecurityValuation{
+						{Date: "2025-06-30", Price: 12.0},
+					},
+					Lots: []c.Lot{
+						{Quantity: 1000, UnitCost: 1.5},
+					},
+				}
+			})
+
+			DescribeTable("invalid private securities",
+				func(modify func(*c.ConfigPrivateSecurity), expectedErr string) {
+					modify(&security)
+					config = c.Config{
+						AssetGroup: []c.ConfigAssetGroup{
+							{Name: "private", PrivateSecurities: []c.ConfigPrivateSecurity{security}},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring(expectedErr)))
+				},
+				Entry("empty symbol", func(s *c.ConfigPrivateSecurity) { s.Symbol = "" }, "private security #1 in group 'private' has empty symbol"),
+				Entry("no valuations", func(s *c.ConfigPrivateSecurity) { s.Valuations = nil }, "private security 'ACME' in group 'private' has no valuations"),
+				Entry("invalid valuation date", func(s *c.ConfigPrivateSecurity) { s.Valuations[0].Date = "30/06/2025" }, "valuation #1 for private security 'ACME' in group 'private' has invalid date"),
+				Entry("negative valuation price", func(s *c.ConfigPrivateSecurity) { s.Valuations[0].Price = -1 }, "valuation #1 for private security 'ACME' in group 'private' has invalid price"),
+				Entry("lot with zero quantity", func(s *c.ConfigPrivateSecurity) { s.Lots[0].Quantity = 0 }, "lot #1 for symbol 'ACME' in group 'private' has invalid quantity"),
+			)
+
+			When("only private securities are set", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						PrivateSecurities: []c.ConfigPrivateSecurity{security},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 	})
 })
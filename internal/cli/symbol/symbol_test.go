@@ -103,6 +103,34 @@ var _ = Describe("Symbol", func() {
 
 		})
 
+		When("a ticker symbol has a CoinGecko source", func() {
+
+			It("should get ticker symbols", func() {
+				// Set up mock response
+				responseFixture := `"PEPE.X","pepe","cg"
+`
+				server.RouteToHandler("GET", "/symbols.csv",
+					ghttp.CombineHandlers(
+						ghttp.RespondWith(http.StatusOK, responseFixture, http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}}),
+					),
+				)
+
+				expectedSymbols := symbol.TickerSymbolToSourceSymbol{
+					"PEPE.X": symbol.SymbolSourceMap{
+						TickerSymbol: "PEPE.X",
+						SourceSymbol: "pepe",
+						Source:       c.QuoteSourceCoingecko,
+					},
+				}
+
+				outputSymbols, outputErr := symbol.GetTickerSymbols(server.URL() + "/symbols.csv")
+
+				Expect(outputSymbols).To(Equal(expectedSymbols))
+				Expect(outputErr).NotTo(HaveOccurred())
+			})
+
+		})
+
 		When("a malformed CSV is returned", func() {
 
 			It("should get ticker symbols", func() {
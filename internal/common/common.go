@@ -2,6 +2,7 @@ package common
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/afero"
 )
@@ -16,39 +17,137 @@ type Context struct {
 
 // Config represents user defined configuration
 type Config struct {
-	RefreshInterval                   int                `yaml:"interval"`
-	Watchlist                         []string           `yaml:"watchlist"`
-	Lots                              []Lot              `yaml:"lots"`
-	Separate                          bool               `yaml:"show-separator"`
-	ExtraInfoExchange                 bool               `yaml:"show-tags"`
-	ExtraInfoFundamentals             bool               `yaml:"show-fundamentals"`
-	ShowSummary                       bool               `yaml:"show-summary"`
-	ShowHoldings                      bool               `yaml:"show-holdings"`  // Deprecated: use ShowPositions instead, kept for backwards compatibility
-	ShowPositions                     bool               `yaml:"show-positions"` // Preferred field name
-	Sort                              string             `yaml:"sort"`
-	Currency                          string             `yaml:"currency"`
-	CurrencyConvertSummaryOnly        bool               `yaml:"currency-summary-only"`
-	CurrencyDisableUnitCostConversion bool               `yaml:"currency-disable-unit-cost-conversion"`
-	ColorScheme                       ConfigColorScheme  `yaml:"colors"`
-	AssetGroup                        []ConfigAssetGroup `yaml:"groups"`
-	Debug                             bool               `yaml:"debug"`
+	RefreshInterval                   int                       `yaml:"interval"`
+	Watchlist                         []string                  `yaml:"watchlist"`
+	Lots                              []Lot                     `yaml:"lots"`
+	Transactions                      []Transaction             `yaml:"transactions"`
+	Cash                              []ConfigCash              `yaml:"cash"`
+	Benchmark                         string                    `yaml:"benchmark"`         // Symbol to compare the day change of the default group to
+	CostBasisMethod                   CostBasisMethod           `yaml:"cost-basis-method"` // Method to match sells to buys; defaults to fifo
+	Separate                          bool                      `yaml:"show-separator"`
+	ExtraInfoExchange                 bool                      `yaml:"show-tags"`
+	ExtraInfoFundamentals             bool                      `yaml:"show-fundamentals"`
+	ShowSummary                       bool                      `yaml:"show-summary"`
+	ShowHoldings                      bool                      `yaml:"show-holdings"`  // Deprecated: use ShowPositions instead, kept for backwards compatibility
+	ShowPositions                     bool                      `yaml:"show-positions"` // Preferred field name
+	ShowSparkline                     bool                      `yaml:"show-sparkline"`
+	ShowReturns                       bool                      `yaml:"show-returns"`
+	Sort                              string                    `yaml:"sort"`
+	Currency                          string                    `yaml:"currency"`
+	CurrencyConvertSummaryOnly        bool                      `yaml:"currency-summary-only"`
+	CurrencyDisableUnitCostConversion bool                      `yaml:"currency-disable-unit-cost-conversion"`
+	ColorScheme                       ConfigColorScheme         `yaml:"colors"`
+	AssetGroup                        []ConfigAssetGroup        `yaml:"groups"`
+	SourcesUserDefined                []ConfigSourceUserDefined `yaml:"sources"`
+	PrivateSecurities                 []ConfigPrivateSecurity   `yaml:"private-securities"`
+	History                           ConfigHistory             `yaml:"history"`
+	Alerts                            []ConfigAlert             `yaml:"alerts"`
+	AlertCooldown                     int                       `yaml:"alert-cooldown"` // Seconds before the same alert can fire again for a symbol
+	Notify                            ConfigNotify              `yaml:"notify"`
+	Debug                             bool                      `yaml:"debug"`
+}
+
+// ConfigHistory represents the configuration for recording quotes and portfolio snapshots on disk
+type ConfigHistory struct {
+	Enabled          bool `yaml:"enabled"`
+	RetentionDays    int  `yaml:"retention-days"`     // Days to keep history; history is kept indefinitely when not set
+	CompactAfterDays int  `yaml:"compact-after-days"` // Days after which quotes are reduced to one per symbol per minute
+	SnapshotInterval int  `yaml:"snapshot-interval"`  // Seconds between portfolio snapshots
+}
+
+// ConfigAlert represents the rules for alerting on price movements of a symbol
+type ConfigAlert struct {
+	Symbol           string  `yaml:"symbol"`
+	PriceAbove       float64 `yaml:"price-above"`
+	PriceBelow       float64 `yaml:"price-below"`
+	ChangePercent    float64 `yaml:"change-percent"` // Alert when the day change percent is beyond this threshold in either direction
+	FiftyTwoWeekHigh bool    `yaml:"fifty-two-week-high"`
+	FiftyTwoWeekLow  bool    `yaml:"fifty-two-week-low"`
+}
+
+// ConfigNotify represents the configuration for delivering notifications outside of the terminal
+type ConfigNotify struct {
+	Rules     []ConfigNotifyRule `yaml:"rules"`
+	Retries   int                `yaml:"retries"`    // Retries after a failed delivery
+	RateLimit int                `yaml:"rate-limit"` // Seconds before a rule can notify again for the same condition
+	Interval  int                `yaml:"interval"`   // Seconds between deliveries to the same webhook or command
+}
+
+// ConfigNotifyRule represents price and change thresholds for a symbol and where to deliver a notification when one is reached
+// Price thresholds are in the currency of the quote as they are for alerts rather than the currency positions are converted to
+type ConfigNotifyRule struct {
+	Symbol        string  `yaml:"symbol"`
+	PriceAbove    float64 `yaml:"price-above"`
+	PriceBelow    float64 `yaml:"price-below"`
+	ChangePercent float64 `yaml:"change-percent"`
+	Webhook       string  `yaml:"webhook"` // URL to POST a JSON payload to
+	Command       string  `yaml:"command"` // Shell command to run with the quote in environment variables
+}
+
+// ConfigSourceUserDefined represents a user defined HTTP/JSON quote source
+type ConfigSourceUserDefined struct {
+	Name     string                              `yaml:"name"`
+	URL      string                              `yaml:"url"` // URL template where {symbol} is replaced with each symbol
+	Symbols  []string                            `yaml:"symbols"`
+	Currency string                              `yaml:"currency"` // Currency used when the response does not include a currency
+	Headers  map[string]string                   `yaml:"headers"`
+	Fields   ConfigSourceUserDefinedFieldMapping `yaml:"fields"`
+}
+
+// ConfigSourceUserDefinedFieldMapping represents the JSON paths in the response of a user defined source for each quote field
+type ConfigSourceUserDefinedFieldMapping struct {
+	Name             string `yaml:"name"`
+	Currency         string `yaml:"currency"`
+	Price            string `yaml:"price"`
+	PricePrevClose   string `yaml:"price_prev_close"`
+	PriceOpen        string `yaml:"price_open"`
+	PriceDayHigh     string `yaml:"price_day_high"`
+	PriceDayLow      string `yaml:"price_day_low"`
+	Change           string `yaml:"change"`
+	ChangePercent    string `yaml:"change_percent"`
+	FiftyTwoWeekHigh string `yaml:"fifty_two_week_high"`
+	FiftyTwoWeekLow  string `yaml:"fifty_two_week_low"`
+	MarketCap        string `yaml:"market_cap"`
+	Volume           string `yaml:"volume"`
+}
+
+// ConfigPrivateSecurity represents a security without a public market price which is valued manually
+type ConfigPrivateSecurity struct {
+	Symbol     string                           `yaml:"symbol"`
+	Name       string                           `yaml:"name"`
+	Currency   string                           `yaml:"currency"`
+	Valuations []ConfigPrivateSecurityValuation `yaml:"valuations"`
+	Lots       []Lot                            `yaml:"lots"` // Symbol is not required and is set to the symbol of the security
+}
+
+// ConfigPrivateSecurityValuation represents the price of a private security as of a date
+type ConfigPrivateSecurityValuation struct {
+	Date  string  `yaml:"date"` // Date in YYYY-MM-DD format
+	Price float64 `yaml:"price"`
 }
 
 // ConfigColorScheme represents user defined color scheme
 type ConfigColorScheme struct {
-	Text          string `yaml:"text"`
-	TextLight     string `yaml:"text-light"`
-	TextLabel     string `yaml:"text-label"`
-	TextLine      string `yaml:"text-line"`
-	TextTag       string `yaml:"text-tag"`
-	BackgroundTag string `yaml:"background-tag"`
+	Text            string `yaml:"text"`
+	TextLight       string `yaml:"text-light"`
+	TextLabel       string `yaml:"text-label"`
+	TextLine        string `yaml:"text-line"`
+	TextTag         string `yaml:"text-tag"`
+	BackgroundTag   string `yaml:"background-tag"`
+	TextAlert       string `yaml:"text-alert"`
+	BackgroundAlert string `yaml:"background-alert"`
+	TextCash        string `yaml:"text-cash"`
 }
 
 type ConfigAssetGroup struct {
-	Name      string   `yaml:"name"`
-	Watchlist []string `yaml:"watchlist"`
-	Lots      []Lot    `yaml:"lots"`     // Preferred field name
-	Holdings  []Lot    `yaml:"holdings"` // Deprecated: use Lots instead, kept for backwards compatibility
+	Name              string                  `yaml:"name"`
+	Watchlist         []string                `yaml:"watchlist"`
+	Lots              []Lot                   `yaml:"lots"`     // Preferred field name
+	Holdings          []Lot                   `yaml:"holdings"` // Deprecated: use Lots instead, kept for backwards compatibility
+	Transactions      []Transaction           `yaml:"transactions"`
+	Cash              []ConfigCash            `yaml:"cash"`
+	PrivateSecurities []ConfigPrivateSecurity `yaml:"private-securities"`
+	Benchmark         string                  `yaml:"benchmark"` // Symbol to compare the day change of the group to
 }
 
 type AssetGroup struct {
@@ -78,6 +177,9 @@ type Dependencies struct {
 	GitHubReleasesURL                string
 	MonitorPriceCoinbaseBaseURL      string
 	MonitorPriceCoinbaseStreamingURL string
+	MonitorPriceCoingeckoBaseURL     string
+	MonitorPriceCoinCapBaseURL       string
+	MonitorPriceCoinCapStreamingURL  string
 	MonitorYahooBaseURL              string
 	MonitorYahooSessionRootURL       string
 	MonitorYahooSessionCrumbURL      string
@@ -98,14 +200,56 @@ type MonitorCurrencyRate interface {
 	Stop() error
 }
 
+// MonitorPriceHistory is implemented by monitors for sources which provide historical prices
+type MonitorPriceHistory interface {
+	GetPriceHistory(symbol string, historyRange PriceHistoryRange, interval PriceHistoryInterval) (PriceHistory, error)
+}
+
 // Lot represents a cost basis lot
 type Lot struct {
 	Symbol    string  `yaml:"symbol"`
 	UnitCost  float64 `yaml:"unit_cost"`
 	Quantity  float64 `yaml:"quantity"`
 	FixedCost float64 `yaml:"fixed_cost"`
+	Date      string  `yaml:"date"` // Optional acquisition date in YYYY-MM-DD format
+}
+
+// ConfigCash represents an uninvested cash balance in a currency
+type ConfigCash struct {
+	Currency string  `yaml:"currency"`
+	Amount   float64 `yaml:"amount"`
+}
+
+// Transaction represents a buy, sell, fee, or split of an asset
+type Transaction struct {
+	Symbol   string          `yaml:"symbol"`
+	Type     TransactionType `yaml:"type"`
+	Date     string          `yaml:"date"`     // Date in YYYY-MM-DD format used to order transactions
+	Quantity float64         `yaml:"quantity"` // Units bought or sold
+	Price    float64         `yaml:"price"`    // Price of each unit bought or sold
+	Fee      float64         `yaml:"fee"`      // Commission on a buy or sell or the amount of a fee
+	Ratio    float64         `yaml:"ratio"`    // Units after a split for each unit before the split
 }
 
+// TransactionType is the kind of transaction
+type TransactionType string
+
+const (
+	TransactionTypeBuy   TransactionType = "buy"
+	TransactionTypeSell  TransactionType = "sell"
+	TransactionTypeFee   TransactionType = "fee"
+	TransactionTypeSplit TransactionType = "split"
+)
+
+// CostBasisMethod is the method used to match units sold to units bought
+type CostBasisMethod string
+
+const (
+	CostBasisMethodFIFO    CostBasisMethod = "fifo"
+	CostBasisMethodLIFO    CostBasisMethod = "lifo"
+	CostBasisMethodAverage CostBasisMethod = "average"
+)
+
 // CurrencyRates is a map of currency rates for lookup by currency that needs to be converted
 type CurrencyRates map[string]CurrencyRate
 
@@ -125,6 +269,8 @@ type Styles struct {
 	TextLine  StyleFn
 	TextPrice func(float64, string) string
 	Tag       StyleFn
+	Alert     StyleFn // Symbol of an asset with an active alert
+	Cash      StyleFn // Symbol of a cash balance
 }
 
 // StyleFn is a function that styles text
@@ -148,8 +294,12 @@ type Position struct {
 	UnitValue   float64
 	UnitCost    float64
 	DayChange   PositionChange
-	TotalChange PositionChange
+	TotalChange PositionChange // Unrealized gain or loss of the units held
 	Weight      float64
+	Realized    float64 // Gain or loss from units sold and fees
+	// AnnualizedReturn is the annual return as a percent using CAGR for a single lot and XIRR for multiple lots or transactions
+	// It is zero when a lot does not have an acquisition date
+	AnnualizedReturn float64
 }
 
 // Currency is the original and converted currency if applicable
@@ -179,6 +329,16 @@ type QuoteExtended struct {
 	Volume           float64
 }
 
+// QuoteDepth represents the best bid and ask at the top of the order book
+type QuoteDepth struct {
+	BidPrice      float64
+	BidSize       float64
+	AskPrice      float64
+	AskSize       float64
+	Spread        float64
+	SpreadPercent float64
+}
+
 type QuoteFutures struct {
 	SymbolUnderlying string
 	IndexPrice       float64
@@ -214,6 +374,7 @@ type Asset struct {
 	Position      Position
 	QuotePrice    QuotePrice
 	QuoteExtended QuoteExtended
+	QuoteDepth    QuoteDepth
 	QuoteFutures  QuoteFutures
 	QuoteSource   QuoteSource
 	Exchange      Exchange
@@ -241,8 +402,33 @@ const (
 	QuoteSourceUnknown
 	QuoteSourceCoinCap
 	QuoteSourceCoinbase
+	QuoteSourceManual
+	QuoteSourceCash
 )
 
+// String returns the name of the quote source used in printed output and metrics
+func (source QuoteSource) String() string {
+	switch source {
+	case QuoteSourceYahoo:
+		return "yahoo"
+	case QuoteSourceUserDefined:
+		return "user-defined"
+	case QuoteSourceCoingecko:
+		return "coingecko"
+	case QuoteSourceCoinCap:
+		return "coincap"
+	case QuoteSourceCoinbase:
+		return "coinbase"
+	case QuoteSourceManual:
+		return "manual"
+	case QuoteSourceCash:
+		return "cash"
+	case QuoteSourceUnknown:
+	}
+
+	return "unknown"
+}
+
 // AssetQuote represents a price quote and related attributes for a single security
 type AssetQuote struct {
 	Name          string
@@ -251,12 +437,92 @@ type AssetQuote struct {
 	Currency      Currency
 	QuotePrice    QuotePrice
 	QuoteExtended QuoteExtended
+	QuoteDepth    QuoteDepth
 	QuoteFutures  QuoteFutures
 	QuoteSource   QuoteSource
 	Exchange      Exchange
 	Meta          Meta
 }
 
+// StreamStatus represents the connection state of a streaming quote source
+type StreamStatus int
+
+const (
+	StreamStatusConnected StreamStatus = iota
+	StreamStatusDisconnected
+	StreamStatusReconnecting
+)
+
+// StreamStatusUpdate is sent by a streaming quote source when its connection state changes
+type StreamStatusUpdate struct {
+	Source  QuoteSource
+	Status  StreamStatus
+	Attempt int   // Reconnect attempt when reconnecting; zero otherwise
+	Err     error // Error which caused the disconnect if any
+}
+
+// AlertType is the rule which triggered an alert
+type AlertType int
+
+const (
+	AlertTypePriceAbove AlertType = iota
+	AlertTypePriceBelow
+	AlertTypeChangePercent
+	AlertTypeFiftyTwoWeekHigh
+	AlertTypeFiftyTwoWeekLow
+)
+
+// Alert is sent when an asset quote meets the conditions of an alert rule
+type Alert struct {
+	Symbol    string
+	Type      AlertType
+	Price     float64
+	Threshold float64 // Value from the alert rule or the 52-week high or low which was reached
+	Time      time.Time
+	Message   string
+}
+
+// PriceHistoryRange is the period of time covered by a price history
+type PriceHistoryRange string
+
+const (
+	PriceHistoryRange1Day   PriceHistoryRange = "1d"
+	PriceHistoryRange5Day   PriceHistoryRange = "5d"
+	PriceHistoryRange1Month PriceHistoryRange = "1mo"
+	PriceHistoryRange3Month PriceHistoryRange = "3mo"
+	PriceHistoryRange1Year  PriceHistoryRange = "1y"
+)
+
+// PriceHistoryInterval is the period of time covered by each candle in a price history
+type PriceHistoryInterval string
+
+const (
+	PriceHistoryInterval1Minute  PriceHistoryInterval = "1m"
+	PriceHistoryInterval5Minute  PriceHistoryInterval = "5m"
+	PriceHistoryInterval15Minute PriceHistoryInterval = "15m"
+	PriceHistoryInterval1Hour    PriceHistoryInterval = "1h"
+	PriceHistoryInterval1Day     PriceHistoryInterval = "1d"
+)
+
+// PriceHistory represents a series of prices for a single asset ordered from oldest to newest
+type PriceHistory struct {
+	Symbol   string
+	Currency string
+	Range    PriceHistoryRange
+	Interval PriceHistoryInterval
+	Candles  []PriceCandle
+}
+
+// PriceCandle represents the open, high, low, close, and volume for a single interval starting at Time
+type PriceCandle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
 type MessageUpdate[T any] struct {
 	Data          T
 	ID            string
@@ -0,0 +1,492 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+
+	"github.com/adrg/xdg"
+	"github.com/spf13/afero"
+)
+
+const (
+	dirQuotes    = "quotes"
+	dirSnapshots = "snapshots"
+	fileLayout   = "2006-01-02"
+	fileExt      = ".jsonl"
+)
+
+// Quote represents a recorded asset quote update
+type Quote struct {
+	Time          time.Time
+	Symbol        string
+	Currency      string
+	Price         float64
+	Change        float64
+	ChangePercent float64
+	Volume        float64
+}
+
+// Snapshot represents the recorded value of the positions in an asset group at a point in time
+type Snapshot struct {
+	Time        time.Time
+	Group       string
+	Value       float64
+	Cost        float64
+	DayChange   c.PositionChange
+	TotalChange c.PositionChange
+}
+
+// quoteLine is the compact on-disk representation of a quote with one JSON object per line
+type quoteLine struct {
+	Time          int64   `json:"t"`
+	Symbol        string  `json:"s"`
+	Currency      string  `json:"cur,omitempty"`
+	Price         float64 `json:"p"`
+	Change        float64 `json:"c,omitempty"`
+	ChangePercent float64 `json:"cp,omitempty"`
+	Volume        float64 `json:"v,omitempty"`
+}
+
+// snapshotLine is the compact on-disk representation of a snapshot with one JSON object per line
+type snapshotLine struct {
+	Time               int64   `json:"t"`
+	Group              string  `json:"g"`
+	Value              float64 `json:"v"`
+	Cost               float64 `json:"cb"`
+	DayChange          float64 `json:"dc"`
+	DayChangePercent   float64 `json:"dcp"`
+	TotalChange        float64 `json:"tc"`
+	TotalChangePercent float64 `json:"tcp"`
+}
+
+// Store records quote updates and portfolio snapshots to files partitioned by day and reads them back
+type Store struct {
+	fs                afero.Fs
+	dir               string
+	retention         time.Duration
+	compactAfter      time.Duration
+	compactResolution time.Duration
+	snapshotInterval  time.Duration
+	now               func() time.Time
+	lastPrices        map[string]float64
+	lastSnapshots     map[string]time.Time
+	mu                sync.Mutex
+}
+
+// Config contains the required configuration for the history store
+type Config struct {
+	Fs  afero.Fs
+	Dir string // Directory to store history in; defaults to the ticker directory under the XDG data directory
+}
+
+// Option defines an option for configuring the history store
+type Option func(*Store)
+
+// NewStore creates a history store
+func NewStore(config Config, opts ...Option) *Store {
+
+	dir := config.Dir
+	if dir == "" {
+		dir = DefaultDir()
+	}
+
+	s := &Store{
+		fs:                config.Fs,
+		dir:               dir,
+		compactAfter:      7 * 24 * time.Hour,
+		compactResolution: time.Minute,
+		snapshotInterval:  5 * time.Minute,
+		now:               time.Now,
+		lastPrices:        make(map[string]float64),
+		lastSnapshots:     make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// DefaultDir returns the directory history is stored in when one is not set
+func DefaultDir() string {
+	return filepath.Join(xdg.DataHome, "ticker", "history")
+}
+
+// WithRetention sets how long history is kept before it is deleted; history is kept indefinitely when not set
+func WithRetention(retention time.Duration) Option {
+	return func(s *Store) {
+		s.retention = retention
+	}
+}
+
+// WithCompactAfter sets the age after which quotes are reduced to the last quote for each symbol in each minute
+func WithCompactAfter(compactAfter time.Duration) Option {
+	return func(s *Store) {
+		if compactAfter > 0 {
+			s.compactAfter = compactAfter
+		}
+	}
+}
+
+// WithSnapshotInterval sets the minimum time between snapshots of each asset group
+func WithSnapshotInterval(snapshotInterval time.Duration) Option {
+	return func(s *Store) {
+		if snapshotInterval > 0 {
+			s.snapshotInterval = snapshotInterval
+		}
+	}
+}
+
+// WithNow sets the function used to get the current time
+func WithNow(now func() time.Time) Option {
+	return func(s *Store) {
+		s.now = now
+	}
+}
+
+// RecordAssetQuote appends an asset quote to the history when the price has changed since the last recorded quote for the symbol
+func (s *Store) RecordAssetQuote(assetQuote c.AssetQuote) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if assetQuote.QuotePrice.Price == 0.0 || s.lastPrices[assetQuote.Symbol] == assetQuote.QuotePrice.Price {
+		return nil
+	}
+
+	now := s.now()
+
+	err := s.appendLine(dirQuotes, now, quoteLine{
+		Time:          now.UnixMilli(),
+		Symbol:        assetQuote.Symbol,
+		Currency:      assetQuote.Currency.FromCurrencyCode,
+		Price:         assetQuote.QuotePrice.Price,
+		Change:        assetQuote.QuotePrice.Change,
+		ChangePercent: assetQuote.QuotePrice.ChangePercent,
+		Volume:        assetQuote.QuoteExtended.Volume,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.lastPrices[assetQuote.Symbol] = assetQuote.QuotePrice.Price
+
+	return nil
+}
+
+// RecordSnapshot appends a snapshot of the positions in an asset group unless one was recorded within the snapshot interval
+func (s *Store) RecordSnapshot(group string, positionSummary asset.PositionSummary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	if positionSummary.Value == 0.0 || now.Sub(s.lastSnapshots[group]) < s.snapshotInterval {
+		return nil
+	}
+
+	err := s.appendLine(dirSnapshots, now, snapshotLine{
+		Time:               now.UnixMilli(),
+		Group:              group,
+		Value:              positionSummary.Value,
+		Cost:               positionSummary.Cost,
+		DayChange:          positionSummary.DayChange.Amount,
+		DayChangePercent:   positionSummary.DayChange.Percent,
+		TotalChange:        positionSummary.TotalChange.Amount,
+		TotalChangePercent: positionSummary.TotalChange.Percent,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.lastSnapshots[group] = now
+
+	return nil
+}
+
+// QueryQuotes returns the recorded quotes for a symbol between from and to (inclusive) ordered from oldest to newest
+func (s *Store) QueryQuotes(symbol string, from time.Time, to time.Time) ([]Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	quotes := make([]Quote, 0)
+
+	err := s.readLines(dirQuotes, from, to, func(data []byte) {
+		quote, ok := parseQuoteLine(data)
+		if !ok || quote.Symbol != symbol || quote.Time.Before(from) || quote.Time.After(to) {
+			return
+		}
+
+		quotes = append(quotes, quote)
+	})
+
+	sortQuotes(quotes)
+
+	return quotes, err
+}
+
+// ReadQuotes returns all quotes in a history file ordered from oldest to newest
+func ReadQuotes(fs afero.Fs, path string) ([]Quote, error) {
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file %s: %w", path, err)
+	}
+
+	quotes := make([]Quote, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if quote, ok := parseQuoteLine(scanner.Bytes()); ok {
+			quotes = append(quotes, quote)
+		}
+	}
+
+	sortQuotes(quotes)
+
+	return quotes, nil
+}
+
+// QuerySnapshots returns the recorded snapshots for an asset group between from and to (inclusive) ordered from oldest to newest
+func (s *Store) QuerySnapshots(group string, from time.Time, to time.Time) ([]Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots := make([]Snapshot, 0)
+
+	err := s.readLines(dirSnapshots, from, to, func(data []byte) {
+		var line snapshotLine
+		if json.Unmarshal(data, &line) != nil || line.Group != group {
+			return
+		}
+
+		t := time.UnixMilli(line.Time)
+		if t.Before(from) || t.After(to) {
+			return
+		}
+
+		snapshots = append(snapshots, Snapshot{
+			Time:        t,
+			Group:       line.Group,
+			Value:       line.Value,
+			Cost:        line.Cost,
+			DayChange:   c.PositionChange{Amount: line.DayChange, Percent: line.DayChangePercent},
+			TotalChange: c.PositionChange{Amount: line.TotalChange, Percent: line.TotalChangePercent},
+		})
+	})
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+
+	return snapshots, err
+}
+
+// Compact deletes history older than the retention period and reduces quotes older than the compaction age to the last quote for each symbol in each minute
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	today := truncateDay(s.now())
+
+	for _, dir := range []string{dirQuotes, dirSnapshots} {
+		days, err := s.listDays(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, day := range days {
+			path := s.getPath(dir, day)
+
+			if s.retention > 0 && day.Before(today.Add(-s.retention)) {
+				if err := s.fs.Remove(path); err != nil {
+					return fmt.Errorf("failed to remove history file %s: %w", path, err)
+				}
+
+				continue
+			}
+
+			if dir == dirQuotes && day.Before(today.Add(-s.compactAfter)) {
+				if err := s.compactQuotes(path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// compactQuotes rewrites a file of quotes keeping only the last quote for each symbol in each interval of the compaction resolution
+func (s *Store) compactQuotes(path string) error {
+
+	data, err := afero.ReadFile(s.fs, path)
+	if err != nil {
+		return fmt.Errorf("failed to read history file %s: %w", path, err)
+	}
+
+	type key struct {
+		symbol string
+		bucket int64
+	}
+
+	lines := make([]quoteLine, 0)
+	lineIndexByKey := make(map[key]int)
+	lineCount := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var line quoteLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil {
+			continue
+		}
+		lineCount++
+
+		k := key{line.Symbol, line.Time / s.compactResolution.Milliseconds()}
+		if i, ok := lineIndexByKey[k]; ok {
+			lines[i] = line
+
+			continue
+		}
+
+		lineIndexByKey[k] = len(lines)
+		lines = append(lines, line)
+	}
+
+	// Skip rewriting files which are already compacted
+	if len(lines) == lineCount {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, line := range lines {
+		encoder.Encode(line) //nolint:errcheck,errchkjson
+	}
+
+	if err := afero.WriteFile(s.fs, path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write history file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (s *Store) appendLine(dir string, t time.Time, line interface{}) error {
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	path := s.getPath(dir, t)
+
+	if err := s.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	file, err := s.fs.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// readLines calls fn with each line in the files for the days between from and to
+func (s *Store) readLines(dir string, from time.Time, to time.Time, fn func(data []byte)) error {
+
+	days, err := s.listDays(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		if day.Before(truncateDay(from)) || day.After(to) {
+			continue
+		}
+
+		data, err := afero.ReadFile(s.fs, s.getPath(dir, day))
+		if err != nil {
+			return fmt.Errorf("failed to read history file: %w", err)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			fn(scanner.Bytes())
+		}
+	}
+
+	return nil
+}
+
+// listDays returns the days which have a history file in a directory
+func (s *Store) listDays(dir string) ([]time.Time, error) {
+
+	entries, err := afero.ReadDir(s.fs, filepath.Join(s.dir, dir))
+	if os.IsNotExist(err) {
+		return []time.Time{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	days := make([]time.Time, 0, len(entries))
+	for _, entry := range entries {
+		day, err := time.Parse(fileLayout, strings.TrimSuffix(entry.Name(), fileExt))
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExt) || err != nil {
+			continue
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// parseQuoteLine converts a line from a history file to a quote and returns false for lines which are incomplete or not quotes
+func parseQuoteLine(data []byte) (Quote, bool) {
+
+	var line quoteLine
+	if json.Unmarshal(data, &line) != nil || line.Symbol == "" {
+		return Quote{}, false
+	}
+
+	return Quote{
+		Time:          time.UnixMilli(line.Time),
+		Symbol:        line.Symbol,
+		Currency:      line.Currency,
+		Price:         line.Price,
+		Change:        line.Change,
+		ChangePercent: line.ChangePercent,
+		Volume:        line.Volume,
+	}, true
+}
+
+func sortQuotes(quotes []Quote) {
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Time.Before(quotes[j].Time)
+	})
+}
+
+func (s *Store) getPath(dir string, t time.Time) string {
+	return filepath.Join(s.dir, dir, t.UTC().Format(fileLayout)+fileExt)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,271 @@
+package history_test
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+	"github.com/spf13/afero"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/history"
+)
+
+var _ = Describe("History", func() {
+
+	var (
+		fs    afero.Fs
+		now   time.Time
+		store *history.Store
+	)
+
+	newAssetQuote := func(symbol string, price float64) c.AssetQuote {
+		return c.AssetQuote{
+			Symbol:     symbol,
+			Currency:   c.Currency{FromCurrencyCode: "USD"},
+			QuotePrice: c.QuotePrice{Price: price, Change: 1.5, ChangePercent: 0.5},
+		}
+	}
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		now = time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+		store = history.NewStore(
+			history.Config{Fs: fs, Dir: "/history"},
+			history.WithNow(func() time.Time { return now }),
+			history.WithRetention(30*24*time.Hour),
+			history.WithCompactAfter(2*24*time.Hour),
+		)
+	})
+
+	Describe("RecordAssetQuote", func() {
+
+		It("should append the quote to the file for the day", func() {
+			Expect(store.RecordAssetQuote(newAssetQuote("AAPL", 150.25))).To(Succeed())
+			now = now.Add(time.Second)
+			Expect(store.RecordAssetQuote(newAssetQuote("AAPL", 150.5))).To(Succeed())
+
+			data, err := afero.ReadFile(fs, "/history/quotes/2025-03-10.jsonl")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Split(strings.TrimSpace(string(data)), "\n")).To(HaveLen(2))
+		})
+
+		When("the price has not changed since the last recorded quote", func() {
+			It("should not record the quote", func() {
+				Expect(store.RecordAssetQuote(newAssetQuote("AAPL", 150.25))).To(Succeed())
+				now = now.Add(time.Second)
+				Expect(store.RecordAssetQuote(newAssetQuote("AAPL", 150.25))).To(Succeed())
+
+				output, err := store.QueryQuotes("AAPL", now.Add(-time.Hour), now)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(HaveLen(1))
+			})
+		})
+
+	})
+
+	Describe("QueryQuotes", func() {
+
+		It("should return the quotes for the symbol within the time range ordered from oldest to newest", func() {
+			start := now
+			store.RecordAssetQuote(newAssetQuote("AAPL", 150.25)) //nolint:errcheck
+			store.RecordAssetQuote(newAssetQuote("MSFT", 410.0))  //nolint:errcheck
+			now = now.Add(24 * time.Hour)
+			store.RecordAssetQuote(newAssetQuote("AAPL", 151.0)) //nolint:errcheck
+			now = now.Add(24 * time.Hour)
+			store.RecordAssetQuote(newAssetQuote("AAPL", 152.0)) //nolint:errcheck
+
+			output, err := store.QueryQuotes("AAPL", start, start.Add(24*time.Hour))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+				"0": g.MatchAllFields(g.Fields{
+					"Time":          BeTemporally("==", start),
+					"Symbol":        Equal("AAPL"),
+					"Currency":      Equal("USD"),
+					"Price":         Equal(150.25),
+					"Change":        Equal(1.5),
+					"ChangePercent": Equal(0.5),
+					"Volume":        Equal(0.0),
+				}),
+				"1": g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Time":  BeTemporally("==", start.Add(24*time.Hour)),
+					"Price": Equal(151.0),
+				}),
+			}))
+		})
+
+		When("there is no history", func() {
+			It("should return an empty list", func() {
+				output, err := store.QueryQuotes("AAPL", now.Add(-time.Hour), now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(BeEmpty())
+			})
+		})
+
+		When("a line in the history file is incomplete", func() {
+			It("should skip the line", func() {
+				store.RecordAssetQuote(newAssetQuote("AAPL", 150.25)) //nolint:errcheck
+				file, _ := fs.OpenFile("/history/quotes/2025-03-10.jsonl", os.O_APPEND|os.O_WRONLY, 0644)
+				file.WriteString(`{"t":17416`) //nolint:errcheck
+				file.Close()
+
+				output, err := store.QueryQuotes("AAPL", now.Add(-time.Hour), now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(HaveLen(1))
+			})
+		})
+
+	})
+
+	Describe("ReadQuotes", func() {
+
+		It("should return all quotes in the file ordered from oldest to newest", func() {
+			afero.WriteFile(fs, "/recording.jsonl", []byte(strings.Join([]string{ //nolint:errcheck
+				`{"t":1741617060000,"s":"MSFT","p":410}`,
+				`{"t":1741617000000,"s":"AAPL","cur":"USD","p":150.25,"c":1.5,"cp":0.5,"v":1000}`,
+				`{"t":17416`,
+			}, "\n")), 0644)
+
+			output, err := history.ReadQuotes(fs, "/recording.jsonl")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+				"0": g.MatchAllFields(g.Fields{
+					"Time":          BeTemporally("==", time.UnixMilli(1741617000000)),
+					"Symbol":        Equal("AAPL"),
+					"Currency":      Equal("USD"),
+					"Price":         Equal(150.25),
+					"Change":        Equal(1.5),
+					"ChangePercent": Equal(0.5),
+					"Volume":        Equal(1000.0),
+				}),
+				"1": g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol": Equal("MSFT"),
+				}),
+			}))
+		})
+
+		When("the file does not exist", func() {
+			It("should return an error", func() {
+				_, err := history.ReadQuotes(fs, "/missing.jsonl")
+
+				Expect(err).To(MatchError(ContainSubstring("failed to read history file")))
+			})
+		})
+
+	})
+
+	Describe("RecordSnapshot", func() {
+
+		positionSummary := asset.PositionSummary{
+			Value:       1000,
+			Cost:        800,
+			DayChange:   c.PositionChange{Amount: 10, Percent: 1},
+			TotalChange: c.PositionChange{Amount: 200, Percent: 25},
+		}
+
+		It("should record a snapshot once per snapshot interval for each group", func() {
+			start := now
+			Expect(store.RecordSnapshot("default", positionSummary)).To(Succeed())
+			Expect(store.RecordSnapshot("crypto", positionSummary)).To(Succeed())
+			now = now.Add(time.Minute)
+			Expect(store.RecordSnapshot("default", positionSummary)).To(Succeed())
+			now = now.Add(5 * time.Minute)
+			Expect(store.RecordSnapshot("default", positionSummary)).To(Succeed())
+
+			output, err := store.QuerySnapshots("default", start, now)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(HaveLen(2))
+			Expect(output[0]).To(g.MatchAllFields(g.Fields{
+				"Time":        BeTemporally("==", start),
+				"Group":       Equal("default"),
+				"Value":       Equal(1000.0),
+				"Cost":        Equal(800.0),
+				"DayChange":   Equal(c.PositionChange{Amount: 10, Percent: 1}),
+				"TotalChange": Equal(c.PositionChange{Amount: 200, Percent: 25}),
+			}))
+			Expect(output[1].Time).To(BeTemporally("==", now))
+		})
+
+		When("there are no positions", func() {
+			It("should not record a snapshot", func() {
+				Expect(store.RecordSnapshot("default", asset.PositionSummary{})).To(Succeed())
+
+				output, err := store.QuerySnapshots("default", now.Add(-time.Hour), now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(BeEmpty())
+			})
+		})
+
+	})
+
+	Describe("Compact", func() {
+
+		It("should delete history older than the retention period", func() {
+			start := now
+			store.RecordAssetQuote(newAssetQuote("AAPL", 150.25))                 //nolint:errcheck
+			store.RecordSnapshot("default", asset.PositionSummary{Value: 1000.0}) //nolint:errcheck
+			now = now.Add(40 * 24 * time.Hour)
+			store.RecordAssetQuote(newAssetQuote("AAPL", 151.0)) //nolint:errcheck
+
+			Expect(store.Compact()).To(Succeed())
+
+			Expect(afero.Exists(fs, "/history/quotes/2025-03-10.jsonl")).To(BeFalse())
+			Expect(afero.Exists(fs, "/history/snapshots/2025-03-10.jsonl")).To(BeFalse())
+			output, _ := store.QueryQuotes("AAPL", start, now)
+			Expect(output).To(HaveLen(1))
+		})
+
+		It("should keep the last quote for each symbol in each minute for history older than the compaction age", func() {
+			start := now
+			for i := range 6 {
+				store.RecordAssetQuote(newAssetQuote("AAPL", 150.0+float64(i))) //nolint:errcheck
+				store.RecordAssetQuote(newAssetQuote("MSFT", 400.0+float64(i))) //nolint:errcheck
+				now = now.Add(20 * time.Second)
+			}
+			now = start.Add(3 * 24 * time.Hour)
+
+			Expect(store.Compact()).To(Succeed())
+
+			output, err := store.QueryQuotes("AAPL", start, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(HaveLen(2))
+			Expect(output[0].Price).To(Equal(152.0))
+			Expect(output[1].Price).To(Equal(155.0))
+
+			output, _ = store.QueryQuotes("MSFT", start, now)
+			Expect(output).To(HaveLen(2))
+		})
+
+		When("history is newer than the compaction age", func() {
+			It("should not change the history", func() {
+				for i := range 3 {
+					store.RecordAssetQuote(newAssetQuote("AAPL", 150.0+float64(i))) //nolint:errcheck
+					now = now.Add(time.Second)
+				}
+
+				Expect(store.Compact()).To(Succeed())
+
+				output, _ := store.QueryQuotes("AAPL", now.Add(-time.Hour), now)
+				Expect(output).To(HaveLen(3))
+			})
+		})
+
+		When("there is no history", func() {
+			It("should not return an error", func() {
+				Expect(store.Compact()).To(Succeed())
+			})
+		})
+
+	})
+
+})
@@ -0,0 +1,256 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// ContentType is the Prometheus text exposition format written by the exporter
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	typeGauge   = "gauge"
+	typeCounter = "counter"
+)
+
+// Exporter keeps the latest values from monitor callbacks and writes them as Prometheus metrics when scraped
+type Exporter struct {
+	assetsByGroup  map[string][]c.Asset
+	errors         int
+	reconnects     map[c.QuoteSource]int
+	streamStatuses map[c.QuoteSource]c.StreamStatus
+	lastUpdates    map[c.QuoteSource]time.Time
+	now            func() time.Time
+	mu             sync.RWMutex
+}
+
+// Config contains the required configuration for the exporter
+type Config struct{}
+
+// Option defines an option for configuring the exporter
+type Option func(*Exporter)
+
+// metric is a family of samples with the same name
+type metric struct {
+	name       string
+	help       string
+	metricType string
+	samples    []sample
+}
+
+type sample struct {
+	labels [][2]string
+	value  float64
+}
+
+// NewExporter creates a metrics exporter
+func NewExporter(_ Config, opts ...Option) *Exporter {
+
+	e := &Exporter{
+		assetsByGroup:  make(map[string][]c.Asset),
+		reconnects:     make(map[c.QuoteSource]int),
+		streamStatuses: make(map[c.QuoteSource]c.StreamStatus),
+		lastUpdates:    make(map[c.QuoteSource]time.Time),
+		now:            time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// WithNow sets the function used to get the current time
+func WithNow(now func() time.Time) Option {
+	return func(e *Exporter) {
+		e.now = now
+	}
+}
+
+// SetAssets replaces the assets of a group so that symbols removed from the group are no longer exported
+func (e *Exporter) SetAssets(group string, assets []c.Asset) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.assetsByGroup[group] = assets
+}
+
+// RecordUpdate records the time of the latest quote from a source
+func (e *Exporter) RecordUpdate(source c.QuoteSource) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.lastUpdates[source] = e.now()
+}
+
+// RecordError counts an error from a monitor
+func (e *Exporter) RecordError() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.errors++
+}
+
+// SetStreamStatus records the connection state of a streaming source and counts reconnect attempts
+func (e *Exporter) SetStreamStatus(streamStatus c.StreamStatusUpdate) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if streamStatus.Status == c.StreamStatusReconnecting {
+		e.reconnects[streamStatus.Source]++
+	}
+
+	e.streamStatuses[streamStatus.Source] = streamStatus.Status
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	//nolint:errcheck
+	w.Write([]byte(e.String()))
+}
+
+// String returns the metrics in the Prometheus text exposition format
+func (e *Exporter) String() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var sb strings.Builder
+
+	for _, m := range append(e.getAssetMetrics(), e.getHealthMetrics()...) {
+		if len(m.samples) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&sb, "# TYPE %s %s\n", m.name, m.metricType)
+
+		for _, s := range m.samples {
+			sb.WriteString(m.name + formatLabels(s.labels) + " " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// getAssetMetrics returns the quote and position gauges for each symbol in each group
+func (e *Exporter) getAssetMetrics() []metric {
+
+	price := metric{name: "ticker_price", help: "Price of the symbol", metricType: typeGauge}
+	changePercent := metric{name: "ticker_change_percent", help: "Percent change in price since the previous close", metricType: typeGauge}
+	volume := metric{name: "ticker_volume", help: "Volume traded during the day", metricType: typeGauge}
+	positionValue := metric{name: "ticker_position_value", help: "Value of the position in the symbol", metricType: typeGauge}
+	positionCost := metric{name: "ticker_position_cost", help: "Cost basis of the position in the symbol", metricType: typeGauge}
+	positionWeight := metric{name: "ticker_position_weight", help: "Percent of the value of the group held in the symbol", metricType: typeGauge}
+
+	groups := make([]string, 0, len(e.assetsByGroup))
+	for group := range e.assetsByGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		for _, a := range e.assetsByGroup[group] {
+			currency := a.Currency.ToCurrencyCode
+			if currency == "" {
+				currency = a.Currency.FromCurrencyCode
+			}
+
+			labels := [][2]string{
+				{"group", group},
+				{"symbol", a.Symbol},
+				{"source", a.QuoteSource.String()},
+				{"currency", currency},
+			}
+
+			price.samples = append(price.samples, sample{labels, a.QuotePrice.Price})
+			changePercent.samples = append(changePercent.samples, sample{labels, a.QuotePrice.ChangePercent})
+			volume.samples = append(volume.samples, sample{labels, a.QuoteExtended.Volume})
+
+			// Symbols in the watchlist without a position only have quote metrics
+			if a.Position.Quantity == 0 {
+				continue
+			}
+
+			positionValue.samples = append(positionValue.samples, sample{labels, a.Position.Value})
+			positionCost.samples = append(positionCost.samples, sample{labels, a.Position.Cost})
+			positionWeight.samples = append(positionWeight.samples, sample{labels, a.Position.Weight})
+		}
+	}
+
+	return []metric{price, changePercent, volume, positionValue, positionCost, positionWeight}
+}
+
+// getHealthMetrics returns the monitor error count and the connection state, reconnect count, and time since the last update of each source
+func (e *Exporter) getHealthMetrics() []metric {
+
+	monitorErrors := metric{name: "ticker_monitor_errors_total", help: "Errors from monitors while getting quotes", metricType: typeCounter}
+	reconnects := metric{name: "ticker_stream_reconnects_total", help: "Attempts to reconnect to a streaming source", metricType: typeCounter}
+	connected := metric{name: "ticker_stream_connected", help: "Whether a streaming source is connected", metricType: typeGauge}
+	lastUpdateAge := metric{name: "ticker_last_update_age_seconds", help: "Seconds since the latest quote from a source", metricType: typeGauge}
+
+	monitorErrors.samples = []sample{{value: float64(e.errors)}}
+
+	for _, source := range getSources(e.streamStatuses) {
+		labels := [][2]string{{"source", source.String()}}
+		value := 0.0
+
+		if e.streamStatuses[source] == c.StreamStatusConnected {
+			value = 1
+		}
+
+		connected.samples = append(connected.samples, sample{labels, value})
+		reconnects.samples = append(reconnects.samples, sample{labels, float64(e.reconnects[source])})
+	}
+
+	now := e.now()
+
+	for _, source := range getSources(e.lastUpdates) {
+		lastUpdateAge.samples = append(lastUpdateAge.samples, sample{
+			labels: [][2]string{{"source", source.String()}},
+			value:  now.Sub(e.lastUpdates[source]).Seconds(),
+		})
+	}
+
+	return []metric{monitorErrors, reconnects, connected, lastUpdateAge}
+}
+
+// getSources returns the sources in a map ordered by name
+func getSources[T any](bySource map[c.QuoteSource]T) []c.QuoteSource {
+
+	sources := make([]c.QuoteSource, 0, len(bySource))
+	for source := range bySource {
+		sources = append(sources, source)
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].String() < sources[j].String()
+	})
+
+	return sources
+}
+
+// formatLabels returns the label set of a sample with values escaped
+func formatLabels(labels [][2]string) string {
+
+	if len(labels) == 0 {
+		return ""
+	}
+
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		pairs = append(pairs, label[0]+`="`+escape.Replace(label[1])+`"`)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
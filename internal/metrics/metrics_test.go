@@ -0,0 +1,152 @@
+package metrics_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+)
+
+var _ = Describe("Metrics", func() {
+
+	var (
+		start    = time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+		now      time.Time
+		exporter *metrics.Exporter
+	)
+
+	BeforeEach(func() {
+		now = start
+		exporter = metrics.NewExporter(metrics.Config{}, metrics.WithNow(func() time.Time { return now }))
+	})
+
+	Describe("SetAssets", func() {
+
+		It("should export quote and position gauges for each symbol labelled by group, source, and currency", func() {
+			exporter.SetAssets("default", []c.Asset{
+				{
+					Symbol:        "GOOG",
+					Currency:      c.Currency{FromCurrencyCode: "USD", ToCurrencyCode: "EUR"},
+					QuoteSource:   c.QuoteSourceYahoo,
+					QuotePrice:    c.QuotePrice{Price: 2838.42, ChangePercent: 1.5},
+					QuoteExtended: c.QuoteExtended{Volume: 1200000},
+					Position:      c.Position{Quantity: 10, Value: 28384.2, Cost: 10000, Weight: 97},
+				},
+				{
+					Symbol:      "BTC.X",
+					Currency:    c.Currency{FromCurrencyCode: "USD"},
+					QuoteSource: c.QuoteSourceCoinbase,
+					QuotePrice:  c.QuotePrice{Price: 50000, ChangePercent: -2},
+				},
+			})
+
+			Expect(exporter.String()).To(Equal(strings.Join([]string{
+				"# HELP ticker_price Price of the symbol",
+				"# TYPE ticker_price gauge",
+				`ticker_price{group="default",symbol="GOOG",source="yahoo",currency="EUR"} 2838.42`,
+				`ticker_price{group="default",symbol="BTC.X",source="coinbase",currency="USD"} 50000`,
+				"# HELP ticker_change_percent Percent change in price since the previous close",
+				"# TYPE ticker_change_percent gauge",
+				`ticker_change_percent{group="default",symbol="GOOG",source="yahoo",currency="EUR"} 1.5`,
+				`ticker_change_percent{group="default",symbol="BTC.X",source="coinbase",currency="USD"} -2`,
+				"# HELP ticker_volume Volume traded during the day",
+				"# TYPE ticker_volume gauge",
+				`ticker_volume{group="default",symbol="GOOG",source="yahoo",currency="EUR"} 1.2e+06`,
+				`ticker_volume{group="default",symbol="BTC.X",source="coinbase",currency="USD"} 0`,
+				"# HELP ticker_position_value Value of the position in the symbol",
+				"# TYPE ticker_position_value gauge",
+				`ticker_position_value{group="default",symbol="GOOG",source="yahoo",currency="EUR"} 28384.2`,
+				"# HELP ticker_position_cost Cost basis of the position in the symbol",
+				"# TYPE ticker_position_cost gauge",
+				`ticker_position_cost{group="default",symbol="GOOG",source="yahoo",currency="EUR"} 10000`,
+				"# HELP ticker_position_weight Percent of the value of the group held in the symbol",
+				"# TYPE ticker_position_weight gauge",
+				`ticker_position_weight{group="default",symbol="GOOG",source="yahoo",currency="EUR"} 97`,
+				"# HELP ticker_monitor_errors_total Errors from monitors while getting quotes",
+				"# TYPE ticker_monitor_errors_total counter",
+				"ticker_monitor_errors_total 0",
+				"",
+			}, "\n")))
+		})
+
+		It("should order groups by name and replace the assets of a group when set again", func() {
+			exporter.SetAssets("retirement", []c.Asset{{Symbol: "VTI", QuotePrice: c.QuotePrice{Price: 250}}})
+			exporter.SetAssets("default", []c.Asset{{Symbol: "GOOG", QuotePrice: c.QuotePrice{Price: 2838.42}}})
+			exporter.SetAssets("default", []c.Asset{{Symbol: "RBLX", QuotePrice: c.QuotePrice{Price: 87.88}}})
+
+			output := exporter.String()
+
+			Expect(output).To(ContainSubstring(`ticker_price{group="default",symbol="RBLX",source="yahoo",currency=""} 87.88` + "\n" + `ticker_price{group="retirement",symbol="VTI",source="yahoo",currency=""} 250`))
+			Expect(output).NotTo(ContainSubstring("GOOG"))
+		})
+
+		It("should escape label values", func() {
+			exporter.SetAssets(`my "main"\group`, []c.Asset{{Symbol: "GOOG"}})
+
+			Expect(exporter.String()).To(ContainSubstring(`ticker_price{group="my \"main\"\\group",symbol="GOOG"`))
+		})
+
+	})
+
+	Describe("health metrics", func() {
+
+		It("should count errors and stream reconnects and export the connection state and time since the last update of each source", func() {
+			exporter.RecordError()
+			exporter.RecordError()
+			exporter.RecordUpdate(c.QuoteSourceYahoo)
+			exporter.RecordUpdate(c.QuoteSourceCoinbase)
+			exporter.SetStreamStatus(c.StreamStatusUpdate{Source: c.QuoteSourceCoinbase, Status: c.StreamStatusConnected})
+			exporter.SetStreamStatus(c.StreamStatusUpdate{Source: c.QuoteSourceCoinbase, Status: c.StreamStatusDisconnected, Err: errors.New("connection reset")})
+			exporter.SetStreamStatus(c.StreamStatusUpdate{Source: c.QuoteSourceCoinbase, Status: c.StreamStatusReconnecting, Attempt: 1})
+			exporter.SetStreamStatus(c.StreamStatusUpdate{Source: c.QuoteSourceCoinbase, Status: c.StreamStatusReconnecting, Attempt: 2})
+			exporter.SetStreamStatus(c.StreamStatusUpdate{Source: c.QuoteSourceCoinCap, Status: c.StreamStatusConnected})
+
+			now = start.Add(90 * time.Second)
+			exporter.RecordUpdate(c.QuoteSourceYahoo)
+			now = start.Add(100 * time.Second)
+
+			Expect(exporter.String()).To(Equal(strings.Join([]string{
+				"# HELP ticker_monitor_errors_total Errors from monitors while getting quotes",
+				"# TYPE ticker_monitor_errors_total counter",
+				"ticker_monitor_errors_total 2",
+				"# HELP ticker_stream_reconnects_total Attempts to reconnect to a streaming source",
+				"# TYPE ticker_stream_reconnects_total counter",
+				`ticker_stream_reconnects_total{source="coinbase"} 2`,
+				`ticker_stream_reconnects_total{source="coincap"} 0`,
+				"# HELP ticker_stream_connected Whether a streaming source is connected",
+				"# TYPE ticker_stream_connected gauge",
+				`ticker_stream_connected{source="coinbase"} 0`,
+				`ticker_stream_connected{source="coincap"} 1`,
+				"# HELP ticker_last_update_age_seconds Seconds since the latest quote from a source",
+				"# TYPE ticker_last_update_age_seconds gauge",
+				`ticker_last_update_age_seconds{source="coinbase"} 100`,
+				`ticker_last_update_age_seconds{source="yahoo"} 10`,
+				"",
+			}, "\n")))
+		})
+
+	})
+
+	Describe("ServeHTTP", func() {
+
+		It("should respond with the metrics in the Prometheus text format", func() {
+			exporter.SetAssets("default", []c.Asset{{Symbol: "GOOG", QuotePrice: c.QuotePrice{Price: 2838.42}}})
+
+			recorder := httptest.NewRecorder()
+			exporter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal(metrics.ContentType))
+			Expect(recorder.Body.String()).To(Equal(exporter.String()))
+		})
+
+	})
+
+})
@@ -0,0 +1,214 @@
+package monitorPriceCash
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// SymbolSuffix is the suffix of ticker symbols for cash balances (e.g. USD.CASH)
+const SymbolSuffix = ".CASH"
+
+// MonitorPriceCash represents a monitor for cash balances which always have a price of one unit of their currency
+type MonitorPriceCash struct {
+	symbols                  []string
+	currenciesRequested      map[string]bool           // Currencies for which currency rates have already been requested
+	assetQuotesCache         []*c.AssetQuote           // Asset quotes for all assets retrieved at start or on symbol change
+	currencyRatesCache       map[string]c.CurrencyRate // Cache of currency rates
+	mu                       sync.RWMutex
+	muCurrencyRates          sync.RWMutex
+	ctx                      context.Context
+	cancel                   context.CancelFunc
+	isStarted                bool
+	chanRequestCurrencyRates chan []string
+}
+
+// Config contains the required configuration for the cash monitor
+type Config struct {
+	Ctx                      context.Context
+	ChanRequestCurrencyRates chan []string
+}
+
+// NewMonitorPriceCash creates a new monitor for cash balances
+func NewMonitorPriceCash(config Config) *MonitorPriceCash {
+	ctx, cancel := context.WithCancel(config.Ctx)
+
+	return &MonitorPriceCash{
+		assetQuotesCache:         make([]*c.AssetQuote, 0),
+		currenciesRequested:      make(map[string]bool),
+		ctx:                      ctx,
+		cancel:                   cancel,
+		chanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+	}
+}
+
+// GetSymbol returns the ticker symbol for a cash balance in a currency
+func GetSymbol(currency string) string {
+	return strings.ToUpper(currency) + SymbolSuffix
+}
+
+// GetAssetQuotes returns the asset quotes for the current symbols
+func (m *MonitorPriceCash) GetAssetQuotes(ignoreCache ...bool) ([]c.AssetQuote, error) {
+
+	// The price of cash never changes so there is no remote source to refresh from
+	if len(ignoreCache) > 0 && ignoreCache[0] {
+		m.replaceCache()
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]c.AssetQuote, len(m.assetQuotesCache))
+	for i, quote := range m.assetQuotesCache {
+		result[i] = *quote
+	}
+
+	return result, nil
+}
+
+// SetSymbols sets the symbols to monitor
+func (m *MonitorPriceCash) SetSymbols(symbols []string, _ int) error {
+
+	m.mu.Lock()
+
+	// Deduplicate symbols since input may have duplicates
+	symbols = slices.Clone(symbols)
+	slices.Sort(symbols)
+	m.symbols = slices.Compact(symbols)
+
+	m.mu.Unlock()
+
+	assetQuotes := m.replaceCache()
+
+	m.requestCurrencyRates(assetQuotes)
+
+	return nil
+}
+
+// Start the monitor
+func (m *MonitorPriceCash) Start() error {
+
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	m.replaceCache()
+
+	m.isStarted = true
+
+	return nil
+}
+
+// Stop the monitor
+func (m *MonitorPriceCash) Stop() error {
+
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.cancel()
+
+	return nil
+}
+
+// SetCurrencyRates sets the currency rates and applies them to the cached asset quotes
+func (m *MonitorPriceCash) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.muCurrencyRates.Lock()
+	m.currencyRatesCache = currencyRates
+	m.muCurrencyRates.Unlock()
+
+	m.replaceCache()
+
+	return nil
+}
+
+// replaceCache builds asset quotes for each cash symbol, adds currency rates, and replaces the asset quotes cache
+func (m *MonitorPriceCash) replaceCache() []*c.AssetQuote {
+
+	cache := make([]*c.AssetQuote, 0)
+
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
+	m.muCurrencyRates.RLock()
+	for _, symbol := range symbols {
+
+		if !strings.HasSuffix(symbol, SymbolSuffix) {
+			continue
+		}
+
+		quote := transformCash(symbol)
+
+		if currencyRate, exists := m.currencyRatesCache[quote.Currency.FromCurrencyCode]; exists {
+			quote.Currency.Rate = currencyRate.Rate
+			quote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		cache = append(cache, &quote)
+	}
+	m.muCurrencyRates.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assetQuotesCache = cache
+
+	return m.assetQuotesCache
+}
+
+func (m *MonitorPriceCash) requestCurrencyRates(assetQuotes []*c.AssetQuote) {
+
+	fromCurrenciesToRequest := make([]string, 0)
+
+	m.muCurrencyRates.Lock()
+	for _, quote := range assetQuotes {
+		if !m.currenciesRequested[quote.Currency.FromCurrencyCode] {
+			m.currenciesRequested[quote.Currency.FromCurrencyCode] = true
+			fromCurrenciesToRequest = append(fromCurrenciesToRequest, quote.Currency.FromCurrencyCode)
+		}
+	}
+	m.muCurrencyRates.Unlock()
+
+	if len(fromCurrenciesToRequest) == 0 {
+		return
+	}
+
+	m.chanRequestCurrencyRates <- fromCurrenciesToRequest
+}
+
+// transformCash converts a cash symbol into an asset quote with a price of one unit of its currency
+func transformCash(symbol string) c.AssetQuote {
+
+	currency := strings.TrimSuffix(symbol, SymbolSuffix)
+
+	return c.AssetQuote{
+		Name:   "Cash",
+		Symbol: symbol,
+		Class:  c.AssetClassCash,
+		Currency: c.Currency{
+			FromCurrencyCode: currency,
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          1.0,
+			PricePrevClose: 1.0,
+			PriceOpen:      1.0,
+			PriceDayHigh:   1.0,
+			PriceDayLow:    1.0,
+		},
+		QuoteSource: c.QuoteSourceCash,
+		Exchange: c.Exchange{
+			Name:                    "Cash",
+			State:                   c.ExchangeStateClosed,
+			IsActive:                false,
+			IsRegularTradingSession: false,
+		},
+		Meta: c.Meta{
+			SymbolInSourceAPI: symbol,
+		},
+	}
+}
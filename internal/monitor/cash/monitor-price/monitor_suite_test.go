@@ -0,0 +1,13 @@
+package monitorPriceCash_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCash(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cash Suite")
+}
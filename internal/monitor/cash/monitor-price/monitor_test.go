@@ -0,0 +1,110 @@
+package monitorPriceCash_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceCash "github.com/achannarasappa/ticker/v5/internal/monitor/cash/monitor-price"
+)
+
+var _ = Describe("Monitor Cash", func() {
+	var (
+		chanRequestCurrencyRates chan []string
+	)
+
+	BeforeEach(func() {
+		chanRequestCurrencyRates = make(chan []string, 1)
+	})
+
+	Describe("GetSymbol", func() {
+		It("should return the cash symbol for the currency", func() {
+			Expect(monitorPriceCash.GetSymbol("eur")).To(Equal("EUR.CASH"))
+		})
+	})
+
+	Describe("SetSymbols", func() {
+		It("should build asset quotes with a price of one unit of the currency", func() {
+			monitor := monitorPriceCash.NewMonitorPriceCash(monitorPriceCash.Config{
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: chanRequestCurrencyRates,
+			})
+
+			err := monitor.SetSymbols([]string{"EUR.CASH", "USD.CASH", "EUR.CASH"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(2))
+			Expect(assetQuotes[0].Name).To(Equal("Cash"))
+			Expect(assetQuotes[0].Symbol).To(Equal("EUR.CASH"))
+			Expect(assetQuotes[0].Class).To(Equal(c.AssetClassCash))
+			Expect(assetQuotes[0].QuoteSource).To(Equal(c.QuoteSourceCash))
+			Expect(assetQuotes[0].Currency.FromCurrencyCode).To(Equal("EUR"))
+			Expect(assetQuotes[0].QuotePrice.Price).To(Equal(1.0))
+			Expect(assetQuotes[0].QuotePrice.Change).To(BeZero())
+			Expect(assetQuotes[1].Currency.FromCurrencyCode).To(Equal("USD"))
+			Expect(chanRequestCurrencyRates).To(Receive(Equal([]string{"EUR", "USD"})))
+		})
+
+		When("a symbol is not a cash symbol", func() {
+			It("should not return a quote for the symbol", func() {
+				monitor := monitorPriceCash.NewMonitorPriceCash(monitorPriceCash.Config{
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: chanRequestCurrencyRates,
+				})
+
+				monitor.SetSymbols([]string{"AAPL"}, 0)
+
+				assetQuotes, _ := monitor.GetAssetQuotes()
+				Expect(assetQuotes).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should apply the currency rates to the asset quotes", func() {
+			monitor := monitorPriceCash.NewMonitorPriceCash(monitorPriceCash.Config{
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: chanRequestCurrencyRates,
+			})
+			monitor.SetSymbols([]string{"EUR.CASH"}, 0)
+
+			err := monitor.SetCurrencyRates(c.CurrencyRates{
+				"EUR": {FromCurrency: "EUR", ToCurrency: "USD", Rate: 1.1},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes[0].Currency.Rate).To(Equal(1.1))
+			Expect(assetQuotes[0].Currency.ToCurrencyCode).To(Equal("USD"))
+		})
+	})
+
+	Describe("Start", func() {
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCash.NewMonitorPriceCash(monitorPriceCash.Config{
+					Ctx: context.Background(),
+				})
+
+				Expect(monitor.Start()).To(Succeed())
+				Expect(monitor.Start()).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCash.NewMonitorPriceCash(monitorPriceCash.Config{
+					Ctx: context.Background(),
+				})
+
+				Expect(monitor.Stop()).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
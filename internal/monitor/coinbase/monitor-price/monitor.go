@@ -33,6 +33,7 @@ type MonitorPriceCoinbase struct {
 	currencyHasRequestedRates        bool                     // Whether the currency rates have been requested; this is used in place of map of every trading pair to currency since there is a single default currency of USD
 	chanStreamUpdateQuotePrice       chan c.MessageUpdate[c.QuotePrice]
 	chanStreamUpdateQuoteExtended    chan c.MessageUpdate[c.QuoteExtended]
+	chanStreamUpdateQuoteDepth       chan c.MessageUpdate[c.QuoteDepth]
 	chanStreamUpdateExchange         chan c.MessageUpdate[c.Exchange]
 	chanPollUpdateAssetQuote         chan c.MessageUpdate[c.AssetQuote]
 	chanError                        chan error
@@ -57,6 +58,7 @@ type Config struct {
 	ChanError                chan error
 	ChanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
 	ChanRequestCurrencyRates chan []string
+	ChanUpdateStreamStatus   chan c.StreamStatusUpdate // Optional channel for streaming connection state changes
 }
 
 // Option defines an option for configuring the monitor
@@ -73,6 +75,7 @@ func NewMonitorPriceCoinbase(config Config, opts ...Option) *MonitorPriceCoinbas
 		productIdsToUnderlyingProductIds: make(map[string]string),
 		chanStreamUpdateQuotePrice:       make(chan c.MessageUpdate[c.QuotePrice]),
 		chanStreamUpdateQuoteExtended:    make(chan c.MessageUpdate[c.QuoteExtended]),
+		chanStreamUpdateQuoteDepth:       make(chan c.MessageUpdate[c.QuoteDepth]),
 		chanStreamUpdateExchange:         make(chan c.MessageUpdate[c.Exchange]),
 		chanPollUpdateAssetQuote:         make(chan c.MessageUpdate[c.AssetQuote]),
 		chanError:                        config.ChanError,
@@ -93,6 +96,9 @@ func NewMonitorPriceCoinbase(config Config, opts ...Option) *MonitorPriceCoinbas
 	streamerConfig := streamer.StreamerConfig{
 		ChanStreamUpdateQuotePrice:    monitor.chanStreamUpdateQuotePrice,
 		ChanStreamUpdateQuoteExtended: monitor.chanStreamUpdateQuoteExtended,
+		ChanStreamUpdateQuoteDepth:    monitor.chanStreamUpdateQuoteDepth,
+		ChanStreamUpdateStatus:        config.ChanUpdateStreamStatus,
+		ChanError:                     monitor.chanError,
 	}
 
 	monitor.streamer = streamer.NewStreamer(ctx, streamerConfig)
@@ -112,6 +118,14 @@ func WithStreamingURL(url string) Option {
 	}
 }
 
+// WithReconnectBackoff sets the initial and maximum delay between attempts to reconnect to the streaming API
+func WithReconnectBackoff(initial time.Duration, maximum time.Duration) Option {
+	return func(m *MonitorPriceCoinbase) {
+		// TODO: handle error
+		m.streamer.SetReconnectBackoff(initial, maximum) //nolint:errcheck
+	}
+}
+
 // WithRefreshInterval sets the refresh interval for the monitor
 func WithRefreshInterval(interval time.Duration) Option {
 	return func(m *MonitorPriceCoinbase) {
@@ -283,6 +297,40 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 			// TODO: handle extended quote
 			continue
 
+		case updateMessage := <-m.chanStreamUpdateQuoteDepth:
+
+			// Check if cache exists and values have changed before acquiring write lock
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if the best bid and ask have not changed
+			if assetQuote.QuoteDepth == updateMessage.Data {
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			m.mu.Lock()
+			assetQuote.QuoteDepth = updateMessage.Data
+			m.mu.Unlock()
+
+			// Send a message with an updated quote
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+
 		case updateMessage := <-m.chanPollUpdateAssetQuote:
 
 			// Check if cache exists and values have changed before acquiring write lock
@@ -301,7 +349,8 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 			// Skip update if nothing has changed
 			if assetQuote.QuotePrice.Price == updateMessage.Data.QuotePrice.Price &&
 				assetQuote.Exchange.IsActive == updateMessage.Data.Exchange.IsActive &&
-				assetQuote.QuotePrice.PriceDayHigh == updateMessage.Data.QuotePrice.PriceDayHigh {
+				assetQuote.QuotePrice.PriceDayHigh == updateMessage.Data.QuotePrice.PriceDayHigh &&
+				assetQuote.QuoteFutures.OpenInterest == updateMessage.Data.QuoteFutures.OpenInterest {
 
 				m.mu.RUnlock()
 
@@ -325,6 +374,8 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 			assetQuote.QuoteExtended.Volume = updateMessage.Data.QuoteExtended.Volume
 			assetQuote.Exchange.IsActive = updateMessage.Data.Exchange.IsActive
 			assetQuote.Exchange.IsRegularTradingSession = updateMessage.Data.Exchange.IsRegularTradingSession
+			assetQuote.QuoteFutures.OpenInterest = updateMessage.Data.QuoteFutures.OpenInterest
+			assetQuote.QuoteFutures.Basis = getBasis(assetQuote.QuotePrice.Price, assetQuote.QuoteFutures.IndexPrice)
 
 			m.mu.Unlock()
 
@@ -342,6 +393,9 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 			var assetQuote *c.AssetQuote
 			var exists bool
 
+			// Spot prices are the index price of futures contracts with the product as the underlying asset
+			m.updateFuturesIndexPrice(updateMessage.ID, updateMessage.Data.Price, updateMessage.VersionVector)
+
 			// Check if cache exists and values have changed before acquiring write lock
 			m.mu.RLock()
 
@@ -376,8 +430,6 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 
 			m.mu.Unlock()
 
-			// TODO: when underlying asset price changes, callback to update basis else skip
-
 			// Send a message with an updated quote
 			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
 				ID:            assetQuote.Symbol,
@@ -390,6 +442,45 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 	}
 }
 
+// updateFuturesIndexPrice sets the index price and basis on futures contracts with the given underlying product and sends updated quotes
+func (m *MonitorPriceCoinbase) updateFuturesIndexPrice(productIdUnderlying string, priceUnderlying float64, versionVector int) {
+
+	assetQuotesUpdated := make([]c.AssetQuote, 0)
+
+	m.mu.Lock()
+
+	for _, assetQuote := range m.assetQuotesCache {
+		if assetQuote.Class != c.AssetClassFuturesContract ||
+			assetQuote.QuoteFutures.SymbolUnderlying != productIdUnderlying ||
+			assetQuote.QuoteFutures.IndexPrice == priceUnderlying {
+			continue
+		}
+
+		assetQuote.QuoteFutures.IndexPrice = priceUnderlying
+		assetQuote.QuoteFutures.Basis = getBasis(assetQuote.QuotePrice.Price, priceUnderlying)
+		assetQuotesUpdated = append(assetQuotesUpdated, *assetQuote)
+	}
+
+	m.mu.Unlock()
+
+	for _, assetQuote := range assetQuotesUpdated {
+		m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+			ID:            assetQuote.Symbol,
+			Data:          assetQuote,
+			VersionVector: versionVector,
+		}
+	}
+}
+
+// getBasis returns the premium of a futures price over the spot price of its underlying asset as a percentage
+func getBasis(price float64, priceUnderlying float64) float64 {
+	if priceUnderlying == 0 {
+		return 0
+	}
+
+	return (price - priceUnderlying) / priceUnderlying * 100
+}
+
 func (m *MonitorPriceCoinbase) SetCurrencyRates(currencyRates c.CurrencyRates) error {
 	m.muCurrencyRates.Lock()
 	m.currencyRatesCache = currencyRates
@@ -405,6 +496,11 @@ func (m *MonitorPriceCoinbase) SetCurrencyRates(currencyRates c.CurrencyRates) e
 	return nil
 }
 
+// GetPriceHistory retrieves historical prices for a product
+func (m *MonitorPriceCoinbase) GetPriceHistory(productID string, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error) {
+	return m.unaryAPI.GetPriceHistory(productID, historyRange, interval)
+}
+
 // Get asset quotes from unary API, add futures quotes, filter out assets not explicitly requested, and replace the asset quotes cache
 func (m *MonitorPriceCoinbase) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote, error) {
 
@@ -440,7 +536,7 @@ func (m *MonitorPriceCoinbase) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote,
 			// Check if there is a quote for the underlying asset
 			if quoteUnderlying, exists := assetQuotesByProductId[quote.QuoteFutures.SymbolUnderlying]; exists {
 				quote.QuoteFutures.IndexPrice = quoteUnderlying.QuotePrice.Price
-				quote.QuoteFutures.Basis = (quoteUnderlying.QuotePrice.Price - quote.QuotePrice.Price) / quote.QuotePrice.Price
+				quote.QuoteFutures.Basis = getBasis(quote.QuotePrice.Price, quote.QuoteFutures.IndexPrice)
 			}
 		}
 
@@ -459,6 +555,13 @@ func (m *MonitorPriceCoinbase) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote,
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// Best bid and ask are only available from the streaming API so carry them over from the previous cache
+	for _, quote := range assetQuotesEnriched {
+		if quotePrevious, exists := m.assetQuotesCacheLookup[quote.Meta.SymbolInSourceAPI]; exists {
+			quote.QuoteDepth = quotePrevious.QuoteDepth
+		}
+	}
+
 	m.assetQuotesCache = assetQuotesEnriched
 	m.assetQuotesCacheLookup = lookup
 
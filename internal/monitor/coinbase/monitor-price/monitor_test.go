@@ -14,6 +14,7 @@ import (
 	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/unary"
 	testWs "github.com/achannarasappa/ticker/v5/test/websocket"
 	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Monitor Coinbase", func() {
@@ -150,6 +151,8 @@ var _ = Describe("Monitor Coinbase", func() {
 			Expect(assetQuotes[0].Symbol).To(Equal("BIT-31JAN25-CDE.CB"))
 			Expect(assetQuotes[0].Name).To(Equal("Bitcoin January 2025 Future"))
 			Expect(assetQuotes[0].Class).To(Equal(c.AssetClassFuturesContract))
+			Expect(assetQuotes[0].QuoteFutures.IndexPrice).To(Equal(50000.00))
+			Expect(assetQuotes[0].QuoteFutures.Basis).To(Equal(20.0))
 			Expect(assetQuotes[1].Symbol).To(Equal("ETH.CB"))
 			Expect(assetQuotes[1].Name).To(Equal("Ethereum"))
 			Expect(assetQuotes[1].Class).To(Equal(c.AssetClassCryptocurrency))
@@ -603,6 +606,149 @@ var _ = Describe("Monitor Coinbase", func() {
 				Expect(receivedQuote.Symbol).To(Equal("ETH.CB"))
 			})
 
+			It("should send the best bid and ask with the spread to the channel", func() {
+				server.RouteToHandler("GET", "/api/v3/brokerage/market/products",
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products", "product_ids=ETH-USD"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+							Products: []unary.ResponseQuote{
+								{
+									Symbol:       "ETH",
+									ProductID:    "ETH-USD",
+									ShortName:    "Ethereum",
+									Price:        "1285.22",
+									MarketState:  "online",
+									Currency:     "USD",
+									ExchangeName: "CBE",
+									ProductType:  "SPOT",
+								},
+							},
+						}),
+					),
+				)
+
+				inputTick := `{
+					"type": "ticker",
+					"product_id": "ETH-USD",
+					"price": "1285.22",
+					"best_bid": "1285.00",
+					"best_bid_size": "0.5",
+					"best_ask": "1285.50",
+					"best_ask_size": "1.5"
+				}`
+				inputServer := testWs.NewTestServer([]string{inputTick})
+
+				updateChan := make(chan c.MessageUpdate[c.AssetQuote], 10)
+
+				monitor := monitorPriceCoinbase.NewMonitorPriceCoinbase(monitorPriceCoinbase.Config{
+					UnaryURL:                 server.URL(),
+					ChanUpdateAssetQuote:     updateChan,
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceCoinbase.WithRefreshInterval(10*time.Second),
+					monitorPriceCoinbase.WithStreamingURL("ws://"+inputServer.URL[7:]))
+
+				monitor.SetSymbols([]string{"ETH-USD"}, 0)
+				monitor.Start()
+				defer monitor.Stop()
+
+				Eventually(updateChan, 5*time.Second).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID": Equal("ETH.CB"),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"QuoteDepth": g.MatchAllFields(g.Fields{
+								"BidPrice":      Equal(1285.00),
+								"BidSize":       Equal(0.5),
+								"AskPrice":      Equal(1285.50),
+								"AskSize":       Equal(1.5),
+								"Spread":        BeNumerically("~", 0.5, 0.0001),
+								"SpreadPercent": BeNumerically("~", 0.0389, 0.0001),
+							}),
+						}),
+					}),
+				))
+			})
+
+			When("the product is the underlying asset of a futures contract", func() {
+				It("should send the futures quote with the updated index price and basis to the channel", func() {
+					quoteFutures := unary.ResponseQuote{
+						Symbol:       "BIT-31JAN25-CDE",
+						ProductID:    "BIT-31JAN25-CDE",
+						ShortName:    "Bitcoin Futures",
+						Price:        "60000.00",
+						MarketState:  "online",
+						Currency:     "USD",
+						ExchangeName: "CDE",
+						ProductType:  "FUTURE",
+						FutureProductDetails: unary.ResponseQuoteFutureProductDetails{
+							ContractRootUnit: "BTC",
+							OpenInterest:     "1500",
+						},
+					}
+					quoteSpot := unary.ResponseQuote{
+						Symbol:       "BTC",
+						ProductID:    "BTC-USD",
+						ShortName:    "Bitcoin",
+						Price:        "50000.00",
+						MarketState:  "online",
+						Currency:     "USD",
+						ExchangeName: "CBE",
+						ProductType:  "SPOT",
+					}
+					responseAll := ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products", "product_ids=BIT-31JAN25-CDE&product_ids=BTC-USD"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+							Products: []unary.ResponseQuote{quoteFutures, quoteSpot},
+						}),
+					)
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products", "product_ids=BIT-31JAN25-CDE"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+								Products: []unary.ResponseQuote{quoteFutures},
+							}),
+						),
+						responseAll,
+						responseAll,
+					)
+
+					inputTick := `{
+						"type": "ticker",
+						"product_id": "BTC-USD",
+						"price": "48000.00",
+						"open_24h": "47000.00"
+					}`
+					inputServer := testWs.NewTestServer([]string{inputTick})
+
+					updateChan := make(chan c.MessageUpdate[c.AssetQuote], 10)
+
+					monitor := monitorPriceCoinbase.NewMonitorPriceCoinbase(monitorPriceCoinbase.Config{
+						UnaryURL:                 server.URL(),
+						ChanUpdateAssetQuote:     updateChan,
+						Ctx:                      context.Background(),
+						ChanRequestCurrencyRates: make(chan []string, 1),
+					}, monitorPriceCoinbase.WithRefreshInterval(10*time.Second),
+						monitorPriceCoinbase.WithStreamingURL("ws://"+inputServer.URL[7:]))
+
+					Expect(monitor.SetSymbols([]string{"BIT-31JAN25-CDE"}, 0)).To(Succeed())
+					Expect(monitor.Start()).To(Succeed())
+					defer monitor.Stop()
+
+					Eventually(updateChan, 5*time.Second).Should(Receive(
+						g.MatchFields(g.IgnoreExtras, g.Fields{
+							"ID": Equal("BIT-31JAN25-CDE.CB"),
+							"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"QuoteFutures": g.MatchFields(g.IgnoreExtras, g.Fields{
+									"IndexPrice":   Equal(48000.00),
+									"Basis":        BeNumerically("~", 25.0, 0.0001),
+									"OpenInterest": Equal(1500.0),
+								}),
+							}),
+						}),
+					))
+				})
+			})
+
 			When("the price has not changed", func() {
 				It("should not send updates to the channel", func() {
 					server.RouteToHandler("GET", "/api/v3/brokerage/market/products",
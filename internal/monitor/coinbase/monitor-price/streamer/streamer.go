@@ -2,12 +2,16 @@ package streamer
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
+	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/util"
 	"github.com/gorilla/websocket"
 )
 
@@ -17,6 +21,20 @@ type messageSubscription struct {
 	Channels   []string `json:"channels"`
 }
 
+type messageSubscriptions struct {
+	Type     string                        `json:"type"`
+	Channels []messageSubscriptionsChannel `json:"channels"`
+}
+
+type messageSubscriptionsChannel struct {
+	Name       string   `json:"name"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+type messageType struct {
+	Type string `json:"type"`
+}
+
 type messagePriceTick struct {
 	Type        string `json:"type"`
 	Sequence    int64  `json:"sequence"`
@@ -37,17 +55,27 @@ type messagePriceTick struct {
 	LastSize    string `json:"last_size"`
 }
 
+// errNotConnected is returned when writing before the first connection is established
+var errNotConnected = errors.New("not connected")
+
 type Streamer struct {
 	symbols                       []string
+	symbolsSubscribed             []string // Product IDs confirmed by the most recent subscriptions acknowledgement from the server
+	subscriptionsPending          int      // Number of subscription changes sent which have not been acknowledged
+	subscriptionsRetried          bool     // Whether a correction has been sent for the current symbols to avoid retrying indefinitely
 	conn                          *websocket.Conn
 	isStarted                     bool
 	url                           string
 	subscriptionChan              chan messageSubscription
+	reconnector                   *util.Reconnector
 	wg                            sync.WaitGroup
+	mu                            sync.RWMutex // Guards conn, symbols, subscription state, and versionVector which change on reconnect and symbol change
+	muWrite                       sync.Mutex   // Websocket connections support only one concurrent writer
 	ctx                           context.Context
 	cancel                        context.CancelFunc
 	chanStreamUpdateQuotePrice    chan c.MessageUpdate[c.QuotePrice]
 	chanStreamUpdateQuoteExtended chan c.MessageUpdate[c.QuoteExtended]
+	chanStreamUpdateQuoteDepth    chan c.MessageUpdate[c.QuoteDepth]
 	chanError                     chan error
 	versionVector                 int
 }
@@ -55,6 +83,8 @@ type Streamer struct {
 type StreamerConfig struct {
 	ChanStreamUpdateQuotePrice    chan c.MessageUpdate[c.QuotePrice]
 	ChanStreamUpdateQuoteExtended chan c.MessageUpdate[c.QuoteExtended]
+	ChanStreamUpdateQuoteDepth    chan c.MessageUpdate[c.QuoteDepth] // Optional channel for best bid and ask updates
+	ChanStreamUpdateStatus        chan c.StreamStatusUpdate          // Optional channel for connection state changes
 	ChanError                     chan error
 }
 
@@ -64,11 +94,13 @@ func NewStreamer(ctx context.Context, config StreamerConfig) *Streamer {
 	s := &Streamer{
 		chanStreamUpdateQuotePrice:    config.ChanStreamUpdateQuotePrice,
 		chanStreamUpdateQuoteExtended: config.ChanStreamUpdateQuoteExtended,
+		chanStreamUpdateQuoteDepth:    config.ChanStreamUpdateQuoteDepth,
 		chanError:                     config.ChanError,
 		ctx:                           ctx,
 		cancel:                        cancel,
 		wg:                            sync.WaitGroup{},
 		subscriptionChan:              make(chan messageSubscription),
+		reconnector:                   util.NewReconnector(c.QuoteSourceCoinbase, config.ChanStreamUpdateStatus),
 		versionVector:                 0,
 	}
 
@@ -85,14 +117,19 @@ func (s *Streamer) Start() error {
 		return nil
 	}
 
+	// Connection failures are retried so an invalid URL is rejected up front since it would never connect
+	err := util.ValidateURL(s.url)
+	if err != nil {
+		return err
+	}
+
 	// Create connection channel for result
 	connChan := make(chan *websocket.Conn, 1)
 	errChan := make(chan error, 1)
 
 	// Connect the websocket address in a goroutine
 	go func() {
-		url := s.url
-		conn, _, err := websocket.DefaultDialer.DialContext(s.ctx, url, nil)
+		conn, err := s.dial()
 		if err != nil {
 			errChan <- err
 
@@ -101,29 +138,38 @@ func (s *Streamer) Start() error {
 		connChan <- conn
 	}()
 
-	// Wait for either connection, error, or context cancellation
+	// Wait for either connection, error, or context cancellation; if the first connection fails (e.g. the network is not
+	// yet available), the reader retries with the same backoff used to reconnect
 	select {
 	case conn := <-connChan:
-		s.conn = conn
-	case err := <-errChan:
-
-		return err
+		s.setConn(conn)
+	case err = <-errChan:
 	case <-s.ctx.Done():
 
 		return fmt.Errorf("connection aborted: %w", s.ctx.Err())
 	}
 
-	// Disconnect on stop signal
+	// Disconnect on stop signal; closing the connection unblocks any pending read
 	go func() {
 		<-s.ctx.Done()
+		s.mu.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.symbols = []string{}
+		s.mu.Unlock()
 		s.wg.Wait()
-		s.conn.Close()
 		s.isStarted = false
-		s.symbols = []string{}
 	}()
 
 	s.isStarted = true
 
+	if err != nil {
+		s.reconnector.SendStatus(s.ctx, c.StreamStatusUpdate{Status: c.StreamStatusDisconnected, Err: err})
+	} else {
+		s.reconnector.SendStatus(s.ctx, c.StreamStatusUpdate{Status: c.StreamStatusConnected})
+	}
+
 	s.wg.Add(2)
 	go s.readStreamQuote()
 	go s.writeStreamSubscription()
@@ -140,19 +186,26 @@ func (s *Streamer) SetSymbolsAndUpdateSubscriptions(symbols []string, versionVec
 		return nil
 	}
 
+	s.mu.Lock()
+	productIDsAdded, productIDsRemoved := diffProductIDs(s.symbols, symbols)
 	s.symbols = symbols
 	s.versionVector = versionVector
+	s.subscriptionsRetried = false
+	s.mu.Unlock()
 
-	// TODO: fix symbol change
-	// err = s.unsubscribe()
-	// if err != nil {
-	// 	return err
-	// }
-
-	err = s.subscribe(s.symbols)
-	if err != nil {
+	// Only send changes so that products no longer shown do not continue to stream
+	if len(productIDsRemoved) > 0 {
+		err = s.unsubscribe(productIDsRemoved)
+		if err != nil {
+			return err
+		}
+	}
 
-		return err
+	if len(productIDsAdded) > 0 {
+		err = s.subscribe(productIDsAdded)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -170,6 +223,19 @@ func (s *Streamer) SetURL(url string) error {
 	return nil
 }
 
+// SetReconnectBackoff sets the delay before the first reconnect attempt and the maximum delay between attempts
+func (s *Streamer) SetReconnectBackoff(initial time.Duration, maximum time.Duration) error {
+
+	if s.isStarted {
+
+		return errors.New("cannot set reconnect backoff while streamer is connected")
+	}
+
+	s.reconnector.SetBackoff(initial, maximum)
+
+	return nil
+}
+
 func (s *Streamer) readStreamQuote() {
 	defer s.wg.Done()
 
@@ -178,23 +244,171 @@ func (s *Streamer) readStreamQuote() {
 		case <-s.ctx.Done():
 			return
 		default:
-			var message messagePriceTick
-			err := s.conn.ReadJSON(&message)
+			conn := s.getConn()
+
+			// Not yet connected since the first connection failed
+			if conn == nil {
+				if !s.reconnect() {
+					return
+				}
+
+				continue
+			}
+
+			_, data, err := conn.ReadMessage()
 			if err != nil {
-				s.chanError <- err
+				if s.ctx.Err() != nil {
+					return
+				}
+
+				s.reconnector.SendStatus(s.ctx, c.StreamStatusUpdate{Status: c.StreamStatusDisconnected, Err: err})
+
+				if !s.reconnect() {
+					return
+				}
+
+				continue
+			}
 
-				return
+			var messageWithType messageType
+			if json.Unmarshal(data, &messageWithType) != nil {
+
+				continue
+			}
+
+			if messageWithType.Type == "subscriptions" {
+				var message messageSubscriptions
+				if json.Unmarshal(data, &message) == nil {
+					s.handleSubscriptions(message)
+				}
+
+				continue
+			}
+
+			// Only handle ticker messages
+			if messageWithType.Type != "ticker" {
+
+				continue
 			}
 
-			// Only handle ticker messages; first message is a subscription confirmation
-			if message.Type != "ticker" {
+			var message messagePriceTick
+			if json.Unmarshal(data, &message) != nil {
 
 				continue
 			}
 
-			qp, qe := transformPriceTick(message, s.versionVector)
+			s.mu.RLock()
+			versionVector := s.versionVector
+			s.mu.RUnlock()
+
+			qp, qe := transformPriceTick(message, versionVector)
 			s.chanStreamUpdateQuotePrice <- qp
 			s.chanStreamUpdateQuoteExtended <- qe
+
+			if s.chanStreamUpdateQuoteDepth != nil {
+				s.chanStreamUpdateQuoteDepth <- transformDepthTick(message, versionVector)
+			}
+		}
+	}
+}
+
+// reconnect dials the stream with exponential backoff until connected and then resubscribes to the current symbols.
+// Returns false if the streamer was stopped before a connection could be established
+func (s *Streamer) reconnect() bool {
+
+	return s.reconnector.Reconnect(s.ctx, func() error {
+		conn, err := s.dial()
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		if s.ctx.Err() != nil {
+			s.mu.Unlock()
+			conn.Close()
+
+			return s.ctx.Err()
+		}
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.conn = conn
+		symbols := s.symbols
+		s.symbolsSubscribed = []string{}
+		s.subscriptionsPending = 0
+		if len(symbols) > 0 {
+			s.subscriptionsPending = 1
+		}
+		s.mu.Unlock()
+
+		if len(symbols) > 0 {
+			return s.writeJSON(newMessageSubscribe(symbols))
+		}
+
+		return nil
+	})
+}
+
+// handleSubscriptions confirms the subscriptions acknowledged by the server against the current symbols once all
+// pending changes have been acknowledged and sends a single correction if they do not match
+func (s *Streamer) handleSubscriptions(message messageSubscriptions) {
+
+	productIDs := make([]string, 0)
+	for _, channel := range message.Channels {
+		if channel.Name == "ticker" {
+			productIDs = channel.ProductIDs
+		}
+	}
+
+	s.mu.Lock()
+
+	s.symbolsSubscribed = productIDs
+
+	if s.subscriptionsPending > 0 {
+		s.subscriptionsPending--
+	}
+
+	if s.subscriptionsPending > 0 {
+		s.mu.Unlock()
+
+		return
+	}
+
+	productIDsMissing, productIDsExtra := diffProductIDs(productIDs, s.symbols)
+
+	if len(productIDsMissing) == 0 && len(productIDsExtra) == 0 {
+		s.mu.Unlock()
+
+		return
+	}
+
+	if s.subscriptionsRetried {
+		s.mu.Unlock()
+
+		if s.chanError != nil {
+			s.chanError <- fmt.Errorf("subscriptions not confirmed by server; missing: %v, extra: %v", productIDsMissing, productIDsExtra)
+		}
+
+		return
+	}
+
+	s.subscriptionsRetried = true
+
+	messages := make([]messageSubscription, 0, 2)
+	if len(productIDsExtra) > 0 {
+		messages = append(messages, newMessageUnsubscribe(productIDsExtra))
+	}
+	if len(productIDsMissing) > 0 {
+		messages = append(messages, newMessageSubscribe(productIDsMissing))
+	}
+	s.subscriptionsPending = len(messages)
+
+	s.mu.Unlock()
+
+	for _, message := range messages {
+		if s.writeJSON(message) != nil {
+
+			return
 		}
 	}
 }
@@ -209,39 +423,103 @@ func (s *Streamer) writeStreamSubscription() {
 			return
 		case message := <-s.subscriptionChan:
 
-			err := s.conn.WriteJSON(message)
-			if err != nil {
+			// Failed writes are not retried since a broken connection is detected by the reader which resubscribes after reconnecting
+			err := s.writeJSON(message)
+			if err != nil && !errors.Is(err, errNotConnected) && s.ctx.Err() == nil && s.chanError != nil {
 				s.chanError <- err
-
-				return
 			}
 		}
 	}
 }
 
+func (s *Streamer) dial() (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(s.ctx, s.url, nil) //nolint:bodyclose
+
+	return conn, err
+}
+
+func (s *Streamer) getConn() *websocket.Conn {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.conn
+}
+
+func (s *Streamer) setConn(conn *websocket.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.conn = conn
+}
+
+func (s *Streamer) writeJSON(message messageSubscription) error {
+	s.muWrite.Lock()
+	defer s.muWrite.Unlock()
+
+	conn := s.getConn()
+	if conn == nil {
+		return errNotConnected
+	}
+
+	return conn.WriteJSON(message)
+}
+
 func (s *Streamer) subscribe(productIDs []string) error {
 
-	message := messageSubscription{
+	s.mu.Lock()
+	s.subscriptionsPending++
+	s.mu.Unlock()
+
+	s.subscriptionChan <- newMessageSubscribe(productIDs)
+
+	return nil
+}
+
+func (s *Streamer) unsubscribe(productIDs []string) error {
+
+	s.mu.Lock()
+	s.subscriptionsPending++
+	s.mu.Unlock()
+
+	s.subscriptionChan <- newMessageUnsubscribe(productIDs)
+
+	return nil
+}
+
+func newMessageSubscribe(productIDs []string) messageSubscription {
+	return messageSubscription{
 		Type:       "subscribe",
 		ProductIDs: productIDs,
 		Channels:   []string{"ticker"},
 	}
+}
 
-	s.subscriptionChan <- message
-
-	return nil
+func newMessageUnsubscribe(productIDs []string) messageSubscription {
+	return messageSubscription{
+		Type:       "unsubscribe",
+		ProductIDs: productIDs,
+		Channels:   []string{"ticker"},
+	}
 }
 
-func (s *Streamer) unsubscribe() error { //nolint:unused
+// diffProductIDs returns the product IDs in next which are not in previous and the product IDs in previous which are not in next
+func diffProductIDs(previous []string, next []string) (productIDsAdded []string, productIDsRemoved []string) {
+	productIDsAdded = make([]string, 0)
+	productIDsRemoved = make([]string, 0)
 
-	message := messageSubscription{
-		Type:     "unsubscribe",
-		Channels: []string{"ticker"},
+	for _, productID := range next {
+		if !slices.Contains(previous, productID) {
+			productIDsAdded = append(productIDsAdded, productID)
+		}
 	}
 
-	s.subscriptionChan <- message
+	for _, productID := range previous {
+		if !slices.Contains(next, productID) {
+			productIDsRemoved = append(productIDsRemoved, productID)
+		}
+	}
 
-	return nil
+	return productIDsAdded, productIDsRemoved
 }
 
 func transformPriceTick(message messagePriceTick, versionVector int) (qp c.MessageUpdate[c.QuotePrice], qe c.MessageUpdate[c.QuoteExtended]) {
@@ -281,3 +559,34 @@ func transformPriceTick(message messagePriceTick, versionVector int) (qp c.Messa
 
 	return qp, qe
 }
+
+func transformDepthTick(message messagePriceTick, versionVector int) c.MessageUpdate[c.QuoteDepth] {
+
+	bidPrice, _ := strconv.ParseFloat(message.BestBid, 64)
+	bidSize, _ := strconv.ParseFloat(message.BestBidSize, 64)
+	askPrice, _ := strconv.ParseFloat(message.BestAsk, 64)
+	askSize, _ := strconv.ParseFloat(message.BestAskSize, 64)
+
+	spread := 0.0
+	spreadPercent := 0.0
+
+	// Spread is only meaningful when both sides of the book are present
+	if bidPrice > 0 && askPrice > 0 {
+		spread = askPrice - bidPrice
+		spreadPercent = spread / ((askPrice + bidPrice) / 2) * 100
+	}
+
+	return c.MessageUpdate[c.QuoteDepth]{
+		ID:            message.ProductID,
+		Sequence:      message.Sequence,
+		VersionVector: versionVector,
+		Data: c.QuoteDepth{
+			BidPrice:      bidPrice,
+			BidSize:       bidSize,
+			AskPrice:      askPrice,
+			AskSize:       askSize,
+			Spread:        spread,
+			SpreadPercent: spreadPercent,
+		},
+	}
+}
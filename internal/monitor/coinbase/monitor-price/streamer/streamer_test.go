@@ -2,9 +2,16 @@ package streamer_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	g "github.com/onsi/gomega/gstruct"
@@ -150,6 +157,70 @@ var _ = Describe("Streamer", func() {
 				})
 			})
 
+			When("a depth channel is set", func() {
+				It("should send the best bid and ask with the spread to the channel", func() {
+					inputTick := `{
+						"type": "ticker",
+						"sequence": 37475248783,
+						"product_id": "ETH-USD",
+						"price": "1285.22",
+						"best_bid": "1285.04",
+						"best_bid_size": "0.46688654",
+						"best_ask": "1285.27",
+						"best_ask_size": "1.56637040"
+					}`
+					inputServer = testWs.NewTestServer([]string{inputTick})
+					outputChanStreamUpdateQuoteDepth := make(chan c.MessageUpdate[c.QuoteDepth], 5)
+
+					s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+						ChanStreamUpdateQuotePrice:    make(chan c.MessageUpdate[c.QuotePrice], 5),
+						ChanStreamUpdateQuoteExtended: make(chan c.MessageUpdate[c.QuoteExtended], 5),
+						ChanStreamUpdateQuoteDepth:    outputChanStreamUpdateQuoteDepth,
+					})
+					s.SetURL("ws://" + inputServer.URL[7:])
+
+					Expect(s.Start()).To(Succeed())
+
+					Eventually(outputChanStreamUpdateQuoteDepth).Should(Receive(
+						g.MatchFields(g.IgnoreExtras, g.Fields{
+							"ID":       Equal("ETH-USD"),
+							"Sequence": Equal(int64(37475248783)),
+							"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"BidPrice":      Equal(1285.04),
+								"BidSize":       Equal(0.46688654),
+								"AskPrice":      Equal(1285.27),
+								"AskSize":       Equal(1.56637040),
+								"Spread":        BeNumerically("~", 0.23, 0.0001),
+								"SpreadPercent": BeNumerically("~", 0.0179, 0.0001),
+							}),
+						}),
+					))
+				})
+
+				When("one side of the book is missing", func() {
+					It("should not calculate the spread", func() {
+						inputTick := `{"type": "ticker", "product_id": "ETH-USD", "price": "1285.22", "best_bid": "1285.04"}`
+						inputServer = testWs.NewTestServer([]string{inputTick})
+						outputChanStreamUpdateQuoteDepth := make(chan c.MessageUpdate[c.QuoteDepth], 5)
+
+						s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+							ChanStreamUpdateQuotePrice:    make(chan c.MessageUpdate[c.QuotePrice], 5),
+							ChanStreamUpdateQuoteExtended: make(chan c.MessageUpdate[c.QuoteExtended], 5),
+							ChanStreamUpdateQuoteDepth:    outputChanStreamUpdateQuoteDepth,
+						})
+						s.SetURL("ws://" + inputServer.URL[7:])
+
+						Expect(s.Start()).To(Succeed())
+
+						Eventually(outputChanStreamUpdateQuoteDepth).Should(Receive(
+							g.MatchFields(g.IgnoreExtras, g.Fields{
+								"Data": Equal(c.QuoteDepth{BidPrice: 1285.04}),
+							}),
+						))
+					})
+				})
+			})
+
 			When("a message is not a price quote or extended quote", func() {
 				It("should not send anything to the channel", func() {
 					invalidMessage := `{"type": "unknown"}`
@@ -173,6 +244,167 @@ var _ = Describe("Streamer", func() {
 		})
 	})
 
+	Describe("reconnect", func() {
+		var (
+			outputChanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+			outputChanStreamUpdateStatus     chan c.StreamStatusUpdate
+			outputSubscriptions              chan []string
+			connectionCount                  *atomic.Int32
+			ctx                              context.Context
+			cancel                           context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			connectionCount = &atomic.Int32{}
+			outputChanStreamUpdateQuotePrice = make(chan c.MessageUpdate[c.QuotePrice], 5)
+			outputChanStreamUpdateStatus = make(chan c.StreamStatusUpdate, 10)
+			outputSubscriptions = make(chan []string, 5)
+			connections := connectionCount
+			subscriptions := outputSubscriptions
+
+			// Mock server drops the first connection after the first subscription and sends a price tick for each subscription on later connections
+			inputServer = testWs.NewTestServerWithHandler(func(conn *websocket.Conn, _ *http.Request) {
+				count := connections.Add(1)
+
+				for {
+					var message struct {
+						Type       string   `json:"type"`
+						ProductIDs []string `json:"product_ids"`
+					}
+					if err := conn.ReadJSON(&message); err != nil {
+						return
+					}
+					subscriptions <- message.ProductIDs
+
+					if count == 1 {
+						return
+					}
+
+					for _, productID := range message.ProductIDs {
+						conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ticker","product_id":"`+productID+`","price":"100.5","open_24h":"100"}`))
+					}
+				}
+			})
+
+			ctx, cancel = context.WithCancel(context.Background())
+			s = streamer.NewStreamer(ctx, streamer.StreamerConfig{
+				ChanStreamUpdateQuotePrice:    outputChanStreamUpdateQuotePrice,
+				ChanStreamUpdateQuoteExtended: make(chan c.MessageUpdate[c.QuoteExtended], 5),
+				ChanStreamUpdateStatus:        outputChanStreamUpdateStatus,
+			})
+			s.SetURL("ws://" + inputServer.URL[7:])
+			s.SetReconnectBackoff(10*time.Millisecond, 40*time.Millisecond)
+		})
+
+		AfterEach(func() {
+			cancel()
+			inputServer.Close()
+		})
+
+		When("the connection is dropped", func() {
+			It("should reconnect and resubscribe to the current symbols with the current version vector", func() {
+				Expect(s.Start()).To(Succeed())
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD", "ETH-USD"}, 2)).To(Succeed())
+
+				Eventually(outputSubscriptions).Should(Receive(Equal([]string{"BTC-USD", "ETH-USD"})))
+				Eventually(outputSubscriptions).Should(Receive(Equal([]string{"BTC-USD", "ETH-USD"})))
+				Expect(connectionCount.Load()).To(Equal(int32(2)))
+
+				Eventually(outputChanStreamUpdateQuotePrice).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID":            Equal("BTC-USD"),
+						"VersionVector": Equal(2),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(100.5),
+						}),
+					}),
+				))
+			})
+
+			It("should send status updates for the disconnect, the reconnect attempt, and the new connection", func() {
+				Expect(s.Start()).To(Succeed())
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD"}, 0)).To(Succeed())
+
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Source": Equal(c.QuoteSourceCoinbase),
+					"Status": Equal(c.StreamStatusConnected),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status": Equal(c.StreamStatusDisconnected),
+					"Err":    HaveOccurred(),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusReconnecting),
+					"Attempt": Equal(1),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusConnected),
+					"Attempt": Equal(1),
+				})))
+			})
+		})
+
+		When("the first connection fails", func() {
+			It("should start without an error and connect with the same backoff used to reconnect", func() {
+				// Reject the first handshake and pass later requests to the mock server
+				rejectedCount := &atomic.Int32{}
+				rejectingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if rejectedCount.Add(1) == 1 {
+						w.WriteHeader(http.StatusServiceUnavailable)
+
+						return
+					}
+					inputServer.Config.Handler.ServeHTTP(w, r)
+				}))
+				defer rejectingServer.Close()
+
+				s = streamer.NewStreamer(ctx, streamer.StreamerConfig{
+					ChanStreamUpdateQuotePrice:    outputChanStreamUpdateQuotePrice,
+					ChanStreamUpdateQuoteExtended: make(chan c.MessageUpdate[c.QuoteExtended], 5),
+					ChanStreamUpdateStatus:        outputChanStreamUpdateStatus,
+				})
+				s.SetURL("ws://" + rejectingServer.URL[7:])
+				s.SetReconnectBackoff(10*time.Millisecond, 40*time.Millisecond)
+
+				Expect(s.Start()).To(Succeed())
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD"}, 0)).To(Succeed())
+
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status": Equal(c.StreamStatusDisconnected),
+					"Err":    HaveOccurred(),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusReconnecting),
+					"Attempt": Equal(1),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusConnected),
+					"Attempt": Equal(1),
+				})))
+				Eventually(outputSubscriptions).Should(Receive(Equal([]string{"BTC-USD"})))
+			})
+		})
+
+		When("the server is not available when reconnecting", func() {
+			It("should keep retrying until the streamer is stopped", func() {
+				Expect(s.Start()).To(Succeed())
+				// Stop accepting connections and then trigger the mock server to drop the current connection
+				inputServer.Listener.Close()
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD"}, 0)).To(Succeed())
+
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusReconnecting),
+					"Attempt": Equal(3),
+				})))
+
+				cancel()
+
+				Eventually(outputChanStreamUpdateStatus).ShouldNot(Receive())
+				Consistently(outputChanStreamUpdateStatus, 200*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+	})
+
 	Describe("SetSymbolsAndUpdateSubscriptions", func() {
 		BeforeEach(func() {
 			inputServer = testWs.NewTestServer([]string{})
@@ -200,6 +432,132 @@ var _ = Describe("Streamer", func() {
 		})
 	})
 
+	Describe("subscription changes", func() {
+		type messageSubscription struct {
+			Type       string   `json:"type"`
+			ProductIDs []string `json:"product_ids"`
+		}
+
+		var (
+			outputMessages  chan messageSubscription
+			outputChanError chan error
+			ignoreCount     *sync.Map
+			ctx             context.Context
+			cancel          context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			outputMessages = make(chan messageSubscription, 10)
+			outputChanError = make(chan error, 5)
+			ignoreCount = &sync.Map{}
+			messages := outputMessages
+			ignored := ignoreCount
+
+			// Mock server keeps track of subscriptions and acknowledges each change with the full list of subscribed products
+			// Products in ignoreCount are not subscribed for the given number of requests to simulate a subscription which was not applied
+			inputServer = testWs.NewTestServerWithHandler(func(conn *websocket.Conn, _ *http.Request) {
+				subscribed := []string{}
+
+				for {
+					var message messageSubscription
+					if err := conn.ReadJSON(&message); err != nil {
+						return
+					}
+					messages <- message
+
+					for _, productID := range message.ProductIDs {
+						if count, isIgnored := ignored.Load(productID); isIgnored && message.Type == "subscribe" {
+							ignored.Store(productID, count.(int)-1)
+							if count.(int) <= 1 {
+								ignored.Delete(productID)
+							}
+
+							continue
+						}
+						subscribed = slices.DeleteFunc(subscribed, func(id string) bool { return id == productID })
+						if message.Type == "subscribe" {
+							subscribed = append(subscribed, productID)
+						}
+					}
+
+					ack, _ := json.Marshal(map[string]any{
+						"type":     "subscriptions",
+						"channels": []map[string]any{{"name": "ticker", "product_ids": subscribed}},
+					})
+					conn.WriteMessage(websocket.TextMessage, ack)
+				}
+			})
+
+			ctx, cancel = context.WithCancel(context.Background())
+			s = streamer.NewStreamer(ctx, streamer.StreamerConfig{
+				ChanStreamUpdateQuotePrice:    make(chan c.MessageUpdate[c.QuotePrice], 5),
+				ChanStreamUpdateQuoteExtended: make(chan c.MessageUpdate[c.QuoteExtended], 5),
+				ChanError:                     outputChanError,
+			})
+			s.SetURL("ws://" + inputServer.URL[7:])
+		})
+
+		AfterEach(func() {
+			cancel()
+			inputServer.Close()
+		})
+
+		It("should unsubscribe from removed products and subscribe to added products", func() {
+			Expect(s.Start()).To(Succeed())
+
+			Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD", "ETH-USD"}, 0)).To(Succeed())
+			Eventually(outputMessages).Should(Receive(Equal(messageSubscription{Type: "subscribe", ProductIDs: []string{"BTC-USD", "ETH-USD"}})))
+
+			Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"ETH-USD", "SOL-USD"}, 1)).To(Succeed())
+			Eventually(outputMessages).Should(Receive(Equal(messageSubscription{Type: "unsubscribe", ProductIDs: []string{"BTC-USD"}})))
+			Eventually(outputMessages).Should(Receive(Equal(messageSubscription{Type: "subscribe", ProductIDs: []string{"SOL-USD"}})))
+
+			Consistently(outputMessages, 100*time.Millisecond).ShouldNot(Receive())
+			Expect(outputChanError).NotTo(Receive())
+		})
+
+		When("the symbols have not changed", func() {
+			It("should not send any subscription changes", func() {
+				Expect(s.Start()).To(Succeed())
+
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD"}, 0)).To(Succeed())
+				Eventually(outputMessages).Should(Receive())
+
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD"}, 1)).To(Succeed())
+				Consistently(outputMessages, 100*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+
+		When("the subscriptions acknowledged by the server do not match the symbols", func() {
+			It("should subscribe again to the missing products", func() {
+				ignoreCount.Store("ETH-USD", 1)
+				Expect(s.Start()).To(Succeed())
+
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD", "ETH-USD"}, 0)).To(Succeed())
+				Eventually(outputMessages).Should(Receive(Equal(messageSubscription{Type: "subscribe", ProductIDs: []string{"BTC-USD", "ETH-USD"}})))
+				Eventually(outputMessages).Should(Receive(Equal(messageSubscription{Type: "subscribe", ProductIDs: []string{"ETH-USD"}})))
+
+				Consistently(outputMessages, 100*time.Millisecond).ShouldNot(Receive())
+				Expect(outputChanError).NotTo(Receive())
+			})
+
+			When("the correction is also not acknowledged", func() {
+				It("should return an error without retrying again", func() {
+					Expect(s.Start()).To(Succeed())
+					Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD"}, 0)).To(Succeed())
+					Eventually(outputMessages).Should(Receive())
+
+					ignoreCount.Store("ETH-USD", 2)
+					Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD", "ETH-USD"}, 1)).To(Succeed())
+					Eventually(outputMessages).Should(Receive(Equal(messageSubscription{Type: "subscribe", ProductIDs: []string{"ETH-USD"}})))
+					Eventually(outputMessages).Should(Receive(Equal(messageSubscription{Type: "subscribe", ProductIDs: []string{"ETH-USD"}})))
+					Eventually(outputChanError).Should(Receive(MatchError(ContainSubstring("subscriptions not confirmed by server; missing: [ETH-USD]"))))
+					Consistently(outputMessages, 100*time.Millisecond).ShouldNot(Receive())
+				})
+			})
+		})
+	})
+
 	Describe("SetURL", func() {
 		It("should set the url and not return an error", func() {
 			s := streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,8 +15,31 @@ import (
 
 const (
 	productTypeFuture = "FUTURE"
+	// maxCandles is the maximum number of candles the API returns for a single request
+	maxCandles = 350
 )
 
+// granularities maps price history intervals to the candle granularity names used by the API
+var granularities = map[c.PriceHistoryInterval]struct {
+	name     string
+	duration time.Duration
+}{
+	c.PriceHistoryInterval1Minute:  {"ONE_MINUTE", time.Minute},
+	c.PriceHistoryInterval5Minute:  {"FIVE_MINUTE", 5 * time.Minute},
+	c.PriceHistoryInterval15Minute: {"FIFTEEN_MINUTE", 15 * time.Minute},
+	c.PriceHistoryInterval1Hour:    {"ONE_HOUR", time.Hour},
+	c.PriceHistoryInterval1Day:     {"ONE_DAY", 24 * time.Hour},
+}
+
+// rangeDurations maps price history ranges to the period of time before now covered by the range
+var rangeDurations = map[c.PriceHistoryRange]time.Duration{
+	c.PriceHistoryRange1Day:   24 * time.Hour,
+	c.PriceHistoryRange5Day:   5 * 24 * time.Hour,
+	c.PriceHistoryRange1Month: 30 * 24 * time.Hour,
+	c.PriceHistoryRange3Month: 90 * 24 * time.Hour,
+	c.PriceHistoryRange1Year:  365 * 24 * time.Hour,
+}
+
 // Response represents the container object from the API response
 type Response struct {
 	Products []ResponseQuote `json:"products"`
@@ -28,13 +52,20 @@ type ResponseQuoteFcmTradingSessionDetails struct {
 
 // ResponseQuoteFutureProductDetails represents the details specific to futures contracts
 type ResponseQuoteFutureProductDetails struct {
-	ContractDisplayName string `json:"contract_display_name"`
-	GroupDescription    string `json:"group_description"`
-	ContractRootUnit    string `json:"contract_root_unit"`
-	ExpirationDate      string `json:"contract_expiry"`
-	ExpirationTimezone  string `json:"expiration_timezone"`
-	NonCrypto           bool   `json:"non_crypto"`
-	ContractSize        string `json:"contract_size"`
+	ContractDisplayName string                              `json:"contract_display_name"`
+	GroupDescription    string                              `json:"group_description"`
+	ContractRootUnit    string                              `json:"contract_root_unit"`
+	ExpirationDate      string                              `json:"contract_expiry"`
+	ExpirationTimezone  string                              `json:"expiration_timezone"`
+	NonCrypto           bool                                `json:"non_crypto"`
+	ContractSize        string                              `json:"contract_size"`
+	OpenInterest        string                              `json:"open_interest"`
+	PerpetualDetails    ResponseQuoteFuturePerpetualDetails `json:"perpetual_details"`
+}
+
+// ResponseQuoteFuturePerpetualDetails represents the details specific to perpetual futures contracts
+type ResponseQuoteFuturePerpetualDetails struct {
+	OpenInterest string `json:"open_interest"`
 }
 
 // ResponseQuote represents a quote of a single product from the Coinbase API
@@ -54,6 +85,21 @@ type ResponseQuote struct {
 	ProductType              string                                `json:"product_type"`
 }
 
+// ResponseCandles represents the container object from the candles API response
+type ResponseCandles struct {
+	Candles []ResponseCandle `json:"candles"`
+}
+
+// ResponseCandle represents a single candle from the Coinbase API
+type ResponseCandle struct {
+	Start  string `json:"start"`
+	Low    string `json:"low"`
+	High   string `json:"high"`
+	Open   string `json:"open"`
+	Close  string `json:"close"`
+	Volume string `json:"volume"`
+}
+
 type AssetQuotesIndexed struct {
 	AssetQuotes            []c.AssetQuote
 	AssetQuotesByProductId map[string]*c.AssetQuote
@@ -114,10 +160,17 @@ func transformResponseQuote(responseQuote ResponseQuote) c.AssetQuote {
 			contractSize = 1.0
 		}
 
+		// Open interest is reported under perpetual details for perpetual contracts
+		openInterest, _ := strconv.ParseFloat(responseQuote.FutureProductDetails.OpenInterest, 64)
+		if openInterest == 0 {
+			openInterest, _ = strconv.ParseFloat(responseQuote.FutureProductDetails.PerpetualDetails.OpenInterest, 64)
+		}
+
 		quoteFutures = c.QuoteFutures{
 			SymbolUnderlying: responseQuote.FutureProductDetails.ContractRootUnit + "-USD",
 			Expiry:           formatExpiry(expirationDate),
 			ContractSize:     contractSize,
+			OpenInterest:     openInterest,
 		}
 	}
 
@@ -199,3 +252,97 @@ func (u *UnaryAPI) GetAssetQuotes(symbols []string) ([]c.AssetQuote, map[string]
 
 	return quotes, quotesByProductId, nil
 }
+
+// GetPriceHistory retrieves the open, high, low, close, and volume for each interval over the range for a product
+func (u *UnaryAPI) GetPriceHistory(productID string, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error) {
+	granularity, ok := granularities[interval]
+	if !ok {
+		return c.PriceHistory{}, fmt.Errorf("unsupported interval: %s", interval)
+	}
+
+	rangeDuration, ok := rangeDurations[historyRange]
+	if !ok {
+		return c.PriceHistory{}, fmt.Errorf("unsupported range: %s", historyRange)
+	}
+
+	// The API limits the number of candles per request so only the most recent candles are requested for long ranges
+	if rangeDuration > granularity.duration*maxCandles {
+		rangeDuration = granularity.duration * maxCandles
+	}
+
+	end := time.Now()
+	start := end.Add(-rangeDuration)
+
+	reqURL, _ := url.Parse(u.baseURL + "/api/v3/brokerage/market/products/" + url.PathEscape(productID) + "/candles")
+	q := reqURL.Query()
+	q.Set("start", strconv.FormatInt(start.Unix(), 10))
+	q.Set("end", strconv.FormatInt(end.Unix(), 10))
+	q.Set("granularity", granularity.name)
+	reqURL.RawQuery = q.Encode()
+
+	resp, err := u.client.Get(reqURL.String())
+	if err != nil {
+		return c.PriceHistory{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.PriceHistory{}, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	var result ResponseCandles
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return c.PriceHistory{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.PriceHistory{
+		Symbol:   productID,
+		Currency: getProductQuoteCurrency(productID),
+		Range:    historyRange,
+		Interval: interval,
+		Candles:  transformResponseCandles(result.Candles),
+	}, nil
+}
+
+// transformResponseCandles converts candles to typed values ordered from oldest to newest since the API returns the newest first
+func transformResponseCandles(responseCandles []ResponseCandle) []c.PriceCandle {
+	candles := make([]c.PriceCandle, 0, len(responseCandles))
+
+	for _, responseCandle := range responseCandles {
+		start, err := strconv.ParseInt(responseCandle.Start, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		open, _ := strconv.ParseFloat(responseCandle.Open, 64)
+		high, _ := strconv.ParseFloat(responseCandle.High, 64)
+		low, _ := strconv.ParseFloat(responseCandle.Low, 64)
+		closePrice, _ := strconv.ParseFloat(responseCandle.Close, 64)
+		volume, _ := strconv.ParseFloat(responseCandle.Volume, 64)
+
+		candles = append(candles, c.PriceCandle{
+			Time:   time.Unix(start, 0),
+			Open:   open,
+			High:   high,
+			Low:    low,
+			Close:  closePrice,
+			Volume: volume,
+		})
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Time.Before(candles[j].Time)
+	})
+
+	return candles
+}
+
+// getProductQuoteCurrency returns the quote currency of a spot product (e.g. USD for BTC-USD) or an empty string for other products
+func getProductQuoteCurrency(productID string) string {
+	parts := strings.Split(productID, "-")
+	if len(parts) != 2 {
+		return ""
+	}
+
+	return parts[1]
+}
@@ -3,8 +3,10 @@ package unary_test
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
+	c "github.com/achannarasappa/ticker/v5/internal/common"
 	"github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/unary"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -117,6 +119,47 @@ var _ = Describe("Unary", func() {
 				Expect(quotes[0].QuoteFutures.ContractSize).To(Equal(1.0))
 			})
 
+			It("should extract open interest from the API response", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products", "product_ids=BIT-31JAN25-CDE&product_ids=BIP-20DEC30-CDE"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+							Products: []unary.ResponseQuote{
+								{
+									Symbol:      "BIT-31JAN25-CDE",
+									ProductID:   "BIT-31JAN25-CDE",
+									Price:       "60000.00",
+									ProductType: "FUTURE",
+									FutureProductDetails: unary.ResponseQuoteFutureProductDetails{
+										ContractRootUnit: "BTC",
+										OpenInterest:     "12345.5",
+									},
+								},
+								{
+									Symbol:      "BIP-20DEC30-CDE",
+									ProductID:   "BIP-20DEC30-CDE",
+									Price:       "60000.00",
+									ProductType: "FUTURE",
+									FutureProductDetails: unary.ResponseQuoteFutureProductDetails{
+										ContractRootUnit: "BTC",
+										PerpetualDetails: unary.ResponseQuoteFuturePerpetualDetails{
+											OpenInterest: "678",
+										},
+									},
+								},
+							},
+						}),
+					),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				quotes, _, err := api.GetAssetQuotes([]string{"BIT-31JAN25-CDE", "BIP-20DEC30-CDE"})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes[0].QuoteFutures.OpenInterest).To(Equal(12345.5))
+				Expect(quotes[1].QuoteFutures.OpenInterest).To(Equal(678.0))
+			})
+
 			It("should extract contract_size from API response", func() {
 				server.AppendHandlers(
 					ghttp.CombineHandlers(
@@ -301,4 +344,113 @@ var _ = Describe("Unary", func() {
 			})
 		})
 	})
+
+	Describe("GetPriceHistory", func() {
+		It("should return candles ordered from oldest to newest", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products/BTC-USD/candles"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.URL.Query().Get("granularity")).To(Equal("ONE_DAY"))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseCandles{
+						Candles: []unary.ResponseCandle{
+							{Start: "1735948800", Low: "97000.00", High: "99000.00", Open: "98000.00", Close: "98500.00", Volume: "1200.5"},
+							{Start: "1735862400", Low: "95000.00", High: "98500.00", Open: "96000.00", Close: "98000.00", Volume: "1500.25"},
+						},
+					}),
+				),
+			)
+
+			api := unary.NewUnaryAPI(server.URL())
+			history, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Day)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Symbol).To(Equal("BTC-USD"))
+			Expect(history.Currency).To(Equal("USD"))
+			Expect(history.Range).To(Equal(c.PriceHistoryRange5Day))
+			Expect(history.Interval).To(Equal(c.PriceHistoryInterval1Day))
+			Expect(history.Candles).To(HaveLen(2))
+			Expect(history.Candles[0]).To(Equal(c.PriceCandle{
+				Time:   time.Unix(1735862400, 0),
+				Open:   96000.00,
+				High:   98500.00,
+				Low:    95000.00,
+				Close:  98000.00,
+				Volume: 1500.25,
+			}))
+			Expect(history.Candles[1].Time).To(Equal(time.Unix(1735948800, 0)))
+		})
+
+		It("should request the period of time covered by the range", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						start, _ := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+						end, _ := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+						Expect(end - start).To(Equal(int64(5 * 24 * 60 * 60)))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseCandles{}),
+				),
+			)
+
+			api := unary.NewUnaryAPI(server.URL())
+			_, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Hour)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the range has more candles than the API returns in a single request", func() {
+			It("should request the most recent candles", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						func(w http.ResponseWriter, r *http.Request) {
+							start, _ := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+							end, _ := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+							Expect(r.URL.Query().Get("granularity")).To(Equal("ONE_MINUTE"))
+							Expect(end - start).To(Equal(int64(350 * 60)))
+						},
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseCandles{}),
+					),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange1Day, c.PriceHistoryInterval1Minute)
+
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the interval is not supported", func() {
+			It("should return an error", func() {
+				api := unary.NewUnaryAPI(server.URL())
+				_, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange1Day, c.PriceHistoryInterval("1w"))
+
+				Expect(err).To(MatchError("unsupported interval: 1w"))
+			})
+		})
+
+		When("the product is not a spot product", func() {
+			It("should not set the currency", func() {
+				server.AppendHandlers(ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseCandles{}))
+
+				api := unary.NewUnaryAPI(server.URL())
+				history, err := api.GetPriceHistory("BIT-31JAN25-CDE", c.PriceHistoryRange1Day, c.PriceHistoryInterval1Hour)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history.Currency).To(BeEmpty())
+			})
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange1Day, c.PriceHistoryInterval1Hour)
+
+				Expect(err).To(MatchError("request failed with status 500"))
+			})
+		})
+	})
 })
@@ -0,0 +1,357 @@
+package monitorPriceCoinCap
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/poller"
+	streamer "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/streamer"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+)
+
+const (
+	fromCurrencyCode = "USD"
+)
+
+type MonitorPriceCoinCap struct {
+	unaryAPI                   *unary.UnaryAPI
+	streamer                   *streamer.Streamer
+	poller                     *poller.Poller
+	ids                        []string                 // CoinCap APIs refer to assets by id (e.g. "bitcoin") which symbols ticker accepts with a .CC suffix
+	assetQuotesCache           []*c.AssetQuote          // Asset quotes for all assets retrieved at start or on symbol change
+	assetQuotesCacheLookup     map[string]*c.AssetQuote // Asset quotes for all assets retrieved at least once (symbol change does not remove symbols)
+	currencyRatesCache         c.CurrencyRates          // Cache of currency rates
+	currencyHasRequestedRates  bool                     // Whether the currency rates have been requested; quotes are always in the single default currency of USD
+	chanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	chanPollUpdateAssetQuote   chan c.MessageUpdate[c.AssetQuote]
+	chanError                  chan error
+	mu                         sync.RWMutex
+	muCurrencyRates            sync.RWMutex
+	ctx                        context.Context
+	cancel                     context.CancelFunc
+	isStarted                  bool
+	chanUpdateAssetQuote       chan c.MessageUpdate[c.AssetQuote]
+	chanRequestCurrencyRates   chan []string // Channel for currency rate requests
+}
+
+// Config contains the required configuration for the CoinCap monitor
+type Config struct {
+	Ctx                      context.Context
+	UnaryURL                 string
+	ChanError                chan error
+	ChanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
+	ChanRequestCurrencyRates chan []string
+	ChanUpdateStreamStatus   chan c.StreamStatusUpdate // Optional channel for streaming connection state changes
+}
+
+// Option defines an option for configuring the monitor
+type Option func(*MonitorPriceCoinCap)
+
+func NewMonitorPriceCoinCap(config Config, opts ...Option) *MonitorPriceCoinCap {
+	ctx, cancel := context.WithCancel(config.Ctx)
+
+	unaryAPI := unary.NewUnaryAPI(config.UnaryURL)
+
+	monitor := &MonitorPriceCoinCap{
+		assetQuotesCacheLookup:     make(map[string]*c.AssetQuote),
+		assetQuotesCache:           make([]*c.AssetQuote, 0),
+		chanStreamUpdateQuotePrice: make(chan c.MessageUpdate[c.QuotePrice]),
+		chanPollUpdateAssetQuote:   make(chan c.MessageUpdate[c.AssetQuote]),
+		chanError:                  config.ChanError,
+		unaryAPI:                   unaryAPI,
+		ctx:                        ctx,
+		cancel:                     cancel,
+		chanUpdateAssetQuote:       config.ChanUpdateAssetQuote,
+		chanRequestCurrencyRates:   config.ChanRequestCurrencyRates,
+	}
+
+	streamerConfig := streamer.StreamerConfig{
+		ChanStreamUpdateQuotePrice: monitor.chanStreamUpdateQuotePrice,
+		ChanStreamUpdateStatus:     config.ChanUpdateStreamStatus,
+		ChanError:                  monitor.chanError,
+	}
+
+	monitor.streamer = streamer.NewStreamer(ctx, streamerConfig)
+
+	pollerConfig := poller.PollerConfig{
+		ChanUpdateAssetQuote: monitor.chanPollUpdateAssetQuote,
+		ChanError:            monitor.chanError,
+		UnaryAPI:             unaryAPI,
+	}
+	monitor.poller = poller.NewPoller(ctx, pollerConfig)
+
+	for _, opt := range opts {
+		opt(monitor)
+	}
+
+	return monitor
+}
+
+// WithStreamingURL sets the streaming URL for the monitor
+func WithStreamingURL(url string) Option {
+	return func(m *MonitorPriceCoinCap) {
+		// TODO: handle error
+		m.streamer.SetURL(url) //nolint:errcheck
+	}
+}
+
+// WithReconnectBackoff sets the initial and maximum delay between attempts to reconnect to the streaming API
+func WithReconnectBackoff(initial time.Duration, maximum time.Duration) Option {
+	return func(m *MonitorPriceCoinCap) {
+		// TODO: handle error
+		m.streamer.SetReconnectBackoff(initial, maximum) //nolint:errcheck
+	}
+}
+
+// WithRefreshInterval sets the interval at which all quote properties are refreshed since the stream only includes the price
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(m *MonitorPriceCoinCap) {
+		// TODO: handle error
+		m.poller.SetRefreshInterval(interval) //nolint:errcheck
+	}
+}
+
+func (m *MonitorPriceCoinCap) GetAssetQuotes(ignoreCache ...bool) ([]c.AssetQuote, error) {
+	if len(ignoreCache) > 0 && ignoreCache[0] {
+		assetQuotes, err := m.getAssetQuotesAndReplaceCache()
+		if err != nil {
+			return []c.AssetQuote{}, err
+		}
+
+		result := make([]c.AssetQuote, len(assetQuotes))
+		for i, quote := range assetQuotes {
+			result[i] = *quote
+		}
+
+		return result, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]c.AssetQuote, len(m.assetQuotesCache))
+	for i, quote := range m.assetQuotesCache {
+		result[i] = *quote
+	}
+
+	return result, nil
+}
+
+func (m *MonitorPriceCoinCap) SetSymbols(ids []string, versionVector int) error {
+
+	m.mu.Lock()
+
+	// Deduplicate ids since input may have duplicates
+	slices.Sort(ids)
+	m.ids = slices.Compact(ids)
+
+	// Request the default currency (USD) once
+	if !m.currencyHasRequestedRates {
+		m.chanRequestCurrencyRates <- []string{fromCurrencyCode}
+		m.currencyHasRequestedRates = true
+	}
+
+	m.mu.Unlock()
+
+	// Since the symbols have changed, make a synchronous call to get price quotes for the new symbols
+	_, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	m.poller.SetSymbols(m.ids, versionVector)
+
+	return m.streamer.SetSymbolsAndUpdateSubscriptions(m.ids, versionVector)
+}
+
+func (m *MonitorPriceCoinCap) Start() error {
+	var err error
+
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	// On start, get initial quotes from unary API
+	_, err = m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	// Start handling updates before the streamer connects so that the initial messages are not blocked
+	go m.handleUpdates()
+
+	err = m.streamer.Start()
+	if err != nil {
+		return err
+	}
+
+	err = m.poller.Start()
+	if err != nil {
+		return err
+	}
+
+	m.isStarted = true
+
+	return nil
+}
+
+func (m *MonitorPriceCoinCap) Stop() error {
+
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.cancel()
+
+	return nil
+}
+
+func (m *MonitorPriceCoinCap) handleUpdates() {
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case updateMessage := <-m.chanStreamUpdateQuotePrice:
+
+			// Check if cache exists and values have changed before acquiring write lock
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if price has not changed
+			if assetQuote.QuotePrice.Price == updateMessage.Data.Price {
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			// Price is different so update cache; the stream only includes the price so change is derived from the price 24 hours ago
+			m.mu.Lock()
+
+			assetQuote.QuotePrice.Price = updateMessage.Data.Price
+			assetQuote.QuotePrice.Change = assetQuote.QuotePrice.Price - assetQuote.QuotePrice.PricePrevClose
+			if assetQuote.QuotePrice.PricePrevClose != 0 {
+				assetQuote.QuotePrice.ChangePercent = (assetQuote.QuotePrice.Change / assetQuote.QuotePrice.PricePrevClose) * 100
+			}
+
+			m.mu.Unlock()
+
+			// Send a message with an updated quote
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+		case updateMessage := <-m.chanPollUpdateAssetQuote:
+
+			// Check if cache exists and values have changed before acquiring write lock
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if nothing has changed
+			if assetQuote.QuotePrice == updateMessage.Data.QuotePrice &&
+				assetQuote.QuoteExtended == updateMessage.Data.QuoteExtended {
+
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			// Replace all quote properties including those not included in the stream such as volume and market cap
+			m.mu.Lock()
+
+			assetQuote.QuotePrice = updateMessage.Data.QuotePrice
+			assetQuote.QuoteExtended = updateMessage.Data.QuoteExtended
+
+			m.mu.Unlock()
+
+			// Send a message with an updated quote
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+		}
+	}
+}
+
+func (m *MonitorPriceCoinCap) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.muCurrencyRates.Lock()
+	m.currencyRatesCache = currencyRates
+	m.muCurrencyRates.Unlock()
+
+	// TODO: make this more efficient by selectively updating based on changes in rates
+	_, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Get asset quotes from unary API, add currency rates, and replace the asset quotes cache
+func (m *MonitorPriceCoinCap) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote, error) {
+
+	lookup := make(map[string]*c.AssetQuote)
+
+	m.mu.RLock()
+	ids := m.ids
+	m.mu.RUnlock()
+
+	assetQuotes, _, err := m.unaryAPI.GetAssetQuotes(ids)
+	if err != nil {
+		return []*c.AssetQuote{}, err
+	}
+
+	assetQuotesEnriched := make([]*c.AssetQuote, 0, len(assetQuotes))
+
+	m.muCurrencyRates.RLock()
+
+	for _, quote := range assetQuotes {
+
+		// Set the currency rate if available
+		if currencyRate, exists := m.currencyRatesCache[fromCurrencyCode]; exists {
+			quote.Currency.Rate = currencyRate.Rate
+			quote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		quote.Exchange.DelayText = "Real-time"
+
+		lookup[quote.Meta.SymbolInSourceAPI] = &quote
+		assetQuotesEnriched = append(assetQuotesEnriched, &quote)
+	}
+
+	m.muCurrencyRates.RUnlock()
+
+	// Lock updates to asset quotes while symbols are changed to ensure data from unary call supercedes potentially outdated streaming data
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assetQuotesCache = assetQuotesEnriched
+	m.assetQuotesCacheLookup = lookup
+
+	return m.assetQuotesCache, nil
+}
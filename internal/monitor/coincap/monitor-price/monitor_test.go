@@ -0,0 +1,213 @@
+package monitorPriceCoinCap_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceCoinCap "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+	testWs "github.com/achannarasappa/ticker/v5/test/websocket"
+)
+
+var _ = Describe("Monitor CoinCap", func() {
+	var (
+		server          *ghttp.Server
+		streamingServer *httptest.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.RouteToHandler("GET", "/v2/assets",
+			ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+				Data: []unary.ResponseQuote{
+					{ID: "bitcoin", Symbol: "BTC", Name: "Bitcoin", Price: "55000.00", ChangePercent24Hr: "10.00"},
+				},
+			}),
+		)
+		streamingServer = testWs.NewTestServerWithHandler(func(conn *websocket.Conn, _ *http.Request) {
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"bitcoin":"60000.00"}`))
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+		streamingServer.Close()
+	})
+
+	Describe("NewMonitorPriceCoinCap", func() {
+		It("should return a new MonitorPriceCoinCap", func() {
+			monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+				Ctx:      context.Background(),
+				UnaryURL: server.URL(),
+			})
+			Expect(monitor).NotTo(BeNil())
+		})
+	})
+
+	Describe("SetSymbols", func() {
+		It("should get quotes for the symbols and request USD currency rates", func() {
+			chanRequestCurrencyRates := make(chan []string, 1)
+			monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+				Ctx:                      context.Background(),
+				UnaryURL:                 server.URL(),
+				ChanRequestCurrencyRates: chanRequestCurrencyRates,
+			})
+
+			Expect(monitor.SetSymbols([]string{"bitcoin"}, 0)).To(Succeed())
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(1))
+			Expect(assetQuotes[0].Symbol).To(Equal("BITCOIN.CC"))
+			Expect(assetQuotes[0].QuotePrice.Price).To(Equal(55000.0))
+			Expect(chanRequestCurrencyRates).To(Receive(Equal([]string{"USD"})))
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.RouteToHandler("GET", "/v2/assets", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					Ctx:                      context.Background(),
+					UnaryURL:                 server.URL(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				})
+
+				Expect(monitor.SetSymbols([]string{"bitcoin"}, 0)).To(MatchError("request failed with status 500"))
+			})
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should apply the USD currency rate to the asset quotes", func() {
+			monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+				Ctx:                      context.Background(),
+				UnaryURL:                 server.URL(),
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			})
+			monitor.SetSymbols([]string{"bitcoin"}, 0)
+
+			Expect(monitor.SetCurrencyRates(c.CurrencyRates{
+				"USD": {FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.9},
+			})).To(Succeed())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes[0].Currency.Rate).To(Equal(0.9))
+			Expect(assetQuotes[0].Currency.ToCurrencyCode).To(Equal("EUR"))
+		})
+	})
+
+	Describe("Start", func() {
+		When("there is a streaming price update", func() {
+			It("should send the updated price quote with the change from the price 24 hours ago to the channel", func() {
+				chanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					Ctx:                      context.Background(),
+					UnaryURL:                 server.URL(),
+					ChanUpdateAssetQuote:     chanUpdateAssetQuote,
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceCoinCap.WithStreamingURL("ws://"+streamingServer.URL[7:]), monitorPriceCoinCap.WithRefreshInterval(time.Second))
+
+				Expect(monitor.SetSymbols([]string{"bitcoin"}, 0)).To(Succeed())
+				Expect(monitor.Start()).To(Succeed())
+				defer monitor.Stop()
+
+				Eventually(chanUpdateAssetQuote).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID": Equal("BITCOIN.CC"),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"Price":         Equal(60000.0),
+								"Change":        BeNumerically("~", 10000.0, 0.0001),
+								"ChangePercent": BeNumerically("~", 20.0, 0.0001),
+							}),
+						}),
+					}),
+				))
+			})
+		})
+
+		When("there is a polling asset update", func() {
+			It("should send the updated asset quote with the properties not included in the stream to the channel", func() {
+				requestCount := 0
+				server.RouteToHandler("GET", "/v2/assets", func(w http.ResponseWriter, r *http.Request) {
+					requestCount++
+					volume := "1000"
+					// Initial requests from SetSymbols and Start return the original volume and subsequent polling requests return the updated volume
+					if requestCount > 2 {
+						volume = "2000"
+					}
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+						Data: []unary.ResponseQuote{
+							{ID: "bitcoin", Symbol: "BTC", Name: "Bitcoin", Price: "55000.00", Volume24Hr: volume},
+						},
+					})(w, r)
+				})
+
+				chanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					Ctx:                      context.Background(),
+					UnaryURL:                 server.URL(),
+					ChanUpdateAssetQuote:     chanUpdateAssetQuote,
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceCoinCap.WithRefreshInterval(time.Millisecond*50))
+
+				Expect(monitor.SetSymbols([]string{"bitcoin"}, 0)).To(Succeed())
+				Expect(monitor.Start()).To(Succeed())
+				defer monitor.Stop()
+
+				Eventually(chanUpdateAssetQuote).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID": Equal("BITCOIN.CC"),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"QuoteExtended": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"Volume": Equal(2000.0),
+							}),
+						}),
+					}),
+				))
+			})
+		})
+
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					Ctx:      context.Background(),
+					UnaryURL: server.URL(),
+				}, monitorPriceCoinCap.WithRefreshInterval(time.Second))
+
+				Expect(monitor.Start()).To(Succeed())
+				defer monitor.Stop()
+
+				Expect(monitor.Start()).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					Ctx:      context.Background(),
+					UnaryURL: server.URL(),
+				})
+
+				Expect(monitor.Stop()).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
@@ -0,0 +1,132 @@
+package poller_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/poller"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+)
+
+var _ = Describe("Poller", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		server.RouteToHandler("GET", "/v2/assets",
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v2/assets", "ids=bitcoin"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+					Data: []unary.ResponseQuote{
+						{
+							ID:     "bitcoin",
+							Symbol: "BTC",
+							Name:   "Bitcoin",
+							Price:  "55000.00",
+						},
+					},
+				}),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewPoller", func() {
+		It("should create a new poller instance", func() {
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+				ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+			})
+			Expect(p).NotTo(BeNil())
+		})
+	})
+
+	Describe("Start", func() {
+		It("should start polling for price updates", func() {
+
+			inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+				ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+			})
+			p.SetSymbols([]string{"bitcoin"}, 0)
+			p.SetRefreshInterval(time.Millisecond * 250)
+
+			err := p.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(inputChanUpdateAssetQuote).Should(Receive(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"ID": Equal("bitcoin"),
+					"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(55000.0),
+						}),
+					}),
+				}),
+			))
+		})
+
+		When("the poller is already started", func() {
+			It("should return an error", func() {
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+				})
+				p.SetRefreshInterval(time.Second * 1)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+				err = p.Start()
+				Expect(err).To(MatchError("poller already started"))
+			})
+		})
+
+		When("the refresh interval is not set", func() {
+			It("should return an error", func() {
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+				})
+
+				err := p.Start()
+				Expect(err).To(MatchError("refresh interval is not set"))
+			})
+		})
+
+		When("the request fails", func() {
+			It("should send an error to the error channel", func() {
+				server.RouteToHandler("GET", "/v2/assets", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				inputChanError := make(chan error, 5)
+
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					ChanError:            inputChanError,
+				})
+				p.SetSymbols([]string{"bitcoin"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 100)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanError).Should(Receive(MatchError("request failed with status 500")))
+			})
+		})
+	})
+})
@@ -0,0 +1,294 @@
+package streamer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/util"
+	"github.com/gorilla/websocket"
+)
+
+// messagePrices is a map of asset ids to prices in USD (e.g. {"bitcoin":"6929.82"})
+type messagePrices map[string]string
+
+type Streamer struct {
+	symbols                    []string
+	conn                       *websocket.Conn
+	connCancel                 context.CancelFunc // Cancels the reader and any reconnect attempts for the current connection
+	isStarted                  bool
+	url                        string
+	reconnector                *util.Reconnector
+	wg                         sync.WaitGroup
+	mu                         sync.Mutex
+	ctx                        context.Context
+	cancel                     context.CancelFunc
+	chanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	chanError                  chan error
+	versionVector              int
+}
+
+type StreamerConfig struct {
+	ChanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	ChanStreamUpdateStatus     chan c.StreamStatusUpdate // Optional channel for connection state changes
+	ChanError                  chan error
+}
+
+func NewStreamer(ctx context.Context, config StreamerConfig) *Streamer {
+	ctx, cancel := context.WithCancel(ctx) //nolint:gosec // cancel stored in struct and called via Stop()
+
+	s := &Streamer{
+		chanStreamUpdateQuotePrice: config.ChanStreamUpdateQuotePrice,
+		chanError:                  config.ChanError,
+		ctx:                        ctx,
+		cancel:                     cancel,
+		wg:                         sync.WaitGroup{},
+		reconnector:                util.NewReconnector(c.QuoteSourceCoinCap, config.ChanStreamUpdateStatus),
+		versionVector:              0,
+	}
+
+	return s
+}
+
+func (s *Streamer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isStarted {
+		return errors.New("streamer already started")
+	}
+
+	if s.url == "" {
+		// TODO: log streaming not started
+		return nil
+	}
+
+	// Connection failures are retried so an invalid URL is rejected up front since it would never connect
+	err := util.ValidateURL(s.url)
+	if err != nil {
+		return err
+	}
+
+	// CoinCap subscriptions are set on the URL so a connection is only made once there are symbols
+	if len(s.symbols) > 0 {
+		err = s.connect()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Disconnect on stop signal
+	go func() {
+		<-s.ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.disconnect()
+		s.isStarted = false
+		s.symbols = []string{}
+	}()
+
+	s.isStarted = true
+
+	return nil
+}
+
+// SetSymbolsAndUpdateSubscriptions sets the symbols to stream and reconnects with the new symbols if the streamer is started
+func (s *Streamer) SetSymbolsAndUpdateSubscriptions(symbols []string, versionVector int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.symbols = symbols
+	s.versionVector = versionVector
+
+	if !s.isStarted {
+
+		return nil
+	}
+
+	s.disconnect()
+
+	if len(s.symbols) == 0 {
+
+		return nil
+	}
+
+	return s.connect()
+}
+
+func (s *Streamer) SetURL(url string) error {
+
+	if s.isStarted {
+
+		return errors.New("cannot set URL while streamer is connected")
+	}
+
+	s.url = url
+
+	return nil
+}
+
+// SetReconnectBackoff sets the delay before the first reconnect attempt and the maximum delay between attempts
+func (s *Streamer) SetReconnectBackoff(initial time.Duration, maximum time.Duration) error {
+
+	if s.isStarted {
+
+		return errors.New("cannot set reconnect backoff while streamer is connected")
+	}
+
+	s.reconnector.SetBackoff(initial, maximum)
+
+	return nil
+}
+
+// connect opens a websocket connection subscribed to the current symbols and retries in the background if the
+// connection can not be opened; the caller must hold the lock
+func (s *Streamer) connect() error {
+
+	ctxConn, cancel := context.WithCancel(s.ctx)
+	s.connCancel = cancel
+
+	conn, err := s.dial(ctxConn, s.symbols)
+	if err != nil {
+		if s.ctx.Err() != nil {
+			return fmt.Errorf("connection aborted: %w", s.ctx.Err())
+		}
+
+		s.reconnector.SendStatus(s.ctx, c.StreamStatusUpdate{Status: c.StreamStatusDisconnected, Err: err})
+
+		go s.reconnect(ctxConn)
+
+		return nil
+	}
+
+	s.conn = conn
+
+	s.wg.Add(1)
+	go s.readStreamQuote(ctxConn, s.conn, s.versionVector)
+
+	s.reconnector.SendStatus(s.ctx, c.StreamStatusUpdate{Status: c.StreamStatusConnected})
+
+	return nil
+}
+
+// disconnect closes the current websocket connection and stops any reconnect attempts; the caller must hold the lock
+func (s *Streamer) disconnect() {
+
+	if s.connCancel != nil {
+		s.connCancel()
+		s.connCancel = nil
+	}
+
+	if s.conn == nil {
+		return
+	}
+
+	s.conn.Close()
+	s.wg.Wait()
+	s.conn = nil
+}
+
+// reconnect dials the stream with exponential backoff until connected with the current symbols. Stops without connecting
+// if the connection is replaced by a change in symbols or the streamer is stopped
+func (s *Streamer) reconnect(ctx context.Context) {
+
+	s.reconnector.Reconnect(ctx, func() error {
+		s.mu.Lock()
+		symbols := s.symbols
+		s.mu.Unlock()
+
+		conn, err := s.dial(ctx, symbols)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if ctx.Err() != nil {
+			conn.Close()
+
+			return ctx.Err()
+		}
+
+		s.conn = conn
+		s.wg.Add(1)
+		go s.readStreamQuote(ctx, conn, s.versionVector)
+
+		return nil
+	})
+}
+
+// dial opens a websocket connection with the symbols set on the URL since CoinCap does not support subscription messages
+func (s *Streamer) dial(ctx context.Context, symbols []string) (*websocket.Conn, error) {
+
+	reqURL, err := url.Parse(s.url)
+	if err != nil {
+		return nil, err
+	}
+	q := reqURL.Query()
+	q.Set("assets", strings.Join(symbols, ","))
+	reqURL.RawQuery = q.Encode()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, reqURL.String(), nil) //nolint:bodyclose
+
+	return conn, err
+}
+
+func (s *Streamer) readStreamQuote(ctx context.Context, conn *websocket.Conn, versionVector int) {
+	defer s.wg.Done()
+
+	for {
+		var message messagePrices
+		err := conn.ReadJSON(&message)
+		if err != nil {
+			// Errors are expected when the connection is closed intentionally
+			if ctx.Err() != nil {
+				return
+			}
+
+			s.reconnector.SendStatus(s.ctx, c.StreamStatusUpdate{Status: c.StreamStatusDisconnected, Err: err})
+
+			// Reconnect outside of the reader so that a symbol change waiting on the reader to exit is not blocked
+			go s.reconnect(ctx)
+
+			return
+		}
+
+		for _, update := range transformPrices(message, versionVector) {
+			select {
+			case <-ctx.Done():
+				return
+			case s.chanStreamUpdateQuotePrice <- update:
+			}
+		}
+	}
+}
+
+// transformPrices converts a price message into quote price updates; CoinCap only streams the price so all other properties are left unset
+func transformPrices(message messagePrices, versionVector int) []c.MessageUpdate[c.QuotePrice] {
+
+	updates := make([]c.MessageUpdate[c.QuotePrice], 0, len(message))
+
+	for id, priceText := range message {
+		price, err := strconv.ParseFloat(priceText, 64)
+		if err != nil {
+			continue
+		}
+
+		updates = append(updates, c.MessageUpdate[c.QuotePrice]{
+			ID:            id,
+			VersionVector: versionVector,
+			Data: c.QuotePrice{
+				Price: price,
+			},
+		})
+	}
+
+	return updates
+}
@@ -0,0 +1,274 @@
+package streamer_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	streamer "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/streamer"
+	testWs "github.com/achannarasappa/ticker/v5/test/websocket"
+)
+
+var _ = Describe("Streamer", func() {
+	var (
+		inputServer                     *httptest.Server
+		inputChanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+		inputChanError                  chan error
+		outputAssets                    chan string
+		s                               *streamer.Streamer
+	)
+
+	BeforeEach(func() {
+		inputChanStreamUpdateQuotePrice = make(chan c.MessageUpdate[c.QuotePrice], 5)
+		inputChanError = make(chan error, 5)
+		outputAssets = make(chan string, 5)
+
+		// Mock server sends a price for each requested asset and keeps the connection open until the client disconnects
+		inputServer = testWs.NewTestServerWithHandler(func(conn *websocket.Conn, r *http.Request) {
+			assets := r.URL.Query().Get("assets")
+			outputAssets <- assets
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"`+assets+`":"101.5"}`))
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		})
+
+		s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+			ChanStreamUpdateQuotePrice: inputChanStreamUpdateQuotePrice,
+			ChanError:                  inputChanError,
+		})
+		s.SetURL("ws://" + inputServer.URL[7:])
+	})
+
+	AfterEach(func() {
+		inputServer.Close()
+	})
+
+	Describe("NewStreamer", func() {
+		It("should return a new Streamer", func() {
+			Expect(streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})).NotTo(BeNil())
+		})
+	})
+
+	Describe("Start", func() {
+		It("should connect with the symbols set before starting and send price updates to the channel", func() {
+			s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 3)
+
+			Expect(s.Start()).To(Succeed())
+
+			Eventually(outputAssets).Should(Receive(Equal("bitcoin")))
+			Eventually(inputChanStreamUpdateQuotePrice).Should(Receive(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"ID":            Equal("bitcoin"),
+					"VersionVector": Equal(3),
+					"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Price": Equal(101.5),
+					}),
+				}),
+			))
+		})
+
+		When("there are no symbols", func() {
+			It("should not connect", func() {
+				Expect(s.Start()).To(Succeed())
+				Consistently(outputAssets).ShouldNot(Receive())
+			})
+		})
+
+		When("the streamer is already started", func() {
+			It("should return the error 'streamer already started'", func() {
+				Expect(s.Start()).To(Succeed())
+				Expect(s.Start()).To(MatchError("streamer already started"))
+			})
+		})
+
+		When("the url is not set", func() {
+			It("should not start the streamer and not return an error", func() {
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
+				Expect(s.Start()).To(Succeed())
+			})
+		})
+
+		When("the websocket connection is not successful", func() {
+			It("should return an error", func() {
+				s.SetURL("http://" + inputServer.URL[7:])
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+
+				Expect(s.Start()).To(MatchError(ContainSubstring("malformed ws or wss URL")))
+			})
+		})
+	})
+
+	Describe("SetSymbolsAndUpdateSubscriptions", func() {
+		It("should reconnect with the new symbols", func() {
+			s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+			Expect(s.Start()).To(Succeed())
+			Eventually(outputAssets).Should(Receive(Equal("bitcoin")))
+
+			Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"ethereum", "solana"}, 1)).To(Succeed())
+
+			Eventually(outputAssets).Should(Receive(Equal("ethereum,solana")))
+			Expect(inputChanError).NotTo(Receive())
+		})
+
+		When("the symbols are removed", func() {
+			It("should disconnect without an error", func() {
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+				Expect(s.Start()).To(Succeed())
+				Eventually(outputAssets).Should(Receive())
+
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{}, 1)).To(Succeed())
+				Consistently(inputChanError).ShouldNot(Receive())
+			})
+		})
+	})
+
+	Describe("reconnect", func() {
+		var (
+			outputChanStreamUpdateStatus chan c.StreamStatusUpdate
+			connectionCount              *atomic.Int32
+			ctx                          context.Context
+			cancel                       context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			connectionCount = &atomic.Int32{}
+			outputChanStreamUpdateStatus = make(chan c.StreamStatusUpdate, 10)
+			connections := connectionCount
+			assets := outputAssets
+
+			// Mock server drops the first connection and sends a price for the requested asset on later connections
+			inputServer.Close()
+			inputServer = testWs.NewTestServerWithHandler(func(conn *websocket.Conn, r *http.Request) {
+				count := connections.Add(1)
+				assets <- r.URL.Query().Get("assets")
+
+				if count == 1 {
+					return
+				}
+
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"`+r.URL.Query().Get("assets")+`":"102.5"}`))
+				for {
+					if _, _, err := conn.ReadMessage(); err != nil {
+						return
+					}
+				}
+			})
+
+			ctx, cancel = context.WithCancel(context.Background())
+			s = streamer.NewStreamer(ctx, streamer.StreamerConfig{
+				ChanStreamUpdateQuotePrice: inputChanStreamUpdateQuotePrice,
+				ChanStreamUpdateStatus:     outputChanStreamUpdateStatus,
+				ChanError:                  inputChanError,
+			})
+			s.SetURL("ws://" + inputServer.URL[7:])
+			s.SetReconnectBackoff(10*time.Millisecond, 40*time.Millisecond)
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		When("the connection is dropped", func() {
+			It("should reconnect with the current symbols and version vector", func() {
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 2)
+				Expect(s.Start()).To(Succeed())
+
+				Eventually(outputAssets).Should(Receive(Equal("bitcoin")))
+				Eventually(outputAssets).Should(Receive(Equal("bitcoin")))
+				Expect(connectionCount.Load()).To(Equal(int32(2)))
+
+				Eventually(inputChanStreamUpdateQuotePrice).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID":            Equal("bitcoin"),
+						"VersionVector": Equal(2),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(102.5),
+						}),
+					}),
+				))
+				Consistently(inputChanError).ShouldNot(Receive())
+			})
+
+			It("should send status updates for the disconnect, the reconnect attempt, and the new connection", func() {
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+				Expect(s.Start()).To(Succeed())
+
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Source": Equal(c.QuoteSourceCoinCap),
+					"Status": Equal(c.StreamStatusConnected),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status": Equal(c.StreamStatusDisconnected),
+					"Err":    HaveOccurred(),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusReconnecting),
+					"Attempt": Equal(1),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusConnected),
+					"Attempt": Equal(1),
+				})))
+			})
+		})
+
+		When("the server is not available", func() {
+			It("should start without an error and keep retrying until the streamer is stopped", func() {
+				inputServer.Listener.Close()
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+
+				Expect(s.Start()).To(Succeed())
+
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status": Equal(c.StreamStatusDisconnected),
+					"Err":    HaveOccurred(),
+				})))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status":  Equal(c.StreamStatusReconnecting),
+					"Attempt": Equal(3),
+				})))
+
+				cancel()
+
+				Eventually(outputChanStreamUpdateStatus).ShouldNot(Receive())
+				Consistently(outputChanStreamUpdateStatus, 200*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+
+		When("the symbols change while reconnecting", func() {
+			It("should connect only with the new symbols", func() {
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+				Expect(s.Start()).To(Succeed())
+				Eventually(outputAssets).Should(Receive(Equal("bitcoin")))
+				Eventually(outputChanStreamUpdateStatus).Should(Receive(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Status": Equal(c.StreamStatusDisconnected),
+				})))
+
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"ethereum"}, 1)).To(Succeed())
+
+				Eventually(outputAssets).Should(Receive(Equal("ethereum")))
+				Consistently(outputAssets, 200*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+	})
+
+	Describe("SetURL", func() {
+		When("the streamer is started", func() {
+			It("should return an error", func() {
+				Expect(s.Start()).To(Succeed())
+				Expect(s.SetURL("ws://localhost")).To(MatchError("cannot set URL while streamer is connected"))
+			})
+		})
+	})
+})
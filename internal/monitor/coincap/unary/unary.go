@@ -0,0 +1,133 @@
+package unary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/util"
+)
+
+const (
+	fromCurrencyCode = "USD"
+)
+
+// Response represents the container object from the API response
+type Response struct {
+	Data []ResponseQuote `json:"data"`
+}
+
+// ResponseQuote represents a quote of a single asset from the CoinCap API
+type ResponseQuote struct {
+	ID                string `json:"id"`
+	Symbol            string `json:"symbol"`
+	Name              string `json:"name"`
+	Price             string `json:"priceUsd"`
+	ChangePercent24Hr string `json:"changePercent24Hr"`
+	MarketCap         string `json:"marketCapUsd"`
+	Volume24Hr        string `json:"volumeUsd24Hr"`
+}
+
+type UnaryAPI struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewUnaryAPI(baseURL string) *UnaryAPI {
+	return &UnaryAPI{
+		client:  &http.Client{Timeout: util.RequestTimeout},
+		baseURL: baseURL,
+	}
+}
+
+func transformResponseQuote(responseQuote ResponseQuote) c.AssetQuote {
+	price, _ := strconv.ParseFloat(responseQuote.Price, 64)
+	changePercent, _ := strconv.ParseFloat(responseQuote.ChangePercent24Hr, 64)
+	marketCap, _ := strconv.ParseFloat(responseQuote.MarketCap, 64)
+	volume, _ := strconv.ParseFloat(responseQuote.Volume24Hr, 64)
+
+	// Price 24 hours ago is used in place of the previous close since crypto markets do not close
+	pricePrevClose := price / (1 + (changePercent / 100))
+
+	return c.AssetQuote{
+		Name:   responseQuote.Name,
+		Symbol: strings.ToUpper(responseQuote.ID) + ".CC",
+		Class:  c.AssetClassCryptocurrency,
+		Currency: c.Currency{
+			FromCurrencyCode: fromCurrencyCode,
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          price,
+			PricePrevClose: pricePrevClose,
+			PriceOpen:      pricePrevClose,
+			Change:         price - pricePrevClose,
+			ChangePercent:  changePercent,
+		},
+		QuoteExtended: c.QuoteExtended{
+			MarketCap: marketCap,
+			Volume:    volume,
+		},
+		QuoteSource: c.QuoteSourceCoinCap,
+		Exchange: c.Exchange{
+			Name:                    "CoinCap",
+			State:                   c.ExchangeStateOpen,
+			IsActive:                true,
+			IsRegularTradingSession: true, // Crypto markets are always in regular session
+		},
+		Meta: c.Meta{
+			IsVariablePrecision: true,
+			SymbolInSourceAPI:   responseQuote.ID,
+		},
+	}
+}
+
+func transformResponseQuotes(responseQuotes []ResponseQuote) ([]c.AssetQuote, map[string]*c.AssetQuote) {
+	quotes := make([]c.AssetQuote, 0, len(responseQuotes))
+	quotesByID := make(map[string]*c.AssetQuote, len(responseQuotes))
+
+	for _, responseQuote := range responseQuotes {
+		quote := transformResponseQuote(responseQuote)
+		quotes = append(quotes, quote)
+		quotesByID[quote.Meta.SymbolInSourceAPI] = &quote
+	}
+
+	return quotes, quotesByID
+}
+
+// GetAssetQuotes gets quotes for CoinCap asset ids (e.g. "bitcoin") in a single request
+func (u *UnaryAPI) GetAssetQuotes(ids []string) ([]c.AssetQuote, map[string]*c.AssetQuote, error) {
+	if len(ids) == 0 {
+		return []c.AssetQuote{}, make(map[string]*c.AssetQuote), nil
+	}
+
+	// Build URL with query parameters
+	reqURL, _ := url.Parse(u.baseURL + "/v2/assets")
+	q := reqURL.Query()
+	q.Set("ids", strings.Join(ids, ","))
+	reqURL.RawQuery = q.Encode()
+
+	// Make request
+	resp, err := u.client.Get(reqURL.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	// Decode response
+	var result Response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	quotes, quotesByID := transformResponseQuotes(result.Data)
+
+	return quotes, quotesByID, nil
+}
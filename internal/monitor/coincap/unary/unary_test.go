@@ -0,0 +1,106 @@
+package unary_test
+
+import (
+	"net/http"
+
+	"github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+var _ = Describe("Unary", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewUnaryAPI", func() {
+		It("should return a new UnaryAPI", func() {
+			api := unary.NewUnaryAPI(server.URL())
+			Expect(api).NotTo(BeNil())
+		})
+	})
+
+	Describe("GetAssetQuotes", func() {
+		It("should return a list of asset quotes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/assets", "ids=bitcoin%2Cethereum"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+						Data: []unary.ResponseQuote{
+							{
+								ID:                "bitcoin",
+								Symbol:            "BTC",
+								Name:              "Bitcoin",
+								Price:             "55000.00",
+								ChangePercent24Hr: "10.00",
+								MarketCap:         "1000000000000",
+								Volume24Hr:        "25000000000",
+							},
+							{
+								ID:     "ethereum",
+								Symbol: "ETH",
+								Name:   "Ethereum",
+								Price:  "3000.00",
+							},
+						},
+					}),
+				),
+			)
+
+			api := unary.NewUnaryAPI(server.URL())
+			quotes, quotesByID, err := api.GetAssetQuotes([]string{"bitcoin", "ethereum"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotes).To(HaveLen(2))
+			Expect(quotes[0].Symbol).To(Equal("BITCOIN.CC"))
+			Expect(quotes[0].Name).To(Equal("Bitcoin"))
+			Expect(quotes[0].Class).To(Equal(c.AssetClassCryptocurrency))
+			Expect(quotes[0].QuoteSource).To(Equal(c.QuoteSourceCoinCap))
+			Expect(quotes[0].QuotePrice.Price).To(Equal(55000.0))
+			Expect(quotes[0].QuotePrice.PricePrevClose).To(BeNumerically("~", 50000.0, 0.0001))
+			Expect(quotes[0].QuotePrice.Change).To(BeNumerically("~", 5000.0, 0.0001))
+			Expect(quotes[0].QuotePrice.ChangePercent).To(Equal(10.0))
+			Expect(quotes[0].QuoteExtended.MarketCap).To(Equal(1000000000000.0))
+			Expect(quotes[0].QuoteExtended.Volume).To(Equal(25000000000.0))
+			Expect(quotesByID).To(HaveKey("ethereum"))
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/assets"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, _, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+				Expect(err).To(MatchError("request failed with status 500"))
+			})
+		})
+
+		When("there are no symbols set", func() {
+			It("should return an empty list", func() {
+				api := unary.NewUnaryAPI(server.URL())
+				quotes, quotesByID, err := api.GetAssetQuotes([]string{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes).To(BeEmpty())
+				Expect(quotesByID).To(BeEmpty())
+			})
+		})
+	})
+})
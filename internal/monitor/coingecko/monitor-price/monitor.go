@@ -0,0 +1,279 @@
+package monitorPriceCoingecko
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/monitor-price/poller"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+)
+
+const (
+	fromCurrencyCode = "USD"
+)
+
+type MonitorPriceCoingecko struct {
+	unaryAPI                  *unary.UnaryAPI
+	poller                    *poller.Poller
+	ids                       []string                 // CoinGecko APIs refer to coins by id (e.g. "bitcoin") which symbols ticker accepts with a .CG suffix
+	assetQuotesCache          []*c.AssetQuote          // Asset quotes for all assets retrieved at start or on symbol change
+	assetQuotesCacheLookup    map[string]*c.AssetQuote // Asset quotes for all assets retrieved at least once (symbol change does not remove symbols)
+	currencyRatesCache        c.CurrencyRates          // Cache of currency rates
+	currencyHasRequestedRates bool                     // Whether the currency rates have been requested; quotes are always requested in the single default currency of USD
+	chanPollUpdateAssetQuote  chan c.MessageUpdate[c.AssetQuote]
+	chanError                 chan error
+	mu                        sync.RWMutex
+	muCurrencyRates           sync.RWMutex
+	ctx                       context.Context
+	cancel                    context.CancelFunc
+	isStarted                 bool
+	chanUpdateAssetQuote      chan c.MessageUpdate[c.AssetQuote]
+	chanRequestCurrencyRates  chan []string // Channel for currency rate requests
+}
+
+// Config contains the required configuration for the CoinGecko monitor
+type Config struct {
+	Ctx                      context.Context
+	UnaryURL                 string
+	ChanError                chan error
+	ChanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
+	ChanRequestCurrencyRates chan []string
+}
+
+// Option defines an option for configuring the monitor
+type Option func(*MonitorPriceCoingecko)
+
+func NewMonitorPriceCoingecko(config Config, opts ...Option) *MonitorPriceCoingecko {
+	ctx, cancel := context.WithCancel(config.Ctx)
+
+	unaryAPI := unary.NewUnaryAPI(config.UnaryURL)
+
+	monitor := &MonitorPriceCoingecko{
+		assetQuotesCacheLookup:   make(map[string]*c.AssetQuote),
+		assetQuotesCache:         make([]*c.AssetQuote, 0),
+		chanPollUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote]),
+		chanError:                config.ChanError,
+		unaryAPI:                 unaryAPI,
+		ctx:                      ctx,
+		cancel:                   cancel,
+		chanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+		chanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+	}
+
+	pollerConfig := poller.PollerConfig{
+		ChanUpdateAssetQuote: monitor.chanPollUpdateAssetQuote,
+		ChanError:            monitor.chanError,
+		UnaryAPI:             unaryAPI,
+	}
+	monitor.poller = poller.NewPoller(ctx, pollerConfig)
+
+	for _, opt := range opts {
+		opt(monitor)
+	}
+
+	return monitor
+}
+
+// WithRefreshInterval sets the refresh interval for the monitor
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(m *MonitorPriceCoingecko) {
+		// TODO: handle error
+		m.poller.SetRefreshInterval(interval) //nolint:errcheck
+	}
+}
+
+func (m *MonitorPriceCoingecko) GetAssetQuotes(ignoreCache ...bool) ([]c.AssetQuote, error) {
+	if len(ignoreCache) > 0 && ignoreCache[0] {
+		assetQuotes, err := m.getAssetQuotesAndReplaceCache()
+		if err != nil {
+			return []c.AssetQuote{}, err
+		}
+
+		result := make([]c.AssetQuote, len(assetQuotes))
+		for i, quote := range assetQuotes {
+			result[i] = *quote
+		}
+
+		return result, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]c.AssetQuote, len(m.assetQuotesCache))
+	for i, quote := range m.assetQuotesCache {
+		result[i] = *quote
+	}
+
+	return result, nil
+}
+
+func (m *MonitorPriceCoingecko) SetSymbols(ids []string, versionVector int) error {
+
+	m.mu.Lock()
+
+	// Deduplicate ids since input may have duplicates
+	slices.Sort(ids)
+	m.ids = slices.Compact(ids)
+
+	// Request the default currency (USD) once
+	if !m.currencyHasRequestedRates {
+		m.chanRequestCurrencyRates <- []string{fromCurrencyCode}
+		m.currencyHasRequestedRates = true
+	}
+
+	m.mu.Unlock()
+
+	// Since the symbols have changed, make a synchronous call to get price quotes for the new symbols
+	_, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	m.poller.SetSymbols(m.ids, versionVector)
+
+	return nil
+}
+
+func (m *MonitorPriceCoingecko) Start() error {
+	var err error
+
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	// On start, get initial quotes from unary API
+	_, err = m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	err = m.poller.Start()
+	if err != nil {
+		return err
+	}
+
+	go m.handleUpdates()
+
+	m.isStarted = true
+
+	return nil
+}
+
+func (m *MonitorPriceCoingecko) Stop() error {
+
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.cancel()
+
+	return nil
+}
+
+func (m *MonitorPriceCoingecko) handleUpdates() {
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case updateMessage := <-m.chanPollUpdateAssetQuote:
+
+			// Check if cache exists and values have changed before acquiring write lock
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if nothing has changed
+			if assetQuote.QuotePrice == updateMessage.Data.QuotePrice &&
+				assetQuote.QuoteExtended == updateMessage.Data.QuoteExtended {
+
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			// Price is different so update cache
+			m.mu.Lock()
+
+			assetQuote.QuotePrice = updateMessage.Data.QuotePrice
+			assetQuote.QuoteExtended = updateMessage.Data.QuoteExtended
+
+			m.mu.Unlock()
+
+			// Send a message with an updated quote
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+		}
+	}
+}
+
+func (m *MonitorPriceCoingecko) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.muCurrencyRates.Lock()
+	m.currencyRatesCache = currencyRates
+	m.muCurrencyRates.Unlock()
+
+	// TODO: make this more efficient by selectively updating based on changes in rates
+	_, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Get asset quotes from unary API, add currency rates, and replace the asset quotes cache
+func (m *MonitorPriceCoingecko) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote, error) {
+
+	lookup := make(map[string]*c.AssetQuote)
+
+	m.mu.RLock()
+	ids := m.ids
+	m.mu.RUnlock()
+
+	assetQuotes, _, err := m.unaryAPI.GetAssetQuotes(ids)
+	if err != nil {
+		return []*c.AssetQuote{}, err
+	}
+
+	assetQuotesEnriched := make([]*c.AssetQuote, 0, len(assetQuotes))
+
+	m.muCurrencyRates.RLock()
+
+	for _, quote := range assetQuotes {
+
+		// Set the currency rate if available
+		if currencyRate, exists := m.currencyRatesCache[fromCurrencyCode]; exists {
+			quote.Currency.Rate = currencyRate.Rate
+			quote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		lookup[quote.Meta.SymbolInSourceAPI] = &quote
+		assetQuotesEnriched = append(assetQuotesEnriched, &quote)
+	}
+
+	m.muCurrencyRates.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assetQuotesCache = assetQuotesEnriched
+	m.assetQuotesCacheLookup = lookup
+
+	return m.assetQuotesCache, nil
+}
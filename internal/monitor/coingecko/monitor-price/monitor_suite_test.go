@@ -0,0 +1,13 @@
+package monitorPriceCoingecko_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCoingecko(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Coingecko Suite")
+}
@@ -0,0 +1,181 @@
+package monitorPriceCoingecko_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceCoingecko "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/monitor-price"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+)
+
+var _ = Describe("Monitor CoinGecko", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewMonitorPriceCoingecko", func() {
+		It("should return a new MonitorPriceCoingecko", func() {
+			monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+				Ctx:      context.Background(),
+				UnaryURL: server.URL(),
+			})
+			Expect(monitor).NotTo(BeNil())
+		})
+	})
+
+	Describe("SetSymbols", func() {
+		It("should get quotes for the symbols and request USD currency rates once", func() {
+			server.RouteToHandler("GET", "/api/v3/coins/markets",
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids=pepe&vs_currency=usd"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{
+						{ID: "pepe", Symbol: "pepe", Name: "Pepe", Price: 0.000012},
+					}),
+				),
+			)
+
+			chanRequestCurrencyRates := make(chan []string, 2)
+			monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+				Ctx:                      context.Background(),
+				UnaryURL:                 server.URL(),
+				ChanRequestCurrencyRates: chanRequestCurrencyRates,
+			})
+
+			Expect(monitor.SetSymbols([]string{"pepe", "pepe"}, 0)).To(Succeed())
+			Expect(monitor.SetSymbols([]string{"pepe"}, 1)).To(Succeed())
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(1))
+			Expect(assetQuotes[0].Symbol).To(Equal("PEPE.CG"))
+			Expect(chanRequestCurrencyRates).To(Receive(Equal([]string{"USD"})))
+			Expect(chanRequestCurrencyRates).NotTo(Receive())
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.RouteToHandler("GET", "/api/v3/coins/markets", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					Ctx:                      context.Background(),
+					UnaryURL:                 server.URL(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				})
+
+				Expect(monitor.SetSymbols([]string{"pepe"}, 0)).To(MatchError("request failed with status 500"))
+			})
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should apply the USD currency rate to the asset quotes", func() {
+			server.RouteToHandler("GET", "/api/v3/coins/markets",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{
+					{ID: "pepe", Symbol: "pepe", Name: "Pepe", Price: 0.000012},
+				}),
+			)
+
+			monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+				Ctx:                      context.Background(),
+				UnaryURL:                 server.URL(),
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			})
+			monitor.SetSymbols([]string{"pepe"}, 0)
+
+			err := monitor.SetCurrencyRates(c.CurrencyRates{
+				"USD": {FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.9},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes[0].Currency.Rate).To(Equal(0.9))
+			Expect(assetQuotes[0].Currency.ToCurrencyCode).To(Equal("EUR"))
+		})
+	})
+
+	Describe("Start", func() {
+		When("there is a polling asset update", func() {
+			It("should send the updated asset quote to the channel", func() {
+				requestCount := 0
+				server.RouteToHandler("GET", "/api/v3/coins/markets", func(w http.ResponseWriter, r *http.Request) {
+					requestCount++
+					price := 0.000012
+					// Initial requests from SetSymbols and Start return the original price and subsequent polling requests return the updated price
+					if requestCount > 2 {
+						price = 0.000015
+					}
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{
+						{ID: "pepe", Symbol: "pepe", Name: "Pepe", Price: price},
+					})(w, r)
+				})
+
+				chanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					Ctx:                      context.Background(),
+					UnaryURL:                 server.URL(),
+					ChanUpdateAssetQuote:     chanUpdateAssetQuote,
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceCoingecko.WithRefreshInterval(time.Millisecond*50))
+				monitor.SetSymbols([]string{"pepe"}, 0)
+
+				Expect(monitor.Start()).To(Succeed())
+				defer monitor.Stop()
+
+				Eventually(chanUpdateAssetQuote).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID": Equal("PEPE.CG"),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"Price": Equal(0.000015),
+							}),
+						}),
+					}),
+				))
+			})
+		})
+
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				server.RouteToHandler("GET", "/api/v3/coins/markets", ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{}))
+
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					Ctx:      context.Background(),
+					UnaryURL: server.URL(),
+				}, monitorPriceCoingecko.WithRefreshInterval(time.Second))
+
+				Expect(monitor.Start()).To(Succeed())
+				defer monitor.Stop()
+
+				Expect(monitor.Start()).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					Ctx:      context.Background(),
+					UnaryURL: server.URL(),
+				})
+
+				Expect(monitor.Stop()).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
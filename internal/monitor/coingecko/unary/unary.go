@@ -0,0 +1,129 @@
+package unary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/util"
+)
+
+const (
+	vsCurrency = "usd"
+)
+
+// ResponseQuote represents a quote of a single coin from the CoinGecko markets API
+type ResponseQuote struct {
+	ID                       string  `json:"id"`
+	Symbol                   string  `json:"symbol"`
+	Name                     string  `json:"name"`
+	Price                    float64 `json:"current_price"`
+	MarketCap                float64 `json:"market_cap"`
+	Volume                   float64 `json:"total_volume"`
+	PriceDayHigh             float64 `json:"high_24h"`
+	PriceDayLow              float64 `json:"low_24h"`
+	PriceChange24H           float64 `json:"price_change_24h"`
+	PriceChangePercentage24H float64 `json:"price_change_percentage_24h"`
+}
+
+type UnaryAPI struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewUnaryAPI(baseURL string) *UnaryAPI {
+	return &UnaryAPI{
+		client:  &http.Client{Timeout: util.RequestTimeout},
+		baseURL: baseURL,
+	}
+}
+
+func transformResponseQuote(responseQuote ResponseQuote) c.AssetQuote {
+
+	// Price 24 hours ago is used in place of the previous close since crypto markets do not close
+	pricePrevClose := responseQuote.Price - responseQuote.PriceChange24H
+
+	return c.AssetQuote{
+		Name:   responseQuote.Name,
+		Symbol: strings.ToUpper(responseQuote.ID) + ".CG",
+		Class:  c.AssetClassCryptocurrency,
+		Currency: c.Currency{
+			FromCurrencyCode: strings.ToUpper(vsCurrency),
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          responseQuote.Price,
+			PricePrevClose: pricePrevClose,
+			PriceOpen:      pricePrevClose,
+			PriceDayHigh:   responseQuote.PriceDayHigh,
+			PriceDayLow:    responseQuote.PriceDayLow,
+			Change:         responseQuote.PriceChange24H,
+			ChangePercent:  responseQuote.PriceChangePercentage24H,
+		},
+		QuoteExtended: c.QuoteExtended{
+			MarketCap: responseQuote.MarketCap,
+			Volume:    responseQuote.Volume,
+		},
+		QuoteSource: c.QuoteSourceCoingecko,
+		Exchange: c.Exchange{
+			Name:                    "CoinGecko",
+			State:                   c.ExchangeStateOpen,
+			IsActive:                true,
+			IsRegularTradingSession: true, // Crypto markets are always in regular session
+		},
+		Meta: c.Meta{
+			IsVariablePrecision: true,
+			SymbolInSourceAPI:   responseQuote.ID,
+		},
+	}
+}
+
+func transformResponseQuotes(responseQuotes []ResponseQuote) ([]c.AssetQuote, map[string]*c.AssetQuote) {
+	quotes := make([]c.AssetQuote, 0, len(responseQuotes))
+	quotesByID := make(map[string]*c.AssetQuote, len(responseQuotes))
+
+	for _, responseQuote := range responseQuotes {
+		quote := transformResponseQuote(responseQuote)
+		quotes = append(quotes, quote)
+		quotesByID[quote.Meta.SymbolInSourceAPI] = &quote
+	}
+
+	return quotes, quotesByID
+}
+
+// GetAssetQuotes gets quotes for CoinGecko coin ids (e.g. "bitcoin") in a single request
+func (u *UnaryAPI) GetAssetQuotes(ids []string) ([]c.AssetQuote, map[string]*c.AssetQuote, error) {
+	if len(ids) == 0 {
+		return []c.AssetQuote{}, make(map[string]*c.AssetQuote), nil
+	}
+
+	// Build URL with query parameters
+	reqURL, _ := url.Parse(u.baseURL + "/api/v3/coins/markets")
+	q := reqURL.Query()
+	q.Set("vs_currency", vsCurrency)
+	q.Set("ids", strings.Join(ids, ","))
+	reqURL.RawQuery = q.Encode()
+
+	// Make request
+	resp, err := u.client.Get(reqURL.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	// Decode response
+	var result []ResponseQuote
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	quotes, quotesByID := transformResponseQuotes(result)
+
+	return quotes, quotesByID, nil
+}
@@ -0,0 +1,123 @@
+package unary_test
+
+import (
+	"net/http"
+
+	"github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+var _ = Describe("Unary", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewUnaryAPI", func() {
+		It("should return a new UnaryAPI", func() {
+			api := unary.NewUnaryAPI(server.URL())
+			Expect(api).NotTo(BeNil())
+		})
+	})
+
+	Describe("GetAssetQuotes", func() {
+		It("should return a list of asset quotes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids=pepe%2Cshiba-inu&vs_currency=usd"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{
+						{
+							ID:                       "pepe",
+							Symbol:                   "pepe",
+							Name:                     "Pepe",
+							Price:                    0.000012,
+							MarketCap:                5000000000,
+							Volume:                   800000000,
+							PriceDayHigh:             0.000013,
+							PriceDayLow:              0.000010,
+							PriceChange24H:           0.000002,
+							PriceChangePercentage24H: 20,
+						},
+						{
+							ID:     "shiba-inu",
+							Symbol: "shib",
+							Name:   "Shiba Inu",
+							Price:  0.00002,
+						},
+					}),
+				),
+			)
+
+			api := unary.NewUnaryAPI(server.URL())
+			quotes, quotesByID, err := api.GetAssetQuotes([]string{"pepe", "shiba-inu"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotes).To(HaveLen(2))
+			Expect(quotes[0].Symbol).To(Equal("PEPE.CG"))
+			Expect(quotes[0].Name).To(Equal("Pepe"))
+			Expect(quotes[0].Class).To(Equal(c.AssetClassCryptocurrency))
+			Expect(quotes[0].QuoteSource).To(Equal(c.QuoteSourceCoingecko))
+			Expect(quotes[0].Currency.FromCurrencyCode).To(Equal("USD"))
+			Expect(quotes[0].QuotePrice.Price).To(Equal(0.000012))
+			Expect(quotes[0].QuotePrice.PricePrevClose).To(BeNumerically("~", 0.000010, 1e-12))
+			Expect(quotes[0].QuotePrice.ChangePercent).To(Equal(20.0))
+			Expect(quotes[0].QuoteExtended.MarketCap).To(Equal(5000000000.0))
+			Expect(quotes[1].Symbol).To(Equal("SHIBA-INU.CG"))
+			Expect(quotesByID).To(HaveKey("shiba-inu"))
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/coins/markets"),
+						ghttp.RespondWith(http.StatusTooManyRequests, ""),
+					),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, _, err := api.GetAssetQuotes([]string{"pepe"})
+
+				Expect(err).To(MatchError("request failed with status 429"))
+			})
+		})
+
+		When("the response cannot be decoded", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/coins/markets"),
+						ghttp.RespondWith(http.StatusOK, "{invalid"),
+					),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, _, err := api.GetAssetQuotes([]string{"pepe"})
+
+				Expect(err).To(MatchError(ContainSubstring("failed to decode response")))
+			})
+		})
+
+		When("there are no symbols set", func() {
+			It("should return an empty list", func() {
+				api := unary.NewUnaryAPI(server.URL())
+				quotes, quotesByID, err := api.GetAssetQuotes([]string{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes).To(BeEmpty())
+				Expect(quotesByID).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -9,12 +9,13 @@ import (
 	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
-	monitorPriceCoinbase "github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/monitor-price"
 	monitorCurrencyRate "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-currency-rates"
-	monitorPriceYahoo "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-price"
 	unaryClientYahoo "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
 )
 
+// recordBufferSize is the number of asset quotes which can be queued for the recorder before updates are dropped from history
+const recordBufferSize = 256
+
 // Monitor represents an overall monitor which manages API specific monitors
 type Monitor struct {
 	monitors                map[c.QuoteSource]c.Monitor
@@ -22,8 +23,16 @@ type Monitor struct {
 	chanError               chan error
 	chanUpdateAssetQuote    chan c.MessageUpdate[c.AssetQuote]
 	chanUpdateCurrencyRates chan c.CurrencyRates
+	chanUpdateStreamStatus  chan c.StreamStatusUpdate
+	chanRecordAssetQuote    chan c.AssetQuote
 	onUpdateAssetQuote      func(symbol string, assetQuote c.AssetQuote, versionVector int)
 	onUpdateAssetGroupQuote func(assetGroupQuote c.AssetGroupQuote, versionVector int)
+	onUpdateStreamStatus    func(streamStatus c.StreamStatusUpdate)
+	onAlert                 func(alert c.Alert)
+	onError                 func(err error)
+	recorder                Recorder
+	wgRecorder              sync.WaitGroup
+	alerter                 Alerter
 	assetGroupVersionVector int
 	assetGroup              c.AssetGroup
 	mu                      sync.RWMutex
@@ -37,8 +46,15 @@ type ConfigMonitor struct {
 	RefreshInterval int
 	TargetCurrency  string
 	Logger          *log.Logger
+	Registry        *Registry // Quote sources to create monitors for; defaults to the built-in sources when not set
+	Recorder        Recorder  // Optional recorder for asset quote updates
+	Alerter         Alerter   // Optional alert rules evaluated on asset quote updates
 	ConfigMonitorPriceCoinbase
+	ConfigMonitorPriceCoingecko
+	ConfigMonitorPriceCoinCap
 	ConfigMonitorsYahoo
+	ConfigMonitorUserDefined
+	ConfigMonitorPrivate
 }
 
 // ConfigMonitorPriceCoinbase represents the configuration for the Coinbase monitor
@@ -47,6 +63,17 @@ type ConfigMonitorPriceCoinbase struct {
 	StreamingURL string
 }
 
+// ConfigMonitorPriceCoingecko represents the configuration for the CoinGecko monitor
+type ConfigMonitorPriceCoingecko struct {
+	BaseURL string
+}
+
+// ConfigMonitorPriceCoinCap represents the configuration for the CoinCap monitor
+type ConfigMonitorPriceCoinCap struct {
+	BaseURL      string
+	StreamingURL string
+}
+
 // ConfigMonitorsYahoo represents the configuration for the Yahoo monitors (price and currency rate)
 type ConfigMonitorsYahoo struct {
 	BaseURL           string
@@ -55,10 +82,71 @@ type ConfigMonitorsYahoo struct {
 	SessionConsentURL string
 }
 
+// ConfigMonitorUserDefined represents the configuration for the user defined source monitor
+type ConfigMonitorUserDefined struct {
+	Sources []c.ConfigSourceUserDefined
+}
+
+// ConfigMonitorPrivate represents the configuration for the private security monitor
+type ConfigMonitorPrivate struct {
+	Securities []c.ConfigPrivateSecurity
+}
+
+// Recorder persists asset quote updates
+type Recorder interface {
+	RecordAssetQuote(assetQuote c.AssetQuote) error
+}
+
+// Alerter evaluates alert rules against asset quote updates
+type Alerter interface {
+	Evaluate(assetQuote c.AssetQuote) []c.Alert
+}
+
 // ConfigUpdateFns represents the callback functions for when asset quotes are updated
 type ConfigUpdateFns struct {
 	OnUpdateAssetQuote      func(symbol string, assetQuote c.AssetQuote, versionVector int)
 	OnUpdateAssetGroupQuote func(assetGroupQuote c.AssetGroupQuote, versionVector int)
+	OnUpdateStreamStatus    func(streamStatus c.StreamStatusUpdate) // Optional callback for when a streaming source connects, disconnects, or reconnects
+	OnAlert                 func(alert c.Alert)                     // Optional callback for when an alert rule is met
+	OnError                 func(err error)                         // Optional callback for when a monitor fails to get quotes
+}
+
+// NewConfigMonitor builds the monitor configuration from external dependencies and user defined configuration
+func NewConfigMonitor(dep c.Dependencies, ctx c.Context) ConfigMonitor {
+
+	privateSecurities := append([]c.ConfigPrivateSecurity{}, ctx.Config.PrivateSecurities...)
+	for _, assetGroup := range ctx.Config.AssetGroup {
+		privateSecurities = append(privateSecurities, assetGroup.PrivateSecurities...)
+	}
+
+	return ConfigMonitor{
+		RefreshInterval: ctx.Config.RefreshInterval,
+		TargetCurrency:  ctx.Config.Currency,
+		Logger:          ctx.Logger,
+		ConfigMonitorsYahoo: ConfigMonitorsYahoo{
+			BaseURL:           dep.MonitorYahooBaseURL,
+			SessionRootURL:    dep.MonitorYahooSessionRootURL,
+			SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
+			SessionConsentURL: dep.MonitorYahooSessionConsentURL,
+		},
+		ConfigMonitorPriceCoinbase: ConfigMonitorPriceCoinbase{
+			BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
+			StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
+		},
+		ConfigMonitorPriceCoingecko: ConfigMonitorPriceCoingecko{
+			BaseURL: dep.MonitorPriceCoingeckoBaseURL,
+		},
+		ConfigMonitorPriceCoinCap: ConfigMonitorPriceCoinCap{
+			BaseURL:      dep.MonitorPriceCoinCapBaseURL,
+			StreamingURL: dep.MonitorPriceCoinCapStreamingURL,
+		},
+		ConfigMonitorUserDefined: ConfigMonitorUserDefined{
+			Sources: ctx.Config.SourcesUserDefined,
+		},
+		ConfigMonitorPrivate: ConfigMonitorPrivate{
+			Securities: privateSecurities,
+		},
+	}
 }
 
 // New creates a new instance of the Coinbase monitor
@@ -68,21 +156,10 @@ func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 	chanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 10)
 	chanUpdateCurrencyRate := make(chan c.CurrencyRates, 10)
 	chanRequestCurrencyRate := make(chan []string, 10)
+	chanUpdateStreamStatus := make(chan c.StreamStatusUpdate, 10)
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	coinbase := monitorPriceCoinbase.NewMonitorPriceCoinbase(
-		monitorPriceCoinbase.Config{
-			Ctx:                      ctx,
-			UnaryURL:                 configMonitor.ConfigMonitorPriceCoinbase.BaseURL,
-			ChanError:                chanError,
-			ChanUpdateAssetQuote:     chanUpdateAssetQuote,
-			ChanRequestCurrencyRates: chanRequestCurrencyRate,
-		},
-		monitorPriceCoinbase.WithStreamingURL(configMonitor.ConfigMonitorPriceCoinbase.StreamingURL),
-		monitorPriceCoinbase.WithRefreshInterval(time.Duration(configMonitor.RefreshInterval)*time.Second),
-	)
-
 	// Create and configure the API client for the Yahoo API shared between monitors
 	unaryAPI := unaryClientYahoo.NewUnaryAPI(unaryClientYahoo.Config{
 		BaseURL:           configMonitor.ConfigMonitorsYahoo.BaseURL,
@@ -91,16 +168,31 @@ func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 		SessionConsentURL: configMonitor.ConfigMonitorsYahoo.SessionConsentURL,
 	})
 
-	yahoo := monitorPriceYahoo.NewMonitorPriceYahoo(
-		monitorPriceYahoo.Config{
+	registry := configMonitor.Registry
+	if registry == nil {
+		registry = DefaultRegistry()
+	}
+
+	// Create a monitor for each registered quote source
+	monitors := make(map[c.QuoteSource]c.Monitor)
+	for _, source := range registry.Sources() {
+		monitor, err := source.NewMonitor(ConfigSource{
 			Ctx:                      ctx,
-			UnaryAPI:                 unaryAPI,
+			ConfigMonitor:            configMonitor,
+			UnaryAPIYahoo:            unaryAPI,
 			ChanError:                chanError,
 			ChanUpdateAssetQuote:     chanUpdateAssetQuote,
 			ChanRequestCurrencyRates: chanRequestCurrencyRate,
-		},
-		monitorPriceYahoo.WithRefreshInterval(time.Duration(configMonitor.RefreshInterval)*time.Second),
-	)
+			ChanUpdateStreamStatus:   chanUpdateStreamStatus,
+		})
+		if err != nil {
+			cancel()
+
+			return nil, fmt.Errorf("failed to create monitor for source %d: %w", source.QuoteSource, err)
+		}
+
+		monitors[source.QuoteSource] = monitor
+	}
 
 	yahooCurrencyRate := monitorCurrencyRate.NewMonitorCurrencyRateYahoo(
 		monitorCurrencyRate.Config{
@@ -115,16 +207,20 @@ func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 	yahooCurrencyRate.SetTargetCurrency(configMonitor.TargetCurrency)
 
 	m := &Monitor{
-		monitors: map[c.QuoteSource]c.Monitor{
-			c.QuoteSourceCoinbase: coinbase,
-			c.QuoteSourceYahoo:    yahoo,
-		},
+		monitors:                monitors,
 		monitorCurrencyRate:     yahooCurrencyRate,
 		chanUpdateAssetQuote:    chanUpdateAssetQuote,
 		chanUpdateCurrencyRates: chanUpdateCurrencyRate,
+		chanUpdateStreamStatus:  chanUpdateStreamStatus,
+		chanRecordAssetQuote:    make(chan c.AssetQuote, recordBufferSize),
 		chanError:               chanError,
 		onUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {},
 		onUpdateAssetQuote:      func(symbol string, assetQuote c.AssetQuote, versionVector int) {},
+		onUpdateStreamStatus:    func(streamStatus c.StreamStatusUpdate) {},
+		onAlert:                 func(alert c.Alert) {},
+		onError:                 func(err error) {},
+		recorder:                configMonitor.Recorder,
+		alerter:                 configMonitor.Alerter,
 		logger:                  configMonitor.Logger,
 		ctx:                     ctx,
 		cancel:                  cancel,
@@ -212,6 +308,18 @@ func (m *Monitor) SetOnUpdate(config ConfigUpdateFns) error {
 	m.onUpdateAssetQuote = config.OnUpdateAssetQuote
 	m.onUpdateAssetGroupQuote = config.OnUpdateAssetGroupQuote
 
+	if config.OnUpdateStreamStatus != nil {
+		m.onUpdateStreamStatus = config.OnUpdateStreamStatus
+	}
+
+	if config.OnAlert != nil {
+		m.onAlert = config.OnAlert
+	}
+
+	if config.OnError != nil {
+		m.onError = config.OnError
+	}
+
 	return nil
 }
 
@@ -224,6 +332,11 @@ func (m *Monitor) Start() {
 		monitor.Start() //nolint:errcheck
 	}
 
+	if m.recorder != nil {
+		m.wgRecorder.Add(1)
+		go m.handleRecords()
+	}
+
 	go m.handleUpdates()
 }
 
@@ -234,7 +347,12 @@ func (m *Monitor) GetAssetGroupQuote(ignoreCache ...bool) c.AssetGroupQuote {
 
 	for _, symbolBySource := range m.assetGroup.SymbolsBySource {
 
-		assetQuotes, _ := m.monitors[symbolBySource.Source].GetAssetQuotes(ignoreCache...)
+		monitor, exists := m.monitors[symbolBySource.Source]
+		if !exists {
+			continue
+		}
+
+		assetQuotes, _ := monitor.GetAssetQuotes(ignoreCache...)
 		assetQuotesFromAllSources = append(assetQuotesFromAllSources, assetQuotes...)
 
 	}
@@ -245,6 +363,29 @@ func (m *Monitor) GetAssetGroupQuote(ignoreCache ...bool) c.AssetGroupQuote {
 	}
 }
 
+// GetPriceHistory synchronously gets historical prices for an asset from its source
+func (m *Monitor) GetPriceHistory(assetQuote c.AssetQuote, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error) {
+
+	monitor, exists := m.monitors[assetQuote.QuoteSource]
+	if !exists {
+		return c.PriceHistory{}, fmt.Errorf("no monitor for source %d", assetQuote.QuoteSource)
+	}
+
+	monitorPriceHistory, ok := monitor.(c.MonitorPriceHistory)
+	if !ok {
+		return c.PriceHistory{}, fmt.Errorf("price history is not supported for source %d", assetQuote.QuoteSource)
+	}
+
+	priceHistory, err := monitorPriceHistory.GetPriceHistory(assetQuote.Meta.SymbolInSourceAPI, historyRange, interval)
+	if err != nil {
+		return c.PriceHistory{}, err
+	}
+
+	priceHistory.Symbol = assetQuote.Symbol
+
+	return priceHistory, nil
+}
+
 // handleUpdates listens for asset quote updates and errors from monitors
 func (m *Monitor) handleUpdates() {
 	for {
@@ -264,15 +405,42 @@ func (m *Monitor) handleUpdates() {
 			}
 			m.mu.RUnlock()
 
+			// Quotes are recorded in the background so that disk writes do not delay updates; if the recorder falls behind,
+			// quotes are dropped from history rather than blocking updates
+			if m.recorder != nil {
+				select {
+				case m.chanRecordAssetQuote <- update.Data:
+				default:
+					if m.logger != nil {
+						m.logger.Printf("failed to record quote: recorder is behind")
+					}
+				}
+			}
+
+			if m.alerter != nil {
+				for _, alert := range m.alerter.Evaluate(update.Data) {
+					go m.onAlert(alert)
+				}
+			}
+
 			// Call the callback function for individual asset quote updates
 			go m.onUpdateAssetQuote(update.Data.Symbol, update.Data, update.VersionVector)
 
+		case streamStatus := <-m.chanUpdateStreamStatus:
+			if m.logger != nil && streamStatus.Err != nil {
+				m.logger.Printf("stream disconnected: %v", streamStatus.Err)
+			}
+
+			go m.onUpdateStreamStatus(streamStatus)
+
 		case err := <-m.chanError:
 			// Log errors using the configured logger if one is set
 			if m.logger != nil {
 				m.logger.Printf("%v", err)
 			}
 
+			go m.onError(err)
+
 		case currencyRates := <-m.chanUpdateCurrencyRates:
 			// Set currency rates on each each monitor
 			for _, monitor := range m.monitors {
@@ -283,15 +451,46 @@ func (m *Monitor) handleUpdates() {
 			}
 
 			// Get asset quotes for all sources with new currency rates
+			m.mu.RLock()
 			assetGroupQuote := m.GetAssetGroupQuote()
+			versionVector := m.assetGroupVersionVector
+			m.mu.RUnlock()
 
 			// Callback with new asset quotes which include the new currency rates
-			go m.onUpdateAssetGroupQuote(assetGroupQuote, m.assetGroupVersionVector)
+			go m.onUpdateAssetGroupQuote(assetGroupQuote, versionVector)
+		}
+	}
+}
+
+// handleRecords persists asset quotes queued by handleUpdates until the monitor is stopped
+func (m *Monitor) handleRecords() {
+	defer m.wgRecorder.Done()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			// Persist quotes queued before the monitor was stopped
+			for {
+				select {
+				case assetQuote := <-m.chanRecordAssetQuote:
+					m.recordAssetQuote(assetQuote)
+				default:
+					return
+				}
+			}
+		case assetQuote := <-m.chanRecordAssetQuote:
+			m.recordAssetQuote(assetQuote)
 		}
 	}
 }
 
-// Stop stops all monitors and cancels the context
+func (m *Monitor) recordAssetQuote(assetQuote c.AssetQuote) {
+	if err := m.recorder.RecordAssetQuote(assetQuote); err != nil && m.logger != nil {
+		m.logger.Printf("failed to record quote: %v", err)
+	}
+}
+
+// Stop stops all monitors, cancels the context, and waits for queued asset quotes to be recorded
 func (m *Monitor) Stop() {
 
 	for _, monitor := range m.monitors {
@@ -300,4 +499,6 @@ func (m *Monitor) Stop() {
 
 	m.cancel()
 
+	m.wgRecorder.Wait()
+
 }
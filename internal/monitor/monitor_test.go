@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -291,8 +292,234 @@ var _ = Describe("Monitor", func() {
 					m.Stop()
 				})
 
+				It("should send the error to the error callback", func() {
+
+					callCount := 0
+					serverYahoo.RouteToHandler("GET", "/v7/finance/quote",
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/v7/finance/quote"),
+							func(w http.ResponseWriter, req *http.Request) {
+								callCount++
+
+								// Fail once symbols are set and the monitor is polling for updates
+								if callCount >= 3 {
+									w.Header().Set("Location", "://bad-url")
+									w.WriteHeader(http.StatusFound)
+
+									return
+								}
+
+								json.NewEncoder(w).Encode(unary.Response{
+									QuoteResponse: unary.ResponseQuoteResponse{
+										Quotes: []unary.ResponseQuote{
+											{
+												MarketState:        "REGULAR",
+												ShortName:          "Apple Inc.",
+												RegularMarketPrice: unary.ResponseFieldFloat{Raw: 150.00, Fmt: "150.00"},
+												Symbol:             "AAPL",
+											},
+										},
+									},
+								})
+							},
+						),
+					)
+
+					m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+						RefreshInterval: 1,
+						ConfigMonitorsYahoo: monitor.ConfigMonitorsYahoo{
+							BaseURL:           serverYahoo.URL(),
+							SessionRootURL:    serverYahoo.URL(),
+							SessionCrumbURL:   serverYahoo.URL(),
+							SessionConsentURL: serverYahoo.URL(),
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					chanErrors := make(chan error, 10)
+
+					err = m.SetOnUpdate(monitor.ConfigUpdateFns{
+						OnUpdateAssetQuote:      func(symbol string, assetQuote c.AssetQuote, versionVector int) {},
+						OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {},
+						OnError: func(err error) {
+							chanErrors <- err
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					m.SetAssetGroup(c.AssetGroup{
+						SymbolsBySource: []c.AssetGroupSymbolsBySource{
+							{
+								Source:  c.QuoteSourceYahoo,
+								Symbols: []string{"AAPL"},
+							},
+						},
+					}, 0)
+
+					m.Start()
+
+					var received error
+					Eventually(chanErrors, 3*time.Second).Should(Receive(&received))
+					Expect(received.Error()).To(ContainSubstring("missing protocol scheme"))
+
+					m.Stop()
+				})
+
+			})
+
+		})
+
+	})
+
+	Describe("Recorder", func() {
+
+		It("should record asset quote updates for the current asset group", func() {
+			var chanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+			recorder := &recorderStub{}
+
+			source := newSourceStub(c.QuoteSourceCoingecko, ".CG", &monitorStub{})
+			source.NewMonitor = func(config monitor.ConfigSource) (c.Monitor, error) {
+				chanUpdateAssetQuote = config.ChanUpdateAssetQuote
+
+				return &monitorStub{}, nil
+			}
+
+			m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+				Registry: monitor.NewRegistry(source),
+				Recorder: recorder,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			m.Start()
+			defer m.Stop()
+
+			chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            "PEPE.CG",
+				Data:          c.AssetQuote{Symbol: "PEPE.CG", QuotePrice: c.QuotePrice{Price: 1.5}},
+				VersionVector: 0,
+			}
+			chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            "PEPE.CG",
+				Data:          c.AssetQuote{Symbol: "PEPE.CG", QuotePrice: c.QuotePrice{Price: 1.6}},
+				VersionVector: 1,
+			}
+
+			Eventually(recorder.getSymbols).Should(Equal([]string{"PEPE.CG"}))
+			Consistently(recorder.getSymbols, 100*time.Millisecond).Should(HaveLen(1))
+		})
+
+		When("the recorder is slow", func() {
+			It("should not delay asset quote updates and should record queued quotes when stopped", func() {
+				var chanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+				recorder := &recorderStub{block: make(chan struct{})}
+				chanSymbolsUpdated := make(chan string, 5)
+
+				source := newSourceStub(c.QuoteSourceCoingecko, ".CG", &monitorStub{})
+				source.NewMonitor = func(config monitor.ConfigSource) (c.Monitor, error) {
+					chanUpdateAssetQuote = config.ChanUpdateAssetQuote
+
+					return &monitorStub{}, nil
+				}
+
+				m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+					Registry: monitor.NewRegistry(source),
+					Recorder: recorder,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = m.SetOnUpdate(monitor.ConfigUpdateFns{
+					OnUpdateAssetQuote: func(symbol string, _ c.AssetQuote, _ int) {
+						chanSymbolsUpdated <- symbol
+					},
+					OnUpdateAssetGroupQuote: func(_ c.AssetGroupQuote, _ int) {},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				m.Start()
+
+				chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+					ID:   "PEPE.CG",
+					Data: c.AssetQuote{Symbol: "PEPE.CG", QuotePrice: c.QuotePrice{Price: 1.5}},
+				}
+				chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+					ID:   "DOGE.CG",
+					Data: c.AssetQuote{Symbol: "DOGE.CG", QuotePrice: c.QuotePrice{Price: 0.1}},
+				}
+
+				symbolsUpdated := make([]string, 0)
+				Eventually(func() []string {
+					select {
+					case symbol := <-chanSymbolsUpdated:
+						symbolsUpdated = append(symbolsUpdated, symbol)
+					default:
+					}
+
+					return symbolsUpdated
+				}).Should(ConsistOf("PEPE.CG", "DOGE.CG"))
+				Expect(recorder.getSymbols()).To(BeEmpty())
+
+				close(recorder.block)
+				m.Stop()
+
+				Expect(recorder.getSymbols()).To(Equal([]string{"PEPE.CG", "DOGE.CG"}))
+			})
+		})
+
+	})
+
+	Describe("Alerter", func() {
+
+		It("should send alerts for asset quote updates in the current asset group to the alert callback", func() {
+			var chanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+			var mu sync.Mutex
+			alerts := make([]c.Alert, 0)
+
+			source := newSourceStub(c.QuoteSourceCoingecko, ".CG", &monitorStub{})
+			source.NewMonitor = func(config monitor.ConfigSource) (c.Monitor, error) {
+				chanUpdateAssetQuote = config.ChanUpdateAssetQuote
+
+				return &monitorStub{}, nil
+			}
+
+			m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+				Registry: monitor.NewRegistry(source),
+				Alerter:  &alerterStub{priceAbove: 1.5},
 			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.SetOnUpdate(monitor.ConfigUpdateFns{
+				OnUpdateAssetQuote:      func(_ string, _ c.AssetQuote, _ int) {},
+				OnUpdateAssetGroupQuote: func(_ c.AssetGroupQuote, _ int) {},
+				OnAlert: func(alert c.Alert) {
+					mu.Lock()
+					defer mu.Unlock()
+					alerts = append(alerts, alert)
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			m.Start()
+			defer m.Stop()
+
+			getAlerts := func() []c.Alert {
+				mu.Lock()
+				defer mu.Unlock()
 
+				return append([]c.Alert{}, alerts...)
+			}
+
+			for _, update := range []c.MessageUpdate[c.AssetQuote]{
+				{ID: "PEPE.CG", Data: c.AssetQuote{Symbol: "PEPE.CG", QuotePrice: c.QuotePrice{Price: 1.4}}, VersionVector: 0},
+				{ID: "PEPE.CG", Data: c.AssetQuote{Symbol: "PEPE.CG", QuotePrice: c.QuotePrice{Price: 1.6}}, VersionVector: 0},
+				{ID: "PEPE.CG", Data: c.AssetQuote{Symbol: "PEPE.CG", QuotePrice: c.QuotePrice{Price: 1.7}}, VersionVector: 1},
+			} {
+				chanUpdateAssetQuote <- update
+			}
+
+			Eventually(getAlerts).Should(HaveExactElements(
+				And(HaveField("Symbol", "PEPE.CG"), HaveField("Price", 1.6)),
+			))
+			Consistently(getAlerts, 100*time.Millisecond).Should(HaveLen(1))
 		})
 
 	})
@@ -465,6 +692,67 @@ var _ = Describe("Monitor", func() {
 
 	})
 
+	Describe("GetPriceHistory", func() {
+
+		It("should get the price history from the monitor for the source of the asset", func() {
+			stub := &monitorPriceHistoryStub{
+				priceHistory: c.PriceHistory{
+					Symbol:  "pepe",
+					Candles: []c.PriceCandle{{Close: 1.5}, {Close: 1.6}},
+				},
+			}
+
+			m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+				Registry: monitor.NewRegistry(newSourceStub(c.QuoteSourceCoingecko, ".CG", stub)),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			priceHistory, err := m.GetPriceHistory(c.AssetQuote{
+				Symbol:      "PEPE.CG",
+				QuoteSource: c.QuoteSourceCoingecko,
+				Meta:        c.Meta{SymbolInSourceAPI: "pepe"},
+			}, c.PriceHistoryRange1Day, c.PriceHistoryInterval5Minute)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stub.symbol).To(Equal("pepe"))
+			Expect(priceHistory.Symbol).To(Equal("PEPE.CG"))
+			Expect(priceHistory.Candles).To(HaveLen(2))
+		})
+
+		When("the monitor for the source does not support price history", func() {
+			It("should return an error", func() {
+				m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+					Registry: monitor.NewRegistry(newSourceStub(c.QuoteSourceCoingecko, ".CG", &monitorStub{})),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = m.GetPriceHistory(c.AssetQuote{
+					Symbol:      "PEPE.CG",
+					QuoteSource: c.QuoteSourceCoingecko,
+				}, c.PriceHistoryRange1Day, c.PriceHistoryInterval5Minute)
+
+				Expect(err).To(MatchError(ContainSubstring("price history is not supported")))
+			})
+		})
+
+		When("there is no monitor for the source", func() {
+			It("should return an error", func() {
+				m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+					Registry: monitor.NewRegistry(newSourceStub(c.QuoteSourceCoingecko, ".CG", &monitorStub{})),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = m.GetPriceHistory(c.AssetQuote{
+					Symbol:      "BTC.CB",
+					QuoteSource: c.QuoteSourceCoinbase,
+				}, c.PriceHistoryRange1Day, c.PriceHistoryInterval5Minute)
+
+				Expect(err).To(MatchError(ContainSubstring("no monitor for source")))
+			})
+		})
+
+	})
+
 })
 
 // setupCoinbaseMockHandler sets up a mock handler for Coinbase API responses
@@ -0,0 +1,256 @@
+package monitorPricePrivate
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	defaultCurrencyCode = "USD"
+	valuationDateLayout = "2006-01-02"
+)
+
+// MonitorPricePrivate represents a monitor for manually valued private securities
+type MonitorPricePrivate struct {
+	securitiesBySymbol       map[string]c.ConfigPrivateSecurity
+	symbols                  []string
+	currenciesRequested      map[string]bool           // Currencies for which currency rates have already been requested
+	assetQuotesCache         []*c.AssetQuote           // Asset quotes for all assets retrieved at start or on symbol change
+	currencyRatesCache       map[string]c.CurrencyRate // Cache of currency rates
+	mu                       sync.RWMutex
+	muCurrencyRates          sync.RWMutex
+	ctx                      context.Context
+	cancel                   context.CancelFunc
+	isStarted                bool
+	chanRequestCurrencyRates chan []string
+}
+
+// Config contains the required configuration for the private security monitor
+type Config struct {
+	Ctx                      context.Context
+	Securities               []c.ConfigPrivateSecurity
+	ChanRequestCurrencyRates chan []string
+}
+
+// NewMonitorPricePrivate creates a new monitor for private securities
+func NewMonitorPricePrivate(config Config) *MonitorPricePrivate {
+	ctx, cancel := context.WithCancel(config.Ctx)
+
+	securitiesBySymbol := make(map[string]c.ConfigPrivateSecurity)
+	for _, security := range config.Securities {
+		securitiesBySymbol[strings.ToUpper(security.Symbol)] = security
+	}
+
+	return &MonitorPricePrivate{
+		securitiesBySymbol:       securitiesBySymbol,
+		assetQuotesCache:         make([]*c.AssetQuote, 0),
+		currenciesRequested:      make(map[string]bool),
+		ctx:                      ctx,
+		cancel:                   cancel,
+		chanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+	}
+}
+
+// GetAssetQuotes returns the asset quotes for the current symbols
+func (m *MonitorPricePrivate) GetAssetQuotes(ignoreCache ...bool) ([]c.AssetQuote, error) {
+
+	// Valuations only change when the configuration changes so there is no remote source to refresh from
+	if len(ignoreCache) > 0 && ignoreCache[0] {
+		m.replaceCache()
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]c.AssetQuote, len(m.assetQuotesCache))
+	for i, quote := range m.assetQuotesCache {
+		result[i] = *quote
+	}
+
+	return result, nil
+}
+
+// SetSymbols sets the symbols to monitor
+func (m *MonitorPricePrivate) SetSymbols(symbols []string, _ int) error {
+
+	m.mu.Lock()
+
+	// Deduplicate symbols since input may have duplicates
+	slices.Sort(symbols)
+	m.symbols = slices.Compact(symbols)
+
+	m.mu.Unlock()
+
+	assetQuotes := m.replaceCache()
+
+	m.requestCurrencyRates(assetQuotes)
+
+	return nil
+}
+
+// Start the monitor
+func (m *MonitorPricePrivate) Start() error {
+
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	m.replaceCache()
+
+	m.isStarted = true
+
+	return nil
+}
+
+// Stop the monitor
+func (m *MonitorPricePrivate) Stop() error {
+
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.cancel()
+
+	return nil
+}
+
+// SetCurrencyRates sets the currency rates and applies them to the cached asset quotes
+func (m *MonitorPricePrivate) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.muCurrencyRates.Lock()
+	m.currencyRatesCache = currencyRates
+	m.muCurrencyRates.Unlock()
+
+	m.replaceCache()
+
+	return nil
+}
+
+// replaceCache builds asset quotes from the configured valuations, adds currency rates, and replaces the asset quotes cache
+func (m *MonitorPricePrivate) replaceCache() []*c.AssetQuote {
+
+	cache := make([]*c.AssetQuote, 0)
+
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
+	m.muCurrencyRates.RLock()
+	for _, symbol := range symbols {
+
+		security, exists := m.securitiesBySymbol[symbol]
+		if !exists {
+			continue
+		}
+
+		quote := transformSecurity(security, symbol)
+
+		if currencyRate, exists := m.currencyRatesCache[quote.Currency.FromCurrencyCode]; exists {
+			quote.Currency.Rate = currencyRate.Rate
+			quote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		cache = append(cache, &quote)
+	}
+	m.muCurrencyRates.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assetQuotesCache = cache
+
+	return m.assetQuotesCache
+}
+
+func (m *MonitorPricePrivate) requestCurrencyRates(assetQuotes []*c.AssetQuote) {
+
+	fromCurrenciesToRequest := make([]string, 0)
+
+	m.muCurrencyRates.Lock()
+	for _, quote := range assetQuotes {
+		if !m.currenciesRequested[quote.Currency.FromCurrencyCode] {
+			m.currenciesRequested[quote.Currency.FromCurrencyCode] = true
+			fromCurrenciesToRequest = append(fromCurrenciesToRequest, quote.Currency.FromCurrencyCode)
+		}
+	}
+	m.muCurrencyRates.Unlock()
+
+	if len(fromCurrenciesToRequest) == 0 {
+		return
+	}
+
+	m.chanRequestCurrencyRates <- fromCurrenciesToRequest
+}
+
+// transformSecurity converts a private security into an asset quote using the latest valuation as the price and the valuation before it as the previous close
+func transformSecurity(security c.ConfigPrivateSecurity, symbol string) c.AssetQuote {
+
+	valuations := slices.Clone(security.Valuations)
+	sort.SliceStable(valuations, func(i, j int) bool {
+		return valuations[i].Date < valuations[j].Date
+	})
+
+	var price, pricePrevClose, change, changePercent float64
+	var valuationDateText string
+
+	if len(valuations) > 0 {
+		latest := valuations[len(valuations)-1]
+		price = latest.Price
+
+		if date, err := time.Parse(valuationDateLayout, latest.Date); err == nil {
+			valuationDateText = "Valued " + date.Format("Jan 2 2006")
+		}
+	}
+
+	if len(valuations) > 1 {
+		pricePrevClose = valuations[len(valuations)-2].Price
+		change = price - pricePrevClose
+
+		if pricePrevClose != 0 {
+			changePercent = (change / pricePrevClose) * 100
+		}
+	}
+
+	name := security.Name
+	if name == "" {
+		name = symbol
+	}
+
+	currency := strings.ToUpper(security.Currency)
+	if currency == "" {
+		currency = defaultCurrencyCode
+	}
+
+	return c.AssetQuote{
+		Name:   name,
+		Symbol: symbol,
+		Class:  c.AssetClassPrivateSecurity,
+		Currency: c.Currency{
+			FromCurrencyCode: currency,
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          price,
+			PricePrevClose: pricePrevClose,
+			PriceOpen:      pricePrevClose,
+			Change:         change,
+			ChangePercent:  changePercent,
+		},
+		QuoteSource: c.QuoteSourceManual,
+		Exchange: c.Exchange{
+			Name:                    "Private",
+			DelayText:               valuationDateText,
+			State:                   c.ExchangeStateClosed,
+			IsActive:                false,
+			IsRegularTradingSession: true,
+		},
+		Meta: c.Meta{
+			SymbolInSourceAPI: symbol,
+		},
+	}
+}
@@ -0,0 +1,13 @@
+package monitorPricePrivate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrivate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Private Suite")
+}
@@ -0,0 +1,146 @@
+package monitorPricePrivate_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPricePrivate "github.com/achannarasappa/ticker/v5/internal/monitor/private/monitor-price"
+)
+
+var _ = Describe("Monitor Private", func() {
+	var (
+		securities               []c.ConfigPrivateSecurity
+		chanRequestCurrencyRates chan []string
+	)
+
+	BeforeEach(func() {
+		chanRequestCurrencyRates = make(chan []string, 1)
+		securities = []c.ConfigPrivateSecurity{
+			{
+				Symbol:   "acme",
+				Name:     "Acme Corp Series A",
+				Currency: "eur",
+				Valuations: []c.ConfigPrivateSecurityValuation{
+					{Date: "2025-06-30", Price: 12.0},
+					{Date: "2024-12-31", Price: 10.0},
+				},
+			},
+			{
+				Symbol: "HOUSE",
+				Valuations: []c.ConfigPrivateSecurityValuation{
+					{Date: "2025-01-01", Price: 500000.0},
+				},
+			},
+		}
+	})
+
+	Describe("SetSymbols", func() {
+		It("should build asset quotes from the latest valuation with the change from the previous valuation", func() {
+			monitor := monitorPricePrivate.NewMonitorPricePrivate(monitorPricePrivate.Config{
+				Ctx:                      context.Background(),
+				Securities:               securities,
+				ChanRequestCurrencyRates: chanRequestCurrencyRates,
+			})
+
+			err := monitor.SetSymbols([]string{"ACME"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(1))
+			Expect(assetQuotes[0].Name).To(Equal("Acme Corp Series A"))
+			Expect(assetQuotes[0].Symbol).To(Equal("ACME"))
+			Expect(assetQuotes[0].Class).To(Equal(c.AssetClassPrivateSecurity))
+			Expect(assetQuotes[0].QuoteSource).To(Equal(c.QuoteSourceManual))
+			Expect(assetQuotes[0].Currency.FromCurrencyCode).To(Equal("EUR"))
+			Expect(assetQuotes[0].QuotePrice.Price).To(Equal(12.0))
+			Expect(assetQuotes[0].QuotePrice.PricePrevClose).To(Equal(10.0))
+			Expect(assetQuotes[0].QuotePrice.Change).To(Equal(2.0))
+			Expect(assetQuotes[0].QuotePrice.ChangePercent).To(Equal(20.0))
+			Expect(assetQuotes[0].Exchange.DelayText).To(Equal("Valued Jun 30 2025"))
+			Expect(chanRequestCurrencyRates).To(Receive(Equal([]string{"EUR"})))
+		})
+
+		When("there is only one valuation", func() {
+			It("should not have a change", func() {
+				monitor := monitorPricePrivate.NewMonitorPricePrivate(monitorPricePrivate.Config{
+					Ctx:                      context.Background(),
+					Securities:               securities,
+					ChanRequestCurrencyRates: chanRequestCurrencyRates,
+				})
+
+				monitor.SetSymbols([]string{"HOUSE"}, 0)
+
+				assetQuotes, _ := monitor.GetAssetQuotes()
+				Expect(assetQuotes).To(HaveLen(1))
+				Expect(assetQuotes[0].Name).To(Equal("HOUSE"))
+				Expect(assetQuotes[0].Currency.FromCurrencyCode).To(Equal("USD"))
+				Expect(assetQuotes[0].QuotePrice.Price).To(Equal(500000.0))
+				Expect(assetQuotes[0].QuotePrice.Change).To(BeZero())
+			})
+		})
+
+		When("a symbol is not a private security", func() {
+			It("should not return a quote for the symbol", func() {
+				monitor := monitorPricePrivate.NewMonitorPricePrivate(monitorPricePrivate.Config{
+					Ctx:                      context.Background(),
+					Securities:               securities,
+					ChanRequestCurrencyRates: chanRequestCurrencyRates,
+				})
+
+				monitor.SetSymbols([]string{"AAPL"}, 0)
+
+				assetQuotes, _ := monitor.GetAssetQuotes()
+				Expect(assetQuotes).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should apply the currency rates to the asset quotes", func() {
+			monitor := monitorPricePrivate.NewMonitorPricePrivate(monitorPricePrivate.Config{
+				Ctx:                      context.Background(),
+				Securities:               securities,
+				ChanRequestCurrencyRates: chanRequestCurrencyRates,
+			})
+			monitor.SetSymbols([]string{"ACME"}, 0)
+
+			err := monitor.SetCurrencyRates(c.CurrencyRates{
+				"EUR": {FromCurrency: "EUR", ToCurrency: "USD", Rate: 1.1},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes[0].Currency.Rate).To(Equal(1.1))
+			Expect(assetQuotes[0].Currency.ToCurrencyCode).To(Equal("USD"))
+		})
+	})
+
+	Describe("Start", func() {
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				monitor := monitorPricePrivate.NewMonitorPricePrivate(monitorPricePrivate.Config{
+					Ctx: context.Background(),
+				})
+
+				Expect(monitor.Start()).To(Succeed())
+				Expect(monitor.Start()).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				monitor := monitorPricePrivate.NewMonitorPricePrivate(monitorPricePrivate.Config{
+					Ctx: context.Background(),
+				})
+
+				Expect(monitor.Stop()).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
@@ -0,0 +1,299 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceCash "github.com/achannarasappa/ticker/v5/internal/monitor/cash/monitor-price"
+	monitorPriceCoinbase "github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/monitor-price"
+	monitorPriceCoinCap "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price"
+	monitorPriceCoingecko "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/monitor-price"
+	monitorPricePrivate "github.com/achannarasappa/ticker/v5/internal/monitor/private/monitor-price"
+	monitorPriceUserDefined "github.com/achannarasappa/ticker/v5/internal/monitor/userdefined/monitor-price"
+	monitorPriceYahoo "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-price"
+	unaryClientYahoo "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
+)
+
+// Source represents a quote source which can be registered with the monitor
+type Source struct {
+	// QuoteSource is the identifier of the source
+	QuoteSource c.QuoteSource
+	// MatchSymbol returns the symbol in the format expected by the source API and whether a ticker symbol should be routed to the source
+	MatchSymbol func(symbol string) (string, bool)
+	// NewMonitor creates the API specific monitor for the source
+	NewMonitor func(config ConfigSource) (c.Monitor, error)
+}
+
+// ConfigSource represents the configuration passed to each source when its monitor is created
+type ConfigSource struct {
+	Ctx                      context.Context
+	ConfigMonitor            ConfigMonitor
+	UnaryAPIYahoo            *unaryClientYahoo.UnaryAPI // Yahoo API client shared with the currency rate monitor
+	ChanError                chan error
+	ChanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
+	ChanRequestCurrencyRates chan []string
+	ChanUpdateStreamStatus   chan c.StreamStatusUpdate
+}
+
+// Registry represents the set of quote sources available to the monitor
+type Registry struct {
+	sources  []Source
+	fallback Source
+	mu       sync.RWMutex
+}
+
+//nolint:gochecknoglobals
+var defaultRegistry = NewRegistry(sourceYahoo(), sourceCoinbase(), sourceCoingecko(), sourceCoinCap(), sourceUserDefined(), sourcePrivate(), sourceCash())
+
+// NewRegistry creates a registry with a fallback source which receives any symbol not matched by another source
+func NewRegistry(fallback Source, sources ...Source) *Registry {
+
+	r := &Registry{
+		sources:  make([]Source, 0, len(sources)),
+		fallback: fallback,
+	}
+
+	for _, source := range sources {
+		r.Register(source) //nolint:errcheck
+	}
+
+	return r
+}
+
+// DefaultRegistry returns the registry containing the built-in quote sources
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a quote source to the registry
+func (r *Registry) Register(source Source) error {
+
+	if source.NewMonitor == nil || source.MatchSymbol == nil {
+		return errors.New("source must define NewMonitor and MatchSymbol")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if source.QuoteSource == r.fallback.QuoteSource {
+		return fmt.Errorf("source %d is already registered", source.QuoteSource)
+	}
+
+	for _, existing := range r.sources {
+		if existing.QuoteSource == source.QuoteSource {
+			return fmt.Errorf("source %d is already registered", source.QuoteSource)
+		}
+	}
+
+	r.sources = append(r.sources, source)
+
+	return nil
+}
+
+// Sources returns all registered sources including the fallback source
+func (r *Registry) Sources() []Source {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sources := make([]Source, 0, len(r.sources)+1)
+	sources = append(sources, r.fallback)
+	sources = append(sources, r.sources...)
+
+	return sources
+}
+
+// MatchSymbol returns the source and source specific symbol for a ticker symbol, using the fallback source when no other source matches
+func (r *Registry) MatchSymbol(symbol string) (c.QuoteSource, string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, source := range r.sources {
+		if sourceSymbol, ok := source.MatchSymbol(symbol); ok {
+			return source.QuoteSource, sourceSymbol
+		}
+	}
+
+	sourceSymbol, _ := r.fallback.MatchSymbol(symbol)
+
+	return r.fallback.QuoteSource, sourceSymbol
+}
+
+func sourceYahoo() Source {
+	return Source{
+		QuoteSource: c.QuoteSourceYahoo,
+		MatchSymbol: func(symbol string) (string, bool) {
+			return strings.ToUpper(symbol), true
+		},
+		NewMonitor: func(config ConfigSource) (c.Monitor, error) {
+			return monitorPriceYahoo.NewMonitorPriceYahoo(
+				monitorPriceYahoo.Config{
+					Ctx:                      config.Ctx,
+					UnaryAPI:                 config.UnaryAPIYahoo,
+					ChanError:                config.ChanError,
+					ChanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+					ChanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+				},
+				monitorPriceYahoo.WithRefreshInterval(time.Duration(config.ConfigMonitor.RefreshInterval)*time.Second),
+			), nil
+		},
+	}
+}
+
+func sourceCoinbase() Source {
+	return Source{
+		QuoteSource: c.QuoteSourceCoinbase,
+		MatchSymbol: func(symbol string) (string, bool) {
+
+			symbol = strings.ToUpper(symbol)
+
+			if !strings.HasSuffix(symbol, ".CB") {
+				return "", false
+			}
+
+			symbol = strings.TrimSuffix(symbol, ".CB")
+
+			// Futures contracts on Coinbase Derivatives Exchange are implicitly USD-denominated
+			if strings.HasSuffix(symbol, "-CDE") {
+				return symbol, true
+			}
+
+			return symbol + "-USD", true
+		},
+		NewMonitor: func(config ConfigSource) (c.Monitor, error) {
+			return monitorPriceCoinbase.NewMonitorPriceCoinbase(
+				monitorPriceCoinbase.Config{
+					Ctx:                      config.Ctx,
+					UnaryURL:                 config.ConfigMonitor.ConfigMonitorPriceCoinbase.BaseURL,
+					ChanError:                config.ChanError,
+					ChanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+					ChanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+					ChanUpdateStreamStatus:   config.ChanUpdateStreamStatus,
+				},
+				monitorPriceCoinbase.WithStreamingURL(config.ConfigMonitor.ConfigMonitorPriceCoinbase.StreamingURL),
+				monitorPriceCoinbase.WithRefreshInterval(time.Duration(config.ConfigMonitor.RefreshInterval)*time.Second),
+			), nil
+		},
+	}
+}
+
+func sourceCoingecko() Source {
+	return Source{
+		QuoteSource: c.QuoteSourceCoingecko,
+		MatchSymbol: func(symbol string) (string, bool) {
+
+			if !strings.HasSuffix(strings.ToUpper(symbol), ".CG") {
+				return "", false
+			}
+
+			// CoinGecko coin ids are lowercase (e.g. "shiba-inu")
+			return strings.ToLower(symbol[:len(symbol)-len(".CG")]), true
+		},
+		NewMonitor: func(config ConfigSource) (c.Monitor, error) {
+			return monitorPriceCoingecko.NewMonitorPriceCoingecko(
+				monitorPriceCoingecko.Config{
+					Ctx:                      config.Ctx,
+					UnaryURL:                 config.ConfigMonitor.ConfigMonitorPriceCoingecko.BaseURL,
+					ChanError:                config.ChanError,
+					ChanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+					ChanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+				},
+				monitorPriceCoingecko.WithRefreshInterval(time.Duration(config.ConfigMonitor.RefreshInterval)*time.Second),
+			), nil
+		},
+	}
+}
+
+func sourceCoinCap() Source {
+	return Source{
+		QuoteSource: c.QuoteSourceCoinCap,
+		MatchSymbol: func(symbol string) (string, bool) {
+
+			if !strings.HasSuffix(strings.ToUpper(symbol), ".CC") {
+				return "", false
+			}
+
+			// CoinCap asset ids are lowercase (e.g. "bitcoin")
+			return strings.ToLower(symbol[:len(symbol)-len(".CC")]), true
+		},
+		NewMonitor: func(config ConfigSource) (c.Monitor, error) {
+			return monitorPriceCoinCap.NewMonitorPriceCoinCap(
+				monitorPriceCoinCap.Config{
+					Ctx:                      config.Ctx,
+					UnaryURL:                 config.ConfigMonitor.ConfigMonitorPriceCoinCap.BaseURL,
+					ChanError:                config.ChanError,
+					ChanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+					ChanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+					ChanUpdateStreamStatus:   config.ChanUpdateStreamStatus,
+				},
+				monitorPriceCoinCap.WithStreamingURL(config.ConfigMonitor.ConfigMonitorPriceCoinCap.StreamingURL),
+				monitorPriceCoinCap.WithRefreshInterval(time.Duration(config.ConfigMonitor.RefreshInterval)*time.Second),
+			), nil
+		},
+	}
+}
+
+func sourceUserDefined() Source {
+	return Source{
+		QuoteSource: c.QuoteSourceUserDefined,
+		// Symbols are routed to user defined sources based on the symbols listed in each source's configuration rather than by pattern
+		MatchSymbol: func(_ string) (string, bool) {
+			return "", false
+		},
+		NewMonitor: func(config ConfigSource) (c.Monitor, error) {
+			return monitorPriceUserDefined.NewMonitorPriceUserDefined(
+				monitorPriceUserDefined.Config{
+					Ctx:                      config.Ctx,
+					Sources:                  config.ConfigMonitor.ConfigMonitorUserDefined.Sources,
+					ChanError:                config.ChanError,
+					ChanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+					ChanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+				},
+				monitorPriceUserDefined.WithRefreshInterval(time.Duration(config.ConfigMonitor.RefreshInterval)*time.Second),
+			), nil
+		},
+	}
+}
+
+func sourcePrivate() Source {
+	return Source{
+		QuoteSource: c.QuoteSourceManual,
+		// Symbols are routed to private securities based on the symbols in the private securities configuration
+		MatchSymbol: func(_ string) (string, bool) {
+			return "", false
+		},
+		NewMonitor: func(config ConfigSource) (c.Monitor, error) {
+			return monitorPricePrivate.NewMonitorPricePrivate(
+				monitorPricePrivate.Config{
+					Ctx:                      config.Ctx,
+					Securities:               config.ConfigMonitor.ConfigMonitorPrivate.Securities,
+					ChanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+				},
+			), nil
+		},
+	}
+}
+
+func sourceCash() Source {
+	return Source{
+		QuoteSource: c.QuoteSourceCash,
+		MatchSymbol: func(symbol string) (string, bool) {
+
+			symbol = strings.ToUpper(symbol)
+
+			return symbol, strings.HasSuffix(symbol, monitorPriceCash.SymbolSuffix)
+		},
+		NewMonitor: func(config ConfigSource) (c.Monitor, error) {
+			return monitorPriceCash.NewMonitorPriceCash(
+				monitorPriceCash.Config{
+					Ctx:                      config.Ctx,
+					ChanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+				},
+			), nil
+		},
+	}
+}
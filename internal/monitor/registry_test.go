@@ -0,0 +1,216 @@
+package monitor_test
+
+import (
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor"
+)
+
+type monitorStub struct {
+	symbols []string
+	quotes  []c.AssetQuote
+}
+
+func (m *monitorStub) Start() error { return nil }
+func (m *monitorStub) GetAssetQuotes(_ ...bool) ([]c.AssetQuote, error) {
+	return m.quotes, nil
+}
+func (m *monitorStub) SetSymbols(symbols []string, _ int) error {
+	m.symbols = symbols
+
+	return nil
+}
+func (m *monitorStub) SetCurrencyRates(_ c.CurrencyRates) error { return nil }
+func (m *monitorStub) Stop() error                              { return nil }
+
+type monitorPriceHistoryStub struct {
+	monitorStub
+	priceHistory c.PriceHistory
+	symbol       string
+}
+
+func (m *monitorPriceHistoryStub) GetPriceHistory(symbol string, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error) {
+	m.symbol = symbol
+
+	return m.priceHistory, nil
+}
+
+type recorderStub struct {
+	symbols []string
+	block   chan struct{} // Optional channel which each record waits on to simulate slow disk writes
+	mu      sync.Mutex
+}
+
+func (r *recorderStub) RecordAssetQuote(assetQuote c.AssetQuote) error {
+	if r.block != nil {
+		<-r.block
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.symbols = append(r.symbols, assetQuote.Symbol)
+
+	return nil
+}
+
+func (r *recorderStub) getSymbols() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string{}, r.symbols...)
+}
+
+type alerterStub struct {
+	priceAbove float64
+}
+
+func (a *alerterStub) Evaluate(assetQuote c.AssetQuote) []c.Alert {
+	if assetQuote.QuotePrice.Price < a.priceAbove {
+		return []c.Alert{}
+	}
+
+	return []c.Alert{{Symbol: assetQuote.Symbol, Type: c.AlertTypePriceAbove, Price: assetQuote.QuotePrice.Price}}
+}
+
+func newSourceStub(quoteSource c.QuoteSource, suffix string, stub c.Monitor) monitor.Source {
+	return monitor.Source{
+		QuoteSource: quoteSource,
+		MatchSymbol: func(symbol string) (string, bool) {
+			if len(symbol) > len(suffix) && symbol[len(symbol)-len(suffix):] == suffix {
+				return symbol[:len(symbol)-len(suffix)], true
+			}
+
+			return "", false
+		},
+		NewMonitor: func(_ monitor.ConfigSource) (c.Monitor, error) {
+			return stub, nil
+		},
+	}
+}
+
+var _ = Describe("Registry", func() {
+
+	Describe("MatchSymbol", func() {
+
+		It("should route a symbol to the first matching source", func() {
+			registry := monitor.NewRegistry(
+				newSourceStub(c.QuoteSourceYahoo, "", &monitorStub{}),
+				newSourceStub(c.QuoteSourceCoingecko, ".CG", &monitorStub{}),
+			)
+
+			source, symbol := registry.MatchSymbol("PEPE.CG")
+
+			Expect(source).To(Equal(c.QuoteSourceCoingecko))
+			Expect(symbol).To(Equal("PEPE"))
+		})
+
+		When("no source matches the symbol", func() {
+			It("should route the symbol to the fallback source", func() {
+				source, symbol := monitor.DefaultRegistry().MatchSymbol("aapl")
+
+				Expect(source).To(Equal(c.QuoteSourceYahoo))
+				Expect(symbol).To(Equal("AAPL"))
+			})
+		})
+
+		DescribeTable("built-in sources",
+			func(input string, expectedSource c.QuoteSource, expectedSymbol string) {
+				source, symbol := monitor.DefaultRegistry().MatchSymbol(input)
+
+				Expect(source).To(Equal(expectedSource))
+				Expect(symbol).To(Equal(expectedSymbol))
+			},
+			Entry("coinbase spot", "btc.cb", c.QuoteSourceCoinbase, "BTC-USD"),
+			Entry("coinbase futures", "BIT-31JAN25-CDE.CB", c.QuoteSourceCoinbase, "BIT-31JAN25-CDE"),
+			Entry("coingecko", "Shiba-Inu.CG", c.QuoteSourceCoingecko, "shiba-inu"),
+			Entry("coincap", "BITCOIN.CC", c.QuoteSourceCoinCap, "bitcoin"),
+			Entry("cash", "eur.cash", c.QuoteSourceCash, "EUR.CASH"),
+			Entry("yahoo", "TSLA", c.QuoteSourceYahoo, "TSLA"),
+		)
+
+	})
+
+	Describe("Register", func() {
+
+		It("should add the source to the registry", func() {
+			registry := monitor.NewRegistry(newSourceStub(c.QuoteSourceYahoo, "", &monitorStub{}))
+
+			err := registry.Register(newSourceStub(c.QuoteSourceCoinCap, ".CC", &monitorStub{}))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.Sources()).To(HaveLen(2))
+		})
+
+		When("the source is already registered", func() {
+			It("should return an error", func() {
+				registry := monitor.NewRegistry(newSourceStub(c.QuoteSourceYahoo, "", &monitorStub{}))
+
+				err := registry.Register(newSourceStub(c.QuoteSourceYahoo, ".Y", &monitorStub{}))
+
+				Expect(err).To(MatchError(ContainSubstring("already registered")))
+			})
+		})
+
+		When("the source does not define a constructor", func() {
+			It("should return an error", func() {
+				registry := monitor.NewRegistry(newSourceStub(c.QuoteSourceYahoo, "", &monitorStub{}))
+
+				err := registry.Register(monitor.Source{QuoteSource: c.QuoteSourceCoinCap})
+
+				Expect(err).To(MatchError("source must define NewMonitor and MatchSymbol"))
+			})
+		})
+
+	})
+
+	When("a registry is set on the monitor configuration", func() {
+
+		It("should create a monitor for each registered source", func() {
+			stub := &monitorStub{
+				quotes: []c.AssetQuote{{Symbol: "PEPE.CG"}},
+			}
+
+			m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+				RefreshInterval: 1,
+				Registry: monitor.NewRegistry(
+					newSourceStub(c.QuoteSourceCoingecko, ".CG", stub),
+				),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.SetAssetGroup(c.AssetGroup{
+				SymbolsBySource: []c.AssetGroupSymbolsBySource{
+					{Source: c.QuoteSourceCoingecko, Symbols: []string{"PEPE"}},
+					{Source: c.QuoteSourceYahoo, Symbols: []string{"AAPL"}},
+				},
+			}, 0)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stub.symbols).To(Equal([]string{"PEPE"}))
+			Expect(m.GetAssetGroupQuote().AssetQuotes).To(Equal(stub.quotes))
+		})
+
+		When("a source fails to create its monitor", func() {
+			It("should return an error", func() {
+				source := newSourceStub(c.QuoteSourceCoingecko, ".CG", &monitorStub{})
+				source.NewMonitor = func(_ monitor.ConfigSource) (c.Monitor, error) {
+					return nil, errors.New("bad config")
+				}
+
+				_, err := monitor.NewMonitor(monitor.ConfigMonitor{
+					Registry: monitor.NewRegistry(source),
+				})
+
+				Expect(err).To(MatchError(ContainSubstring("bad config")))
+			})
+		})
+
+	})
+
+})
@@ -0,0 +1,300 @@
+package monitorPriceUserDefined
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/userdefined/monitor-price/poller"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/userdefined/unary"
+)
+
+const (
+	defaultCurrencyCode = "USD"
+)
+
+// MonitorPriceUserDefined represents a monitor for user defined HTTP/JSON quote sources
+type MonitorPriceUserDefined struct {
+	unaryAPI                 *unary.UnaryAPI
+	poller                   *poller.Poller
+	symbols                  []string
+	currenciesRequested      map[string]bool           // Currencies for which currency rates have already been requested
+	assetQuotesCache         []*c.AssetQuote           // Asset quotes for all assets retrieved at start or on symbol change
+	assetQuotesCacheLookup   map[string]*c.AssetQuote  // Asset quotes for all assets retrieved at least once (symbol change does not remove symbols)
+	currencyRatesCache       map[string]c.CurrencyRate // Cache of currency rates
+	chanPollUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+	chanError                chan error
+	mu                       sync.RWMutex
+	muCurrencyRates          sync.RWMutex
+	ctx                      context.Context
+	cancel                   context.CancelFunc
+	isStarted                bool
+	chanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
+	chanRequestCurrencyRates chan []string
+}
+
+// Config contains the required configuration for the user defined source monitor
+type Config struct {
+	Ctx                      context.Context
+	Sources                  []c.ConfigSourceUserDefined
+	ChanError                chan error
+	ChanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
+	ChanRequestCurrencyRates chan []string
+}
+
+// Option defines an option for configuring the monitor
+type Option func(*MonitorPriceUserDefined)
+
+// NewMonitorPriceUserDefined creates a new monitor for user defined sources
+func NewMonitorPriceUserDefined(config Config, opts ...Option) *MonitorPriceUserDefined {
+	ctx, cancel := context.WithCancel(config.Ctx)
+
+	unaryAPI := unary.NewUnaryAPI(config.Sources)
+
+	monitor := &MonitorPriceUserDefined{
+		assetQuotesCacheLookup:   make(map[string]*c.AssetQuote),
+		assetQuotesCache:         make([]*c.AssetQuote, 0),
+		currenciesRequested:      make(map[string]bool),
+		chanPollUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote]),
+		chanError:                config.ChanError,
+		unaryAPI:                 unaryAPI,
+		ctx:                      ctx,
+		cancel:                   cancel,
+		chanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+		chanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+	}
+
+	pollerConfig := poller.PollerConfig{
+		ChanUpdateAssetQuote: monitor.chanPollUpdateAssetQuote,
+		ChanError:            monitor.chanError,
+		UnaryAPI:             unaryAPI,
+	}
+	monitor.poller = poller.NewPoller(ctx, pollerConfig)
+
+	for _, opt := range opts {
+		opt(monitor)
+	}
+
+	return monitor
+}
+
+// WithRefreshInterval sets the refresh interval for the monitor
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(m *MonitorPriceUserDefined) {
+		// TODO: handle error
+		m.poller.SetRefreshInterval(interval) //nolint:errcheck
+	}
+}
+
+// GetAssetQuotes returns the asset quotes either from the cache or from the user defined sources if ignoreCache is set
+func (m *MonitorPriceUserDefined) GetAssetQuotes(ignoreCache ...bool) ([]c.AssetQuote, error) {
+
+	if len(ignoreCache) > 0 && ignoreCache[0] {
+		assetQuotes, err := m.getAssetQuotesAndReplaceCache()
+		if err != nil {
+			return []c.AssetQuote{}, err
+		}
+		result := make([]c.AssetQuote, len(assetQuotes))
+		for i, quote := range assetQuotes {
+			result[i] = *quote
+		}
+
+		return result, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]c.AssetQuote, len(m.assetQuotesCache))
+	for i, quote := range m.assetQuotesCache {
+		result[i] = *quote
+	}
+
+	return result, nil
+}
+
+// SetSymbols sets the symbols to monitor
+func (m *MonitorPriceUserDefined) SetSymbols(symbols []string, versionVector int) error {
+
+	m.mu.Lock()
+
+	// Deduplicate symbols since input may have duplicates
+	slices.Sort(symbols)
+	m.symbols = slices.Compact(symbols)
+
+	m.mu.Unlock()
+
+	// Since the symbols have changed, make a synchronous call to get price quotes for the new symbols
+	// Symbols which failed are still polled so that they recover once the source is available again
+	assetQuotes, err := m.getAssetQuotesAndReplaceCache()
+
+	// Request currency rates for any currency not yet seen since the currency is only known once a quote is retrieved
+	m.requestCurrencyRates(assetQuotes)
+
+	m.poller.SetSymbols(m.symbols, versionVector)
+
+	return err
+}
+
+// Start the monitor
+func (m *MonitorPriceUserDefined) Start() error {
+
+	var err error
+
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	// On start, get initial quotes from the user defined sources
+	_, err = m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	err = m.poller.Start()
+	if err != nil {
+		return err
+	}
+
+	go m.handleUpdates()
+
+	m.isStarted = true
+
+	return nil
+}
+
+// Stop the monitor
+func (m *MonitorPriceUserDefined) Stop() error {
+
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.cancel()
+
+	return nil
+}
+
+// SetCurrencyRates sets the currency rates and applies them to the cached asset quotes
+func (m *MonitorPriceUserDefined) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.muCurrencyRates.Lock()
+	m.currencyRatesCache = currencyRates
+	m.muCurrencyRates.Unlock()
+
+	// TODO: make this more efficient by selectively updating based on changes in rates
+	_, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleUpdates listens for asset quote change messages and updates the cache
+func (m *MonitorPriceUserDefined) handleUpdates() {
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+
+		case updateMessage := <-m.chanPollUpdateAssetQuote:
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if nothing has changed
+			if assetQuote.QuotePrice == updateMessage.Data.QuotePrice &&
+				assetQuote.QuoteExtended == updateMessage.Data.QuoteExtended {
+
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			m.mu.Lock()
+
+			// Update properties on the asset quote which may have changed while keeping the currency rate
+			assetQuote.QuotePrice = updateMessage.Data.QuotePrice
+			assetQuote.QuoteExtended = updateMessage.Data.QuoteExtended
+
+			m.mu.Unlock()
+
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+		}
+	}
+}
+
+// Get asset quotes from the user defined sources, add currency rates, and replace the asset quotes cache
+func (m *MonitorPriceUserDefined) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote, error) {
+
+	lookup := make(map[string]*c.AssetQuote)
+	cache := make([]*c.AssetQuote, 0)
+
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
+	// Quotes for symbols which resolved are cached even if other symbols failed
+	assetQuotes, _, err := m.unaryAPI.GetAssetQuotes(symbols)
+
+	m.muCurrencyRates.RLock()
+	for _, quote := range assetQuotes {
+
+		if quote.Currency.FromCurrencyCode == "" {
+			quote.Currency.FromCurrencyCode = defaultCurrencyCode
+		}
+
+		if currencyRate, exists := m.currencyRatesCache[quote.Currency.FromCurrencyCode]; exists {
+			quote.Currency.Rate = currencyRate.Rate
+			quote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		lookup[quote.Meta.SymbolInSourceAPI] = &quote
+		cache = append(cache, &quote)
+	}
+	m.muCurrencyRates.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assetQuotesCache = cache
+	m.assetQuotesCacheLookup = lookup
+
+	return m.assetQuotesCache, err
+}
+
+func (m *MonitorPriceUserDefined) requestCurrencyRates(assetQuotes []*c.AssetQuote) {
+
+	fromCurrenciesToRequest := make([]string, 0)
+
+	m.muCurrencyRates.Lock()
+	for _, quote := range assetQuotes {
+		if !m.currenciesRequested[quote.Currency.FromCurrencyCode] {
+			m.currenciesRequested[quote.Currency.FromCurrencyCode] = true
+			fromCurrenciesToRequest = append(fromCurrenciesToRequest, quote.Currency.FromCurrencyCode)
+		}
+	}
+	m.muCurrencyRates.Unlock()
+
+	if len(fromCurrenciesToRequest) == 0 {
+		return
+	}
+
+	m.chanRequestCurrencyRates <- fromCurrenciesToRequest
+}
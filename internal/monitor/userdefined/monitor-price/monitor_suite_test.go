@@ -0,0 +1,13 @@
+package monitorPriceUserDefined_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUserDefined(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "User Defined Suite")
+}
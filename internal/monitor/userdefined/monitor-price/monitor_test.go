@@ -0,0 +1,136 @@
+package monitorPriceUserDefined_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceUserDefined "github.com/achannarasappa/ticker/v5/internal/monitor/userdefined/monitor-price"
+)
+
+var _ = Describe("Monitor User Defined", func() {
+	var (
+		server  *ghttp.Server
+		sources []c.ConfigSourceUserDefined
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		sources = []c.ConfigSourceUserDefined{
+			{
+				Name:     "my-broker",
+				URL:      server.URL() + "/quote/{symbol}",
+				Symbols:  []string{"FUND1"},
+				Currency: "EUR",
+				Fields: c.ConfigSourceUserDefinedFieldMapping{
+					Price: "price",
+				},
+			},
+		}
+		server.RouteToHandler(http.MethodGet, "/quote/FUND1",
+			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"price": 12.5}),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("SetSymbols", func() {
+		It("should get quotes for the symbols and request currency rates for their currencies", func() {
+			chanRequestCurrencyRates := make(chan []string, 1)
+			monitor := monitorPriceUserDefined.NewMonitorPriceUserDefined(monitorPriceUserDefined.Config{
+				Ctx:                      context.Background(),
+				Sources:                  sources,
+				ChanRequestCurrencyRates: chanRequestCurrencyRates,
+			})
+
+			err := monitor.SetSymbols([]string{"FUND1", "FUND1"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(1))
+			Expect(assetQuotes[0].QuotePrice.Price).To(Equal(12.5))
+			Expect(chanRequestCurrencyRates).To(Receive(Equal([]string{"EUR"})))
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should apply the currency rates to the cached quotes", func() {
+			monitor := monitorPriceUserDefined.NewMonitorPriceUserDefined(monitorPriceUserDefined.Config{
+				Ctx:                      context.Background(),
+				Sources:                  sources,
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			})
+			monitor.SetSymbols([]string{"FUND1"}, 0)
+
+			err := monitor.SetCurrencyRates(c.CurrencyRates{
+				"EUR": {FromCurrency: "EUR", ToCurrency: "USD", Rate: 1.1},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes[0].Currency.Rate).To(Equal(1.1))
+			Expect(assetQuotes[0].Currency.ToCurrencyCode).To(Equal("USD"))
+		})
+	})
+
+	Describe("Start", func() {
+		It("should send an update when a polled price changes", func() {
+			price := 12.5
+			server.RouteToHandler(http.MethodGet, "/quote/FUND1", func(w http.ResponseWriter, _ *http.Request) {
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"price": price})(w, nil)
+				price++
+			})
+
+			chanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+			monitor := monitorPriceUserDefined.NewMonitorPriceUserDefined(monitorPriceUserDefined.Config{
+				Ctx:                      context.Background(),
+				Sources:                  sources,
+				ChanUpdateAssetQuote:     chanUpdateAssetQuote,
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			}, monitorPriceUserDefined.WithRefreshInterval(time.Millisecond*50))
+			monitor.SetSymbols([]string{"FUND1"}, 0)
+
+			err := monitor.Start()
+			Expect(err).NotTo(HaveOccurred())
+			defer monitor.Stop()
+
+			Eventually(chanUpdateAssetQuote).Should(Receive(HaveField("Data.Symbol", "FUND1")))
+		})
+
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceUserDefined.NewMonitorPriceUserDefined(monitorPriceUserDefined.Config{
+					Ctx:                      context.Background(),
+					Sources:                  sources,
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceUserDefined.WithRefreshInterval(time.Second))
+
+				monitor.Start()
+				defer monitor.Stop()
+
+				Expect(monitor.Start()).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceUserDefined.NewMonitorPriceUserDefined(monitorPriceUserDefined.Config{
+					Ctx:     context.Background(),
+					Sources: sources,
+				})
+
+				Expect(monitor.Stop()).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
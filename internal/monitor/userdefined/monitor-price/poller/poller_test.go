@@ -0,0 +1,209 @@
+package poller_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/userdefined/monitor-price/poller"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/userdefined/unary"
+)
+
+var _ = Describe("Poller", func() {
+	var (
+		server                    *ghttp.Server
+		ctx                       context.Context
+		cancel                    context.CancelFunc
+		inputUnaryAPI             *unary.UnaryAPI
+		inputChanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+		inputChanError            chan error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		server.RouteToHandler(http.MethodGet, "/quote/FUND1",
+			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+				"price": 110.0,
+			}),
+		)
+
+		inputChanUpdateAssetQuote = make(chan c.MessageUpdate[c.AssetQuote], 5)
+		inputChanError = make(chan error, 5)
+		ctx, cancel = context.WithCancel(context.Background())
+
+		inputUnaryAPI = unary.NewUnaryAPI([]c.ConfigSourceUserDefined{
+			{
+				Name:    "my-broker",
+				URL:     server.URL() + "/quote/{symbol}",
+				Symbols: []string{"FUND1", "FUND2"},
+				Fields: c.ConfigSourceUserDefinedFieldMapping{
+					Price: "price",
+				},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		cancel()
+		server.Close()
+	})
+
+	Describe("NewPoller", func() {
+		It("should create a new poller instance", func() {
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             inputUnaryAPI,
+				ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+				ChanError:            inputChanError,
+			})
+			Expect(p).NotTo(BeNil())
+		})
+	})
+
+	Describe("Start", func() {
+		It("should start polling for price updates", func() {
+
+			p := poller.NewPoller(ctx, poller.PollerConfig{
+				UnaryAPI:             inputUnaryAPI,
+				ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+				ChanError:            inputChanError,
+			})
+
+			p.SetSymbols([]string{"FUND1"}, 1)
+			p.SetRefreshInterval(time.Millisecond * 100)
+
+			err := p.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(inputChanUpdateAssetQuote).Should(Receive(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"ID":            Equal("FUND1"),
+					"VersionVector": Equal(1),
+					"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(110.0),
+						}),
+					}),
+				}),
+			))
+			Consistently(inputChanError).ShouldNot(Receive())
+
+		})
+
+		When("the request for one of the symbols fails", func() {
+			It("should send price updates for the other symbols and an error", func() {
+
+				server.RouteToHandler(http.MethodGet, "/quote/FUND2", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				p := poller.NewPoller(ctx, poller.PollerConfig{
+					UnaryAPI:             inputUnaryAPI,
+					ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+					ChanError:            inputChanError,
+				})
+
+				p.SetSymbols([]string{"FUND1", "FUND2"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 100)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanUpdateAssetQuote).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID": Equal("FUND1"),
+					}),
+				))
+				Eventually(inputChanError).Should(Receive(MatchError(ContainSubstring("failed to get quote for FUND2"))))
+
+			})
+		})
+
+		When("the poller is already started", func() {
+			It("should return an error", func() {
+
+				p := poller.NewPoller(ctx, poller.PollerConfig{
+					UnaryAPI:             inputUnaryAPI,
+					ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+					ChanError:            inputChanError,
+				})
+
+				p.SetSymbols([]string{"FUND1"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 100)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = p.Start()
+				Expect(err).To(HaveOccurred())
+
+				err = p.SetRefreshInterval(time.Millisecond * 200)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("the refresh interval is not set", func() {
+			It("should return an error", func() {
+				p := poller.NewPoller(ctx, poller.PollerConfig{
+					UnaryAPI:             inputUnaryAPI,
+					ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+					ChanError:            inputChanError,
+				})
+
+				p.SetSymbols([]string{"FUND1"}, 0)
+
+				err := p.Start()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("the symbols are not set", func() {
+			It("should not return any price updates", func() {
+
+				p := poller.NewPoller(ctx, poller.PollerConfig{
+					UnaryAPI:             inputUnaryAPI,
+					ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+					ChanError:            inputChanError,
+				})
+
+				p.SetRefreshInterval(time.Millisecond * 100)
+				p.SetSymbols([]string{}, 0)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Consistently(inputChanUpdateAssetQuote).ShouldNot(Receive())
+				Consistently(inputChanError).ShouldNot(Receive())
+
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		It("should stop the polling process", func() {
+
+			p := poller.NewPoller(ctx, poller.PollerConfig{
+				UnaryAPI:             inputUnaryAPI,
+				ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+				ChanError:            inputChanError,
+			})
+
+			p.SetSymbols([]string{"FUND1"}, 0)
+			p.SetRefreshInterval(time.Millisecond * 100)
+
+			err := p.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = p.Stop()
+			Expect(err).NotTo(HaveOccurred())
+
+			Consistently(inputChanUpdateAssetQuote).ShouldNot(Receive())
+			Consistently(inputChanError).ShouldNot(Receive())
+		})
+	})
+})
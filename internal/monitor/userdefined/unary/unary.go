@@ -0,0 +1,269 @@
+package unary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/util"
+)
+
+const (
+	placeholderSymbol = "{symbol}"
+)
+
+// UnaryAPI is a client for user defined HTTP/JSON quote sources
+type UnaryAPI struct {
+	client          *http.Client
+	sourcesBySymbol map[string]c.ConfigSourceUserDefined
+}
+
+// NewUnaryAPI creates a new client for the given user defined sources
+func NewUnaryAPI(sources []c.ConfigSourceUserDefined) *UnaryAPI {
+
+	sourcesBySymbol := make(map[string]c.ConfigSourceUserDefined)
+
+	for _, source := range sources {
+		for _, symbol := range source.Symbols {
+			sourcesBySymbol[strings.ToUpper(symbol)] = source
+		}
+	}
+
+	return &UnaryAPI{
+		client:          &http.Client{Timeout: util.RequestTimeout},
+		sourcesBySymbol: sourcesBySymbol,
+	}
+}
+
+// GetAssetQuotes issues a HTTP request for each symbol to its user defined source and maps the response onto an asset quote
+// Symbols which fail are skipped and their errors are joined so that quotes for the other symbols are still returned
+func (u *UnaryAPI) GetAssetQuotes(symbols []string) ([]c.AssetQuote, map[string]*c.AssetQuote, error) {
+	if len(symbols) == 0 {
+		return []c.AssetQuote{}, make(map[string]*c.AssetQuote), nil
+	}
+
+	quotes := make([]c.AssetQuote, 0, len(symbols))
+	quotesBySymbol := make(map[string]*c.AssetQuote, len(symbols))
+	errs := make([]error, 0)
+
+	for _, symbol := range symbols {
+
+		source, exists := u.sourcesBySymbol[strings.ToUpper(symbol)]
+		if !exists {
+			errs = append(errs, fmt.Errorf("no user defined source for symbol %s", symbol))
+
+			continue
+		}
+
+		response, err := u.getQuote(source, symbol)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get quote for %s from source %s: %w", symbol, source.Name, err))
+
+			continue
+		}
+
+		quote, err := transformResponse(response, source, symbol)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to map response for %s from source %s: %w", symbol, source.Name, err))
+
+			continue
+		}
+
+		quotes = append(quotes, quote)
+		quotesBySymbol[symbol] = &quote
+	}
+
+	return quotes, quotesBySymbol, errors.Join(errs...)
+}
+
+func (u *UnaryAPI) getQuote(source c.ConfigSourceUserDefined, symbol string) (interface{}, error) {
+
+	reqURL := strings.ReplaceAll(source.URL, placeholderSymbol, url.PathEscape(symbol))
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	for key, value := range source.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	var result interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result, nil
+}
+
+func transformResponse(response interface{}, source c.ConfigSourceUserDefined, symbol string) (c.AssetQuote, error) {
+
+	fields := source.Fields
+
+	if fields.Price == "" {
+		return c.AssetQuote{}, errors.New("no field mapping for price")
+	}
+
+	price, err := getFloat(response, fields.Price)
+	if err != nil {
+		return c.AssetQuote{}, err
+	}
+
+	var values [10]float64
+	paths := [10]string{
+		fields.PricePrevClose,
+		fields.PriceOpen,
+		fields.PriceDayHigh,
+		fields.PriceDayLow,
+		fields.Change,
+		fields.ChangePercent,
+		fields.FiftyTwoWeekHigh,
+		fields.FiftyTwoWeekLow,
+		fields.MarketCap,
+		fields.Volume,
+	}
+
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		values[i], err = getFloat(response, path)
+		if err != nil {
+			return c.AssetQuote{}, err
+		}
+	}
+
+	quotePrice := c.QuotePrice{
+		Price:          price,
+		PricePrevClose: values[0],
+		PriceOpen:      values[1],
+		PriceDayHigh:   values[2],
+		PriceDayLow:    values[3],
+		Change:         values[4],
+		ChangePercent:  values[5],
+	}
+
+	// Derive the change from the previous close when the source does not provide it
+	if fields.Change == "" && quotePrice.PricePrevClose != 0 {
+		quotePrice.Change = quotePrice.Price - quotePrice.PricePrevClose
+	}
+
+	if fields.ChangePercent == "" && quotePrice.PricePrevClose != 0 {
+		quotePrice.ChangePercent = (quotePrice.Change / quotePrice.PricePrevClose) * 100
+	}
+
+	name := getStringOrDefault(response, fields.Name, source.Name)
+	currency := strings.ToUpper(getStringOrDefault(response, fields.Currency, source.Currency))
+
+	return c.AssetQuote{
+		Name:   name,
+		Symbol: strings.ToUpper(symbol),
+		Class:  c.AssetClassUnknown,
+		Currency: c.Currency{
+			FromCurrencyCode: currency,
+		},
+		QuotePrice: quotePrice,
+		QuoteExtended: c.QuoteExtended{
+			FiftyTwoWeekHigh: values[6],
+			FiftyTwoWeekLow:  values[7],
+			MarketCap:        values[8],
+			Volume:           values[9],
+		},
+		QuoteSource: c.QuoteSourceUserDefined,
+		Exchange: c.Exchange{
+			Name:                    source.Name,
+			State:                   c.ExchangeStateOpen,
+			IsActive:                true,
+			IsRegularTradingSession: true,
+		},
+		Meta: c.Meta{
+			SymbolInSourceAPI: symbol,
+		},
+	}, nil
+}
+
+// getValue resolves a dot separated path such as "data.quotes.0.price" against a decoded JSON value
+func getValue(value interface{}, path string) (interface{}, error) {
+
+	for _, key := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			next, exists := node[key]
+			if !exists {
+				return nil, fmt.Errorf("path %s not found in response", path)
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("path %s not found in response", path)
+			}
+			value = node[index]
+		default:
+			return nil, fmt.Errorf("path %s not found in response", path)
+		}
+	}
+
+	return value, nil
+}
+
+func getFloat(response interface{}, path string) (float64, error) {
+
+	value, err := getValue(response, path)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value at path %s is not a number", path)
+		}
+
+		return f, nil
+	case nil:
+		return 0, nil
+	}
+
+	return 0, fmt.Errorf("value at path %s is not a number", path)
+}
+
+func getStringOrDefault(response interface{}, path string, defaultValue string) string {
+
+	if path == "" {
+		return defaultValue
+	}
+
+	value, err := getValue(response, path)
+	if err != nil {
+		return defaultValue
+	}
+
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+
+	return defaultValue
+}
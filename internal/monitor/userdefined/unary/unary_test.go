@@ -0,0 +1,182 @@
+package unary_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/userdefined/unary"
+)
+
+var _ = Describe("Unary", func() {
+	var (
+		server *ghttp.Server
+		source c.ConfigSourceUserDefined
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		source = c.ConfigSourceUserDefined{
+			Name:     "my-broker",
+			URL:      server.URL() + "/quote/{symbol}",
+			Symbols:  []string{"fund1"},
+			Currency: "EUR",
+			Headers:  map[string]string{"Authorization": "Bearer token"},
+			Fields: c.ConfigSourceUserDefinedFieldMapping{
+				Name:           "data.name",
+				Price:          "data.quotes.0.price",
+				PricePrevClose: "data.quotes.0.previous",
+				Volume:         "data.volume",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("GetAssetQuotes", func() {
+		It("should map the response onto an asset quote", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/quote/FUND1"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{
+							"name":   "Fund One",
+							"volume": "1200",
+							"quotes": []interface{}{
+								map[string]interface{}{"price": 110.0, "previous": 100.0},
+							},
+						},
+					}),
+				),
+			)
+
+			api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+			quotes, quotesBySymbol, err := api.GetAssetQuotes([]string{"FUND1"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotes).To(HaveLen(1))
+			Expect(quotes[0].Name).To(Equal("Fund One"))
+			Expect(quotes[0].Symbol).To(Equal("FUND1"))
+			Expect(quotes[0].QuoteSource).To(Equal(c.QuoteSourceUserDefined))
+			Expect(quotes[0].Currency.FromCurrencyCode).To(Equal("EUR"))
+			Expect(quotes[0].QuotePrice.Price).To(Equal(110.0))
+			Expect(quotes[0].QuotePrice.PricePrevClose).To(Equal(100.0))
+			Expect(quotes[0].QuotePrice.Change).To(Equal(10.0))
+			Expect(quotes[0].QuotePrice.ChangePercent).To(Equal(10.0))
+			Expect(quotes[0].QuoteExtended.Volume).To(Equal(1200.0))
+			Expect(quotesBySymbol).To(HaveKey("FUND1"))
+		})
+
+		When("the symbol is configured in lowercase", func() {
+			It("should request the symbol as configured and show it in uppercase", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, "/quote/fund1"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+							"data": map[string]interface{}{
+								"volume": 10.0,
+								"quotes": []interface{}{map[string]interface{}{"price": 110.0, "previous": 100.0}},
+							},
+						}),
+					),
+				)
+
+				api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+				quotes, quotesBySymbol, err := api.GetAssetQuotes([]string{"fund1"})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes).To(HaveLen(1))
+				Expect(quotes[0].Symbol).To(Equal("FUND1"))
+				Expect(quotes[0].Meta.SymbolInSourceAPI).To(Equal("fund1"))
+				Expect(quotesBySymbol).To(HaveKey("fund1"))
+			})
+		})
+
+		When("there are no symbols", func() {
+			It("should return an empty result without making a request", func() {
+				api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+				quotes, quotesBySymbol, err := api.GetAssetQuotes([]string{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes).To(BeEmpty())
+				Expect(quotesBySymbol).To(BeEmpty())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		When("the symbol is not listed on any source", func() {
+			It("should return an error", func() {
+				api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+				_, _, err := api.GetAssetQuotes([]string{"OTHER"})
+
+				Expect(err).To(MatchError("no user defined source for symbol OTHER"))
+			})
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+				_, _, err := api.GetAssetQuotes([]string{"FUND1"})
+
+				Expect(err).To(MatchError(ContainSubstring("request failed with status 500")))
+			})
+		})
+
+		When("the request for one of the symbols fails", func() {
+			It("should return the quotes for the other symbols and an error for the failed symbol", func() {
+				source.Symbols = []string{"FUND1", "FUND2"}
+				server.RouteToHandler(http.MethodGet, "/quote/FUND1", ghttp.RespondWith(http.StatusInternalServerError, ""))
+				server.RouteToHandler(http.MethodGet, "/quote/FUND2", ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"data": map[string]interface{}{
+						"volume": 10.0,
+						"quotes": []interface{}{map[string]interface{}{"price": 50.0, "previous": 40.0}},
+					},
+				}))
+
+				api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+				quotes, quotesBySymbol, err := api.GetAssetQuotes([]string{"FUND1", "FUND2"})
+
+				Expect(err).To(MatchError(ContainSubstring("failed to get quote for FUND1 from source my-broker")))
+				Expect(quotes).To(HaveLen(1))
+				Expect(quotes[0].Symbol).To(Equal("FUND2"))
+				Expect(quotes[0].QuotePrice.Price).To(Equal(50.0))
+				Expect(quotesBySymbol).To(HaveKey("FUND2"))
+				Expect(quotesBySymbol).NotTo(HaveKey("FUND1"))
+			})
+		})
+
+		When("the price path is not in the response", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"data": map[string]interface{}{}}))
+
+				api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+				_, _, err := api.GetAssetQuotes([]string{"FUND1"})
+
+				Expect(err).To(MatchError(ContainSubstring("path data.quotes.0.price not found in response")))
+			})
+		})
+
+		When("the value at a path is not a number", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"data": map[string]interface{}{
+						"quotes": []interface{}{map[string]interface{}{"price": "n/a"}},
+					},
+				}))
+
+				api := unary.NewUnaryAPI([]c.ConfigSourceUserDefined{source})
+				_, _, err := api.GetAssetQuotes([]string{"FUND1"})
+
+				Expect(err).To(MatchError(ContainSubstring("value at path data.quotes.0.price is not a number")))
+			})
+		})
+	})
+})
@@ -0,0 +1,117 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	defaultReconnectBackoffInitial = 500 * time.Millisecond
+	defaultReconnectBackoffMax     = 30 * time.Second
+)
+
+// Reconnector retries connecting to a stream with exponential backoff and sends the connection state of the stream
+type Reconnector struct {
+	source         c.QuoteSource
+	chanStatus     chan c.StreamStatusUpdate
+	backoffInitial time.Duration
+	backoffMax     time.Duration
+}
+
+// NewReconnector creates a reconnector which sends connection state changes for the source to an optional channel
+func NewReconnector(source c.QuoteSource, chanStatus chan c.StreamStatusUpdate) *Reconnector {
+	return &Reconnector{
+		source:         source,
+		chanStatus:     chanStatus,
+		backoffInitial: defaultReconnectBackoffInitial,
+		backoffMax:     defaultReconnectBackoffMax,
+	}
+}
+
+// SetBackoff sets the delay before the first reconnect attempt and the maximum delay between attempts
+func (r *Reconnector) SetBackoff(initial time.Duration, maximum time.Duration) {
+	r.backoffInitial = initial
+	r.backoffMax = maximum
+}
+
+// SendStatus sends a connection state change for the source unless the context is done first
+func (r *Reconnector) SendStatus(ctx context.Context, status c.StreamStatusUpdate) {
+	if r.chanStatus == nil {
+		return
+	}
+
+	status.Source = r.source
+
+	select {
+	case r.chanStatus <- status:
+	case <-ctx.Done():
+	}
+}
+
+// Reconnect calls connect with exponential backoff between attempts until it succeeds and sends the state of the
+// stream before each attempt and once connected. Returns false without connecting if the context is done first
+func (r *Reconnector) Reconnect(ctx context.Context, connect func() error) bool {
+
+	for attempt := 1; ; attempt++ {
+		r.SendStatus(ctx, c.StreamStatusUpdate{Status: c.StreamStatusReconnecting, Attempt: attempt})
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(getReconnectDelay(attempt, r.backoffInitial, r.backoffMax)):
+		}
+
+		if err := connect(); err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+
+			continue
+		}
+
+		r.SendStatus(ctx, c.StreamStatusUpdate{Status: c.StreamStatusConnected, Attempt: attempt})
+
+		return true
+	}
+}
+
+// ValidateURL returns an error if the URL is not a websocket URL
+func ValidateURL(rawURL string) error {
+
+	reqURL, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if reqURL.Scheme != "ws" && reqURL.Scheme != "wss" {
+		return fmt.Errorf("malformed ws or wss URL: %s", rawURL) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// getReconnectDelay returns the delay before a reconnect attempt which doubles with each attempt up to the maximum
+// with a random jitter of up to half of the delay so that many clients do not reconnect at the same time
+func getReconnectDelay(attempt int, initial time.Duration, maximum time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempt && delay < maximum; i++ {
+		delay *= 2
+	}
+
+	if delay > maximum {
+		delay = maximum
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	jitter := rand.Int64N(int64(delay)/2 + 1) //nolint:gosec // jitter does not need to be cryptographically secure
+
+	return delay/2 + time.Duration(jitter)
+}
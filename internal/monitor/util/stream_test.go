@@ -0,0 +1,108 @@
+package util_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/util"
+)
+
+var _ = Describe("Stream", func() {
+
+	Describe("Reconnector", func() {
+
+		var (
+			chanStatus  chan c.StreamStatusUpdate
+			reconnector *util.Reconnector
+		)
+
+		BeforeEach(func() {
+			chanStatus = make(chan c.StreamStatusUpdate, 10)
+			reconnector = util.NewReconnector(c.QuoteSourceCoinbase, chanStatus)
+			reconnector.SetBackoff(time.Millisecond, 4*time.Millisecond)
+		})
+
+		Describe("Reconnect", func() {
+
+			It("should retry until connected and send the state of the stream for each attempt", func() {
+				attempts := 0
+
+				output := reconnector.Reconnect(context.Background(), func() error {
+					attempts++
+					if attempts < 3 {
+						return errors.New("connection refused")
+					}
+
+					return nil
+				})
+
+				Expect(output).To(BeTrue())
+				Expect(attempts).To(Equal(3))
+				Expect(chanStatus).To(HaveLen(4))
+
+				statuses := make([]c.StreamStatusUpdate, 0, 4)
+				for range 4 {
+					statuses = append(statuses, <-chanStatus)
+				}
+
+				Expect(statuses).To(HaveExactElements(
+					g.MatchFields(g.IgnoreExtras, g.Fields{"Source": Equal(c.QuoteSourceCoinbase), "Status": Equal(c.StreamStatusReconnecting), "Attempt": Equal(1)}),
+					g.MatchFields(g.IgnoreExtras, g.Fields{"Source": Equal(c.QuoteSourceCoinbase), "Status": Equal(c.StreamStatusReconnecting), "Attempt": Equal(2)}),
+					g.MatchFields(g.IgnoreExtras, g.Fields{"Source": Equal(c.QuoteSourceCoinbase), "Status": Equal(c.StreamStatusReconnecting), "Attempt": Equal(3)}),
+					g.MatchFields(g.IgnoreExtras, g.Fields{"Source": Equal(c.QuoteSourceCoinbase), "Status": Equal(c.StreamStatusConnected), "Attempt": Equal(3)}),
+				))
+			})
+
+			When("the context is done before connecting", func() {
+				It("should stop retrying and return false", func() {
+					ctx, cancel := context.WithCancel(context.Background())
+					attempts := 0
+
+					output := reconnector.Reconnect(ctx, func() error {
+						attempts++
+						cancel()
+
+						return errors.New("connection refused")
+					})
+
+					Expect(output).To(BeFalse())
+					Expect(attempts).To(Equal(1))
+				})
+			})
+
+		})
+
+		Describe("SendStatus", func() {
+
+			When("there is no status channel", func() {
+				It("should not block", func() {
+					reconnector = util.NewReconnector(c.QuoteSourceCoinCap, nil)
+
+					reconnector.SendStatus(context.Background(), c.StreamStatusUpdate{Status: c.StreamStatusConnected})
+				})
+			})
+
+		})
+
+	})
+
+	Describe("ValidateURL", func() {
+
+		It("should accept ws and wss URLs", func() {
+			Expect(util.ValidateURL("ws://localhost:8080")).To(Succeed())
+			Expect(util.ValidateURL("wss://ws-feed.exchange.coinbase.com")).To(Succeed())
+		})
+
+		It("should return an error for other URLs", func() {
+			Expect(util.ValidateURL("http://localhost:8080")).To(MatchError("malformed ws or wss URL: http://localhost:8080"))
+		})
+
+	})
+
+})
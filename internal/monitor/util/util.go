@@ -0,0 +1,6 @@
+package util
+
+import "time"
+
+// RequestTimeout is the time allowed for each request to a quote source so that an unresponsive source does not block polling
+const RequestTimeout = 10 * time.Second
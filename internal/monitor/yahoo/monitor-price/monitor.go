@@ -213,6 +213,11 @@ func (m *MonitorPriceYahoo) SetCurrencyRates(currencyRates c.CurrencyRates) erro
 	return nil
 }
 
+// GetPriceHistory retrieves historical prices for a symbol
+func (m *MonitorPriceYahoo) GetPriceHistory(symbol string, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error) {
+	return m.unaryAPI.GetPriceHistory(symbol, historyRange, interval)
+}
+
 // handleUpdates listens for asset quote change messages and updates the cache
 func (m *MonitorPriceYahoo) handleUpdates() {
 	for {
@@ -56,3 +56,50 @@ var (
 		},
 	}
 )
+
+const (
+	responseChartFixture = `{
+		"chart": {
+			"result": [
+				{
+					"meta": {"symbol": "NET", "currency": "USD"},
+					"timestamp": [1735828200, 1735914600],
+					"indicators": {
+						"quote": [
+							{
+								"open": [84.0, 85.1],
+								"high": [86.5, 86.0],
+								"low": [83.25, 84.2],
+								"close": [85.0, 84.98],
+								"volume": [1200000, 980000]
+							}
+						]
+					}
+				}
+			],
+			"error": null
+		}
+	}`
+	responseChartWithGapFixture = `{
+		"chart": {
+			"result": [
+				{
+					"meta": {"symbol": "NET", "currency": "USD"},
+					"timestamp": [1735828200, 1735914600],
+					"indicators": {
+						"quote": [
+							{
+								"open": [null, 85.1],
+								"high": [null, 86.0],
+								"low": [null, 84.2],
+								"close": [null, 84.98],
+								"volume": [null, 980000]
+							}
+						]
+					}
+				}
+			],
+			"error": null
+		}
+	}`
+)
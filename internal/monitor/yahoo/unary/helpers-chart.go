@@ -0,0 +1,60 @@
+package unary
+
+import (
+	"strings"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// transformResponseChart transforms the price history returned by the chart API into a PriceHistory
+func transformResponseChart(result ResponseChartResult, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) c.PriceHistory {
+
+	candles := make([]c.PriceCandle, 0, len(result.Timestamps))
+
+	if len(result.Indicators.Quotes) > 0 {
+		quote := result.Indicators.Quotes[0]
+
+		for i, timestamp := range result.Timestamps {
+			closePrice := getValueAt(quote.Close, i)
+
+			// Skip intervals without any trades
+			if closePrice == nil {
+				continue
+			}
+
+			candles = append(candles, c.PriceCandle{
+				Time:   time.Unix(timestamp, 0).UTC(),
+				Open:   getValueOrZero(getValueAt(quote.Open, i)),
+				High:   getValueOrZero(getValueAt(quote.High, i)),
+				Low:    getValueOrZero(getValueAt(quote.Low, i)),
+				Close:  *closePrice,
+				Volume: getValueOrZero(getValueAt(quote.Volume, i)),
+			})
+		}
+	}
+
+	return c.PriceHistory{
+		Symbol:   result.Meta.Symbol,
+		Currency: strings.ToUpper(result.Meta.Currency),
+		Range:    historyRange,
+		Interval: interval,
+		Candles:  candles,
+	}
+}
+
+func getValueAt(values []*float64, i int) *float64 {
+	if i >= len(values) {
+		return nil
+	}
+
+	return values[i]
+}
+
+func getValueOrZero(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+
+	return *value
+}
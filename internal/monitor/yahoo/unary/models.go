@@ -48,3 +48,43 @@ type ResponseFieldString struct {
 	Raw string `json:"raw"`
 	Fmt string `json:"fmt"`
 }
+
+// ResponseChart represents the container object from the chart API response
+type ResponseChart struct {
+	Chart ResponseChartChart `json:"chart"`
+}
+
+type ResponseChartChart struct {
+	Results []ResponseChartResult `json:"result"`
+	Error   *ResponseChartError   `json:"error"`
+}
+
+type ResponseChartError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// ResponseChartResult represents the price history of a single security from the chart API response
+type ResponseChartResult struct {
+	Meta       ResponseChartMeta       `json:"meta"`
+	Timestamps []int64                 `json:"timestamp"`
+	Indicators ResponseChartIndicators `json:"indicators"`
+}
+
+type ResponseChartMeta struct {
+	Symbol   string `json:"symbol"`
+	Currency string `json:"currency"`
+}
+
+type ResponseChartIndicators struct {
+	Quotes []ResponseChartQuote `json:"quote"`
+}
+
+// ResponseChartQuote contains a value for each timestamp which is null when there was no trading in the interval
+type ResponseChartQuote struct {
+	Open   []*float64 `json:"open"`
+	High   []*float64 `json:"high"`
+	Low    []*float64 `json:"low"`
+	Close  []*float64 `json:"close"`
+	Volume []*float64 `json:"volume"`
+}
@@ -154,32 +154,75 @@ func (u *UnaryAPI) GetCurrencyRates(fromCurrencies []string, toCurrency string)
 	return currencyRates, nil
 }
 
-func (u *UnaryAPI) getQuotes(symbols []string, fields []string) (Response, error) {
+// GetPriceHistory retrieves the open, high, low, close, and volume for each interval over the range for a symbol
+func (u *UnaryAPI) GetPriceHistory(symbol string, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error) {
+	return u.getChart(symbol, historyRange, interval, false)
+}
 
-	// Build URL with query parameters
-	reqURL, err := url.Parse(u.baseURL + "/v7/finance/quote")
+func (u *UnaryAPI) getChart(symbol string, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval, isRetry bool) (c.PriceHistory, error) {
+
+	reqURL, err := url.Parse(u.baseURL + "/v8/finance/chart/" + url.PathEscape(symbol))
 	if err != nil {
-		return Response{}, fmt.Errorf("failed to create request: %w", err)
+		return c.PriceHistory{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	q := reqURL.Query()
-	q.Set("fields", strings.Join(fields, ","))
-	q.Set("symbols", strings.Join(symbols, ","))
-
-	// Add common Yahoo Finance query parameters
-	q.Set("formatted", "true")
+	q.Set("range", string(historyRange))
+	q.Set("interval", string(interval))
+	q.Set("includePrePost", "false")
 	q.Set("lang", "en-US")
 	q.Set("region", "US")
-	q.Set("corsDomain", "finance.yahoo.com")
 
-	// Add crumb if available
 	if u.crumb != "" {
 		q.Set("crumb", u.crumb)
 	}
 
 	reqURL.RawQuery = q.Encode()
 
-	// Create request
+	resp, err := u.client.Do(u.newRequest(reqURL))
+	if err != nil {
+		return c.PriceHistory{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result ResponseChart
+	errDecode := json.NewDecoder(resp.Body).Decode(&result)
+
+	// The API describes errors such as an unknown symbol in the response body
+	if result.Chart.Error != nil {
+		return c.PriceHistory{}, fmt.Errorf("failed to get price history for %s: %s", symbol, result.Chart.Error.Description)
+	}
+
+	// Handle not ok responses by refreshing the session and retrying once
+	if resp.StatusCode >= 400 {
+		if isRetry {
+			return c.PriceHistory{}, fmt.Errorf("unexpected response: %d", resp.StatusCode)
+		}
+
+		if err := u.refreshSession(); err != nil {
+			return c.PriceHistory{}, fmt.Errorf("session refresh failed: %w", err)
+		}
+
+		return u.getChart(symbol, historyRange, interval, true)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c.PriceHistory{}, fmt.Errorf("unexpected response: %d", resp.StatusCode)
+	}
+
+	if errDecode != nil {
+		return c.PriceHistory{}, fmt.Errorf("failed to decode response: %w", errDecode)
+	}
+
+	if len(result.Chart.Results) == 0 {
+		return c.PriceHistory{}, fmt.Errorf("no price history for %s", symbol)
+	}
+
+	return transformResponseChart(result.Chart.Results[0], historyRange, interval), nil
+}
+
+// newRequest creates a GET request with the headers and session cookies expected by the API
+func (u *UnaryAPI) newRequest(reqURL *url.URL) *http.Request {
 	req, _ := http.NewRequest(http.MethodGet, reqURL.String(), nil)
 
 	// Set common headers
@@ -196,6 +239,37 @@ func (u *UnaryAPI) getQuotes(symbols []string, fields []string) (Response, error
 		}
 	}
 
+	return req
+}
+
+func (u *UnaryAPI) getQuotes(symbols []string, fields []string) (Response, error) {
+
+	// Build URL with query parameters
+	reqURL, err := url.Parse(u.baseURL + "/v7/finance/quote")
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := reqURL.Query()
+	q.Set("fields", strings.Join(fields, ","))
+	q.Set("symbols", strings.Join(symbols, ","))
+
+	// Add common Yahoo Finance query parameters
+	q.Set("formatted", "true")
+	q.Set("lang", "en-US")
+	q.Set("region", "US")
+	q.Set("corsDomain", "finance.yahoo.com")
+
+	// Add crumb if available
+	if u.crumb != "" {
+		q.Set("crumb", u.crumb)
+	}
+
+	reqURL.RawQuery = q.Encode()
+
+	// Create request
+	req := u.newRequest(reqURL)
+
 	// Make request
 	resp, err := u.client.Do(req)
 	if err != nil {
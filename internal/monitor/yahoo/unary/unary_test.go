@@ -8,6 +8,7 @@ import (
 
 	"net/http"
 	"net/url"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -627,6 +628,129 @@ var _ = Describe("Unary", func() {
 		})
 	})
 
+	Describe("GetPriceHistory", func() {
+		It("should return the candles for the range and interval", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					verifyRequest(server, "GET", "/v8/finance/chart/NET", "range", "5d"),
+					verifyRequest(server, "GET", "/v8/finance/chart/NET", "interval", "1d"),
+					ghttp.RespondWith(http.StatusOK, responseChartFixture),
+				),
+			)
+
+			output, outputError := client.GetPriceHistory("NET", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Day)
+			Expect(outputError).NotTo(HaveOccurred())
+			Expect(output).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+				"Symbol":   Equal("NET"),
+				"Currency": Equal("USD"),
+				"Range":    Equal(c.PriceHistoryRange5Day),
+				"Interval": Equal(c.PriceHistoryInterval1Day),
+			}))
+			Expect(output.Candles).To(HaveLen(2))
+			Expect(output.Candles[0]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+				"Time":   BeTemporally("==", time.Unix(1735828200, 0)),
+				"Open":   Equal(84.0),
+				"High":   Equal(86.5),
+				"Low":    Equal(83.25),
+				"Close":  Equal(85.0),
+				"Volume": Equal(1200000.0),
+			}))
+			Expect(output.Candles[1].Close).To(Equal(84.98))
+		})
+
+		When("an interval has no close price", func() {
+			It("should skip the interval", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v8/finance/chart/NET"),
+						ghttp.RespondWith(http.StatusOK, responseChartWithGapFixture),
+					),
+				)
+
+				output, outputError := client.GetPriceHistory("NET", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Day)
+				Expect(outputError).NotTo(HaveOccurred())
+				Expect(output.Candles).To(HaveLen(1))
+				Expect(output.Candles[0].Close).To(Equal(84.98))
+			})
+		})
+
+		When("the API returns an error for the symbol", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v8/finance/chart/INVALID"),
+						ghttp.RespondWith(http.StatusNotFound, `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`),
+					),
+				)
+
+				_, outputError := client.GetPriceHistory("INVALID", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Day)
+				Expect(outputError).To(HaveOccurred())
+				Expect(outputError.Error()).To(ContainSubstring("No data found, symbol may be delisted"))
+			})
+		})
+
+		When("the API returns no results", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v8/finance/chart/NET"),
+						ghttp.RespondWith(http.StatusOK, `{"chart":{"result":[],"error":null}}`),
+					),
+				)
+
+				_, outputError := client.GetPriceHistory("NET", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Day)
+				Expect(outputError).To(HaveOccurred())
+				Expect(outputError.Error()).To(ContainSubstring("no price history for NET"))
+			})
+		})
+
+		When("the session is not set or is expired", func() {
+			It("should refresh the session and then retry the request", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v8/finance/chart/NET"),
+						ghttp.RespondWith(http.StatusUnauthorized, ""),
+					),
+				)
+				appendRootSessionOK(server)
+				appendCrumb(server, "abc123")
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						verifyRequest(server, "GET", "/v8/finance/chart/NET", "crumb", "abc123"),
+						ghttp.RespondWith(http.StatusOK, responseChartFixture),
+					),
+				)
+
+				output, outputError := client.GetPriceHistory("NET", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Day)
+				Expect(outputError).NotTo(HaveOccurred())
+				Expect(output.Candles).To(HaveLen(2))
+			})
+
+			When("the retried request also fails", func() {
+				It("should return an error", func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/v8/finance/chart/NET"),
+							ghttp.RespondWith(http.StatusUnauthorized, ""),
+						),
+					)
+					appendRootSessionOK(server)
+					appendCrumb(server, "abc123")
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/v8/finance/chart/NET"),
+							ghttp.RespondWith(http.StatusUnauthorized, ""),
+						),
+					)
+
+					_, outputError := client.GetPriceHistory("NET", c.PriceHistoryRange5Day, c.PriceHistoryInterval1Day)
+					Expect(outputError).To(HaveOccurred())
+					Expect(outputError.Error()).To(ContainSubstring("unexpected response: 401"))
+				})
+			})
+		})
+	})
+
 	Describe("GetCurrencyMap", func() {
 		It("should return a map of symbols to currency codes", func() {
 			appendQuoteHandler(server, "NET", urlParamsForCurrency, responseQuoteForCurrencyMap1Fixture)
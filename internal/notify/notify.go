@@ -0,0 +1,408 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/alert"
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	deliveryTimeout  = 10 * time.Second
+	deliveryInterval = time.Second
+)
+
+// Payload is the notification sent to a webhook and passed to a command
+// The threshold, price, and change are in the currency of the quote and the position and portfolio are in the currency positions are shown in
+type Payload struct {
+	Time          time.Time        `json:"time"`
+	Symbol        string           `json:"symbol"`
+	Name          string           `json:"name"`
+	Rule          string           `json:"rule"`
+	Threshold     float64          `json:"threshold"`
+	Message       string           `json:"message"`
+	Currency      string           `json:"currency"`
+	Price         float64          `json:"price"`
+	Change        float64          `json:"change"`
+	ChangePercent float64          `json:"change_percent"`
+	Position      *PayloadPosition `json:"position,omitempty"`
+	Portfolio     PayloadPortfolio `json:"portfolio"`
+}
+
+// PayloadPosition is the position in the asset when there are lots for the symbol
+type PayloadPosition struct {
+	Quantity           float64 `json:"quantity"`
+	Value              float64 `json:"value"`
+	Cost               float64 `json:"cost"`
+	Weight             float64 `json:"weight"`
+	DayChangeAmount    float64 `json:"day_change_amount"`
+	DayChangePercent   float64 `json:"day_change_percent"`
+	TotalChangeAmount  float64 `json:"total_change_amount"`
+	TotalChangePercent float64 `json:"total_change_percent"`
+}
+
+// PayloadPortfolio is the summary of all positions in the asset group
+type PayloadPortfolio struct {
+	Value              float64 `json:"value"`
+	Cost               float64 `json:"cost"`
+	DayChangeAmount    float64 `json:"day_change_amount"`
+	DayChangePercent   float64 `json:"day_change_percent"`
+	TotalChangeAmount  float64 `json:"total_change_amount"`
+	TotalChangePercent float64 `json:"total_change_percent"`
+}
+
+// Notifier evaluates notification rules against assets and delivers notifications to webhooks and commands
+type Notifier struct {
+	rules      []rule
+	retries    int
+	retryDelay time.Duration
+	interval   time.Duration
+	targets    map[string]*target
+	client     *http.Client
+	logger     *log.Logger
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+}
+
+// target serializes deliveries to a webhook or command so that they are spaced by the delivery interval
+type target struct {
+	mu            sync.Mutex
+	lastDelivered time.Time
+}
+
+type rule struct {
+	config    c.ConfigNotifyRule
+	evaluator *alert.Evaluator
+}
+
+// Config contains the required configuration for the notifier
+type Config struct {
+	Rules     []c.ConfigNotifyRule
+	Retries   int           // Retries after a failed delivery; defaults to 3
+	RateLimit time.Duration // Minimum time before a rule can notify again for the same condition; defaults to 5 minutes
+	Interval  time.Duration // Minimum time between deliveries to the same webhook or command; defaults to 1 second
+	Logger    *log.Logger   // Optional logger for failed deliveries
+}
+
+// Option defines an option for configuring the notifier
+type Option func(*Notifier)
+
+// NewConfigNotifier builds the notifier configuration from user defined configuration
+func NewConfigNotifier(ctx c.Context) Config {
+	return Config{
+		Rules:     ctx.Config.Notify.Rules,
+		Retries:   ctx.Config.Notify.Retries,
+		RateLimit: time.Duration(ctx.Config.Notify.RateLimit) * time.Second,
+		Interval:  time.Duration(ctx.Config.Notify.Interval) * time.Second,
+		Logger:    ctx.Logger,
+	}
+}
+
+// NewNotifier creates a notifier
+func NewNotifier(config Config, opts ...Option) *Notifier {
+
+	n := &Notifier{
+		rules:      make([]rule, 0, len(config.Rules)),
+		retries:    3,
+		retryDelay: time.Second,
+		interval:   deliveryInterval,
+		targets:    make(map[string]*target),
+		client:     &http.Client{Timeout: deliveryTimeout},
+		logger:     config.Logger,
+	}
+
+	if config.Retries > 0 {
+		n.retries = config.Retries
+	}
+
+	if config.Interval > 0 {
+		n.interval = config.Interval
+	}
+
+	rateLimit := config.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 5 * time.Minute
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	for _, configRule := range config.Rules {
+		n.rules = append(n.rules, rule{
+			config: configRule,
+			evaluator: alert.NewEvaluator(
+				alert.Config{Rules: []c.ConfigAlert{{
+					Symbol:        configRule.Symbol,
+					PriceAbove:    configRule.PriceAbove,
+					PriceBelow:    configRule.PriceBelow,
+					ChangePercent: configRule.ChangePercent,
+				}}},
+				alert.WithCooldown(rateLimit),
+			),
+		})
+	}
+
+	return n
+}
+
+// WithClient sets the HTTP client used to deliver to webhooks
+func WithClient(client *http.Client) Option {
+	return func(n *Notifier) {
+		n.client = client
+	}
+}
+
+// WithRetryDelay sets the delay before the first retry which doubles after each retry
+func WithRetryDelay(retryDelay time.Duration) Option {
+	return func(n *Notifier) {
+		n.retryDelay = retryDelay
+	}
+}
+
+// Notify evaluates the rules against the quote of each asset and delivers notifications in the background
+// Rules are evaluated in the currency of the quote as alerts are so that a threshold is met at the same price for both regardless of the currency positions are shown in
+func (n *Notifier) Notify(assetQuotes []c.AssetQuote, assets []c.Asset, positionSummary asset.PositionSummary) {
+
+	assetsBySymbol := make(map[string]c.Asset, len(assets))
+	for _, a := range assets {
+		assetsBySymbol[a.Symbol] = a
+	}
+
+	for _, assetQuote := range assetQuotes {
+		a, ok := assetsBySymbol[assetQuote.Symbol]
+		if !ok {
+			continue
+		}
+
+		for _, r := range n.rules {
+			for _, triggeredAlert := range r.evaluator.Evaluate(assetQuote) {
+				payload := NewPayload(assetQuote, a, positionSummary, triggeredAlert)
+
+				n.wg.Add(1)
+				go func(r rule, payload Payload) {
+					defer n.wg.Done()
+					n.deliver(r.config, payload)
+				}(r, payload)
+			}
+		}
+	}
+}
+
+// Wait blocks until all notifications have been delivered or have failed
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// NewPayload builds the notification for an alert on the quote of an asset
+func NewPayload(assetQuote c.AssetQuote, a c.Asset, positionSummary asset.PositionSummary, triggeredAlert c.Alert) Payload {
+
+	payload := Payload{
+		Time:          triggeredAlert.Time,
+		Symbol:        a.Symbol,
+		Name:          a.Name,
+		Rule:          getRuleName(triggeredAlert.Type),
+		Threshold:     triggeredAlert.Threshold,
+		Message:       triggeredAlert.Message,
+		Currency:      assetQuote.Currency.FromCurrencyCode,
+		Price:         assetQuote.QuotePrice.Price,
+		Change:        assetQuote.QuotePrice.Change,
+		ChangePercent: assetQuote.QuotePrice.ChangePercent,
+		Portfolio: PayloadPortfolio{
+			Value:              positionSummary.Value,
+			Cost:               positionSummary.Cost,
+			DayChangeAmount:    positionSummary.DayChange.Amount,
+			DayChangePercent:   positionSummary.DayChange.Percent,
+			TotalChangeAmount:  positionSummary.TotalChange.Amount,
+			TotalChangePercent: positionSummary.TotalChange.Percent,
+		},
+	}
+
+	if a.Position.Quantity != 0 {
+		payload.Position = &PayloadPosition{
+			Quantity:           a.Position.Quantity,
+			Value:              a.Position.Value,
+			Cost:               a.Position.Cost,
+			Weight:             a.Position.Weight,
+			DayChangeAmount:    a.Position.DayChange.Amount,
+			DayChangePercent:   a.Position.DayChange.Percent,
+			TotalChangeAmount:  a.Position.TotalChange.Amount,
+			TotalChangePercent: a.Position.TotalChange.Percent,
+		}
+	}
+
+	return payload
+}
+
+// getTarget returns the delivery state for a webhook or command
+func (n *Notifier) getTarget(name string) *target {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	t, ok := n.targets[name]
+	if !ok {
+		t = &target{}
+		n.targets[name] = t
+	}
+
+	return t
+}
+
+// deliver sends the payload to each target in the rule and retries failed deliveries with an increasing delay
+// Deliveries to the same target from any rule wait until the delivery interval has passed since the previous one
+func (n *Notifier) deliver(configRule c.ConfigNotifyRule, payload Payload) {
+
+	targets := make(map[string]func() error)
+
+	if configRule.Webhook != "" {
+		targets["webhook "+configRule.Webhook] = func() error { return n.postWebhook(configRule.Webhook, payload) }
+	}
+
+	if configRule.Command != "" {
+		targets["command "+configRule.Command] = func() error { return runCommand(configRule.Command, payload) }
+	}
+
+	for name, send := range targets {
+		t := n.getTarget(name)
+		t.mu.Lock()
+
+		if wait := n.interval - time.Since(t.lastDelivered); wait > 0 {
+			time.Sleep(wait)
+		}
+
+		var err error
+		delay := n.retryDelay
+
+		for attempt := 0; attempt <= n.retries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(delay)
+				delay *= 2
+			}
+
+			if err = send(); err == nil {
+				break
+			}
+		}
+
+		t.lastDelivered = time.Now()
+		t.mu.Unlock()
+
+		if err != nil && n.logger != nil {
+			n.logger.Printf("failed to notify %s for %s: %v", name, payload.Symbol, err)
+		}
+	}
+}
+
+func (n *Notifier) postWebhook(url string, payload Payload) error {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("request failed with status %d", res.StatusCode) //nolint:goerr113
+	}
+
+	return nil
+}
+
+func runCommand(command string, payload Payload) error {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	cmd := exec.CommandContext(ctx, shell, flag, command)
+	cmd.Env = append(os.Environ(), getEnv(payload, body)...)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(output))
+	}
+
+	return nil
+}
+
+// getEnv returns the environment variables with the quote passed to commands
+func getEnv(payload Payload, body []byte) []string {
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	env := []string{
+		"TICKER_SYMBOL=" + payload.Symbol,
+		"TICKER_NAME=" + payload.Name,
+		"TICKER_RULE=" + payload.Rule,
+		"TICKER_THRESHOLD=" + formatFloat(payload.Threshold),
+		"TICKER_MESSAGE=" + payload.Message,
+		"TICKER_CURRENCY=" + payload.Currency,
+		"TICKER_PRICE=" + formatFloat(payload.Price),
+		"TICKER_CHANGE=" + formatFloat(payload.Change),
+		"TICKER_CHANGE_PERCENT=" + formatFloat(payload.ChangePercent),
+		"TICKER_PAYLOAD=" + string(body),
+	}
+
+	if payload.Position != nil {
+		env = append(env,
+			"TICKER_POSITION_QUANTITY="+formatFloat(payload.Position.Quantity),
+			"TICKER_POSITION_VALUE="+formatFloat(payload.Position.Value),
+		)
+	}
+
+	return env
+}
+
+func getRuleName(alertType c.AlertType) string {
+	switch alertType {
+	case c.AlertTypePriceAbove:
+		return "price-above"
+	case c.AlertTypePriceBelow:
+		return "price-below"
+	case c.AlertTypeChangePercent:
+		return "change-percent"
+	case c.AlertTypeFiftyTwoWeekHigh:
+		return "fifty-two-week-high"
+	case c.AlertTypeFiftyTwoWeekLow:
+		return "fifty-two-week-low"
+	}
+
+	return ""
+}
@@ -0,0 +1,315 @@
+package notify_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/notify"
+)
+
+var _ = Describe("Notify", func() {
+
+	var (
+		server          *ghttp.Server
+		assetQuotes     []c.AssetQuote
+		assets          []c.Asset
+		positionSummary asset.PositionSummary
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		assetQuotes = []c.AssetQuote{
+			{
+				Symbol:     "AAPL",
+				Name:       "Apple Inc.",
+				Currency:   c.Currency{FromCurrencyCode: "USD"},
+				QuotePrice: c.QuotePrice{Price: 210, Change: 12, ChangePercent: 6.06},
+			},
+			{
+				Symbol:     "MSFT",
+				Name:       "Microsoft Corporation",
+				Currency:   c.Currency{FromCurrencyCode: "USD"},
+				QuotePrice: c.QuotePrice{Price: 410, Change: -2, ChangePercent: -0.48},
+			},
+		}
+		assets = []c.Asset{
+			{
+				Symbol:     "AAPL",
+				Name:       "Apple Inc.",
+				Currency:   c.Currency{FromCurrencyCode: "USD"},
+				QuotePrice: c.QuotePrice{Price: 210, Change: 12, ChangePercent: 6.06},
+				Position: c.Position{
+					Quantity:    10,
+					Value:       2100,
+					Cost:        1500,
+					Weight:      100,
+					DayChange:   c.PositionChange{Amount: 120, Percent: 6.06},
+					TotalChange: c.PositionChange{Amount: 600, Percent: 40},
+				},
+			},
+			{
+				Symbol:     "MSFT",
+				Name:       "Microsoft Corporation",
+				Currency:   c.Currency{FromCurrencyCode: "USD"},
+				QuotePrice: c.QuotePrice{Price: 410, Change: -2, ChangePercent: -0.48},
+			},
+		}
+		positionSummary = asset.PositionSummary{
+			Value:       2100,
+			Cost:        1500,
+			DayChange:   c.PositionChange{Amount: 120, Percent: 6.06},
+			TotalChange: c.PositionChange{Amount: 600, Percent: 40},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewPayload", func() {
+
+		It("should build the payload from the quote, asset, position summary, and alert", func() {
+			now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+
+			output := notify.NewPayload(assetQuotes[0], assets[0], positionSummary, c.Alert{
+				Symbol:    "AAPL",
+				Type:      c.AlertTypePriceAbove,
+				Price:     210,
+				Threshold: 200,
+				Time:      now,
+				Message:   "AAPL is above 200.00 at 210.00",
+			})
+
+			Expect(output).To(Equal(notify.Payload{
+				Time:          now,
+				Symbol:        "AAPL",
+				Name:          "Apple Inc.",
+				Rule:          "price-above",
+				Threshold:     200,
+				Message:       "AAPL is above 200.00 at 210.00",
+				Currency:      "USD",
+				Price:         210,
+				Change:        12,
+				ChangePercent: 6.06,
+				Position: &notify.PayloadPosition{
+					Quantity:           10,
+					Value:              2100,
+					Cost:               1500,
+					Weight:             100,
+					DayChangeAmount:    120,
+					DayChangePercent:   6.06,
+					TotalChangeAmount:  600,
+					TotalChangePercent: 40,
+				},
+				Portfolio: notify.PayloadPortfolio{
+					Value:              2100,
+					Cost:               1500,
+					DayChangeAmount:    120,
+					DayChangePercent:   6.06,
+					TotalChangeAmount:  600,
+					TotalChangePercent: 40,
+				},
+			}))
+		})
+
+		When("there is no position in the asset", func() {
+			It("should not include a position", func() {
+				output := notify.NewPayload(assetQuotes[1], assets[1], positionSummary, c.Alert{Type: c.AlertTypeChangePercent})
+
+				Expect(output.Position).To(BeNil())
+				Expect(output.Rule).To(Equal("change-percent"))
+			})
+		})
+
+	})
+
+	Describe("Notify", func() {
+
+		When("a rule has a webhook", func() {
+
+			var payloads []notify.Payload
+
+			BeforeEach(func() {
+				payloads = make([]notify.Payload, 0)
+				server.RouteToHandler(http.MethodPost, "/webhook",
+					ghttp.CombineHandlers(
+						ghttp.VerifyContentType("application/json"),
+						func(_ http.ResponseWriter, r *http.Request) {
+							var payload notify.Payload
+							json.NewDecoder(r.Body).Decode(&payload) //nolint:errcheck
+							payloads = append(payloads, payload)
+						},
+					),
+				)
+			})
+
+			It("should post the payload to the webhook for each rule which is met", func() {
+				notifier := notify.NewNotifier(notify.Config{
+					Rules: []c.ConfigNotifyRule{
+						{Symbol: "AAPL", PriceAbove: 200, Webhook: server.URL() + "/webhook"},
+						{Symbol: "MSFT", PriceAbove: 500, Webhook: server.URL() + "/webhook"},
+					},
+				})
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				Expect(payloads).To(HaveExactElements(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol":    Equal("AAPL"),
+					"Rule":      Equal("price-above"),
+					"Threshold": Equal(200.0),
+					"Message":   Equal("AAPL is above 200.00 at 210.00"),
+					"Position":  Not(BeNil()),
+				})))
+			})
+
+			It("should not notify again for the same condition within the rate limit", func() {
+				notifier := notify.NewNotifier(notify.Config{
+					Rules: []c.ConfigNotifyRule{
+						{Symbol: "AAPL", PriceAbove: 200, ChangePercent: 5, Webhook: server.URL() + "/webhook"},
+					},
+					RateLimit: time.Hour,
+				})
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+
+			It("should space deliveries to the same webhook by the interval when several rules are met at once", func() {
+				times := make([]time.Time, 0)
+				server.RouteToHandler(http.MethodPost, "/webhook", func(_ http.ResponseWriter, _ *http.Request) {
+					times = append(times, time.Now())
+				})
+
+				notifier := notify.NewNotifier(notify.Config{
+					Rules: []c.ConfigNotifyRule{
+						{Symbol: "AAPL", PriceAbove: 200, Webhook: server.URL() + "/webhook"},
+						{Symbol: "AAPL", ChangePercent: 5, Webhook: server.URL() + "/webhook"},
+					},
+					Interval: 100 * time.Millisecond,
+				})
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				Expect(times).To(HaveLen(2))
+				Expect(times[1].Sub(times[0]).Abs()).To(BeNumerically(">=", 100*time.Millisecond))
+			})
+
+			It("should evaluate the rules in the currency of the quote when positions are shown in another currency", func() {
+				assets[0].Currency.ToCurrencyCode = "EUR"
+				assets[0].QuotePrice = c.QuotePrice{Price: 105, Change: 6, ChangePercent: 6.06}
+
+				notifier := notify.NewNotifier(notify.Config{
+					Rules: []c.ConfigNotifyRule{
+						{Symbol: "AAPL", PriceAbove: 200, Webhook: server.URL() + "/webhook"},
+						{Symbol: "AAPL", PriceBelow: 150, Webhook: server.URL() + "/webhook"},
+					},
+				})
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				Expect(payloads).To(HaveExactElements(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Rule":     Equal("price-above"),
+					"Currency": Equal("USD"),
+					"Price":    Equal(210.0),
+				})))
+			})
+
+		})
+
+		When("delivery to the webhook fails", func() {
+
+			It("should retry the delivery", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusInternalServerError, ""),
+					ghttp.RespondWith(http.StatusOK, ""),
+				)
+
+				notifier := notify.NewNotifier(notify.Config{
+					Rules: []c.ConfigNotifyRule{{Symbol: "AAPL", PriceAbove: 200, Webhook: server.URL() + "/webhook"}},
+				}, notify.WithRetryDelay(time.Millisecond))
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+
+			It("should log the error once all retries have failed", func() {
+				var logs bytes.Buffer
+				server.RouteToHandler(http.MethodPost, "/webhook", ghttp.RespondWith(http.StatusBadGateway, ""))
+
+				notifier := notify.NewNotifier(notify.Config{
+					Rules:   []c.ConfigNotifyRule{{Symbol: "AAPL", PriceAbove: 200, Webhook: server.URL() + "/webhook"}},
+					Retries: 2,
+					Logger:  log.New(&logs, "", 0),
+				}, notify.WithRetryDelay(time.Millisecond))
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+				Expect(logs.String()).To(ContainSubstring("failed to notify webhook " + server.URL() + "/webhook for AAPL: request failed with status 502"))
+			})
+
+		})
+
+		When("a rule has a command", func() {
+
+			It("should run the command with the quote in environment variables", func() {
+				outputPath := filepath.Join(GinkgoT().TempDir(), "output")
+
+				notifier := notify.NewNotifier(notify.Config{
+					Rules: []c.ConfigNotifyRule{{
+						Symbol:        "AAPL",
+						ChangePercent: 5,
+						Command:       `printf '%s|%s|%s|%s' "$TICKER_SYMBOL" "$TICKER_RULE" "$TICKER_PRICE" "$TICKER_POSITION_VALUE" > ` + outputPath,
+					}},
+				})
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				output, err := os.ReadFile(outputPath)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("AAPL|change-percent|210|2100"))
+			})
+
+			It("should log the error when the command fails", func() {
+				var logs bytes.Buffer
+
+				notifier := notify.NewNotifier(notify.Config{
+					Rules:   []c.ConfigNotifyRule{{Symbol: "AAPL", ChangePercent: 5, Command: "echo failed && exit 1"}},
+					Retries: 1,
+					Logger:  log.New(&logs, "", 0),
+				}, notify.WithRetryDelay(time.Millisecond))
+
+				notifier.Notify(assetQuotes, assets, positionSummary)
+				notifier.Wait()
+
+				Expect(logs.String()).To(ContainSubstring("failed to notify command echo failed && exit 1 for AAPL: exit status 1: failed"))
+			})
+
+		})
+
+	})
+
+})
@@ -0,0 +1,405 @@
+package print //nolint:predeclared
+
+import (
+	"html"
+	"strings"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/ui/util"
+	"github.com/muesli/reflow/ansi"
+)
+
+const (
+	formatCSV      = "csv"
+	formatJSON     = "json"
+	formatTable    = "table"
+	formatMarkdown = "markdown"
+	formatHTML     = "html"
+)
+
+// formatter renders tables of assets, summaries, or lots in a format meant to be read rather than parsed
+type formatter interface {
+	format(tables []table) string
+}
+
+// table is printed output with a row for each asset, lot, or group
+type table struct {
+	title   string // Name of the group when all groups are printed
+	columns []column
+	rows    [][]cell
+}
+
+type column struct {
+	name       string
+	alignRight bool
+}
+
+// cell is the text of a value in a table and, for a change in price or value, the percent change used to color it
+type cell struct {
+	text          string
+	changePercent float64
+	isChange      bool
+}
+
+// getFormatter returns the formatter for the format or false when the format is JSON or CSV which are not rendered from tables
+func getFormatter(format string, styles c.Styles, now time.Time) (formatter, bool) {
+	switch format {
+	case formatTable:
+		return tableFormatter{styles: styles}, true
+	case formatMarkdown:
+		return markdownFormatter{}, true
+	case formatHTML:
+		return htmlFormatter{now: now}, true
+	}
+
+	return nil, false
+}
+
+func getAssetTables(groups []groupAssets, allGroups bool) []table {
+
+	tables := make([]table, 0, len(groups))
+
+	for _, group := range groups {
+		t := table{
+			columns: []column{
+				{name: "Symbol"},
+				{name: "Name"},
+				{name: "Price", alignRight: true},
+				{name: "Day Change", alignRight: true},
+				{name: "Quantity", alignRight: true},
+				{name: "Value", alignRight: true},
+				{name: "Cost", alignRight: true},
+				{name: "Total Change", alignRight: true},
+				{name: "Weight", alignRight: true},
+			},
+			rows: make([][]cell, 0, len(group.assets)),
+		}
+
+		if allGroups {
+			t.title = group.name
+		}
+
+		for _, a := range group.assets {
+			isVariablePrecision := a.Meta.IsVariablePrecision
+			row := []cell{
+				{text: a.Symbol},
+				{text: a.Name},
+				{text: util.ConvertFloatToString(a.QuotePrice.Price, isVariablePrecision)},
+				newChangeCell(a.QuotePrice.Change, a.QuotePrice.ChangePercent, isVariablePrecision),
+			}
+
+			if a.Position.Quantity == 0 {
+				row = append(row, cell{}, cell{}, cell{}, cell{}, cell{})
+			} else {
+				row = append(row,
+					cell{text: util.ConvertFloatToString(a.Position.Quantity, isVariablePrecision)},
+					cell{text: util.ConvertFloatToString(a.Position.Value, false)},
+					cell{text: util.ConvertFloatToString(a.Position.Cost, false)},
+					newChangeCell(a.Position.TotalChange.Amount, a.Position.TotalChange.Percent, false),
+					cell{text: util.ConvertFloatToString(a.Position.Weight, false) + "%"},
+				)
+			}
+
+			t.rows = append(t.rows, row)
+		}
+
+		tables = append(tables, t)
+	}
+
+	return tables
+}
+
+// getSummaryTables returns a table with the summary of each group and, when all groups are printed, the total across groups
+func getSummaryTables(groups []groupAssets, total asset.PositionSummary, allGroups bool) []table {
+
+	t := table{
+		columns: []column{
+			{name: "Value", alignRight: true},
+			{name: "Cost", alignRight: true},
+			{name: "Day Change", alignRight: true},
+			{name: "Total Change", alignRight: true},
+			{name: "Realized", alignRight: true},
+		},
+	}
+
+	getRow := func(summary asset.PositionSummary) []cell {
+		return []cell{
+			{text: util.ConvertFloatToString(summary.Value, false)},
+			{text: util.ConvertFloatToString(summary.Cost, false)},
+			newChangeCell(summary.DayChange.Amount, summary.DayChange.Percent, false),
+			newChangeCell(summary.TotalChange.Amount, summary.TotalChange.Percent, false),
+			{text: util.ConvertFloatToString(summary.Realized, false), changePercent: summary.Realized, isChange: true},
+		}
+	}
+
+	if !allGroups {
+		t.rows = [][]cell{getRow(groups[0].positionSummary)}
+
+		return []table{t}
+	}
+
+	t.columns = append([]column{{name: "Group"}}, t.columns...)
+
+	for _, group := range groups {
+		t.rows = append(t.rows, append([]cell{{text: group.name}}, getRow(group.positionSummary)...))
+	}
+
+	t.rows = append(t.rows, append([]cell{{text: "Total"}}, getRow(total)...))
+
+	return []table{t}
+}
+
+func getLotTables(groups []groupLots, allGroups bool) []table {
+
+	tables := make([]table, 0, len(groups))
+
+	for _, group := range groups {
+		t := table{
+			columns: []column{
+				{name: "Symbol"},
+				{name: "Name"},
+				{name: "Date"},
+				{name: "Quantity", alignRight: true},
+				{name: "Unit Cost", alignRight: true},
+				{name: "Cost", alignRight: true},
+				{name: "Value", alignRight: true},
+				{name: "Total Change", alignRight: true},
+				{name: "Days Held", alignRight: true},
+				{name: "Holding Period"},
+			},
+			rows: make([][]cell, 0, len(group.lotPositions)),
+		}
+
+		if allGroups {
+			t.title = group.name
+		}
+
+		for _, lot := range group.lotPositions {
+			t.rows = append(t.rows, []cell{
+				{text: lot.Symbol},
+				{text: lot.Name},
+				{text: lot.Date},
+				{text: util.ConvertFloatToString(lot.Quantity, true)},
+				{text: util.ConvertFloatToString(lot.UnitCost, true)},
+				{text: util.ConvertFloatToString(lot.Cost, false)},
+				{text: util.ConvertFloatToString(lot.Value, false)},
+				newChangeCell(lot.TotalChange.Amount, lot.TotalChange.Percent, false),
+				{text: formatDaysHeld(lot.DaysHeld)},
+				{text: string(lot.HoldingPeriod)},
+			})
+		}
+
+		tables = append(tables, t)
+	}
+
+	return tables
+}
+
+// newChangeCell returns a cell with the change amount and percent with an arrow showing the direction of the change
+func newChangeCell(amount float64, percent float64, isVariablePrecision bool) cell {
+
+	text := util.ConvertFloatToString(amount, isVariablePrecision) + " (" + util.ConvertFloatToString(percent, false) + "%)"
+
+	if amount > 0.0 {
+		text = "↑ " + text
+	}
+
+	if amount < 0.0 {
+		text = "↓ " + text
+	}
+
+	return cell{text: text, changePercent: percent, isChange: true}
+}
+
+// tableFormatter renders aligned columns colored with the configured color scheme for a terminal
+type tableFormatter struct {
+	styles c.Styles
+}
+
+func (f tableFormatter) format(tables []table) string {
+
+	sections := make([]string, 0, len(tables))
+
+	for _, t := range tables {
+		var sb strings.Builder
+
+		widths := make([]int, len(t.columns))
+		for i, col := range t.columns {
+			widths[i] = ansi.PrintableRuneWidth(col.name)
+		}
+
+		for _, row := range t.rows {
+			for i, cl := range row {
+				widths[i] = max(widths[i], ansi.PrintableRuneWidth(cl.text))
+			}
+		}
+
+		if t.title != "" {
+			sb.WriteString(f.styles.TextBold(t.title) + "\n")
+		}
+
+		header := make([]string, len(t.columns))
+		for i, col := range t.columns {
+			header[i] = f.styles.TextLabel(pad(col.name, widths[i], col.alignRight))
+		}
+
+		sb.WriteString(strings.TrimRight(strings.Join(header, "  "), " "))
+
+		for _, row := range t.rows {
+			line := make([]string, len(row))
+
+			for i, cl := range row {
+				text := pad(cl.text, widths[i], t.columns[i].alignRight)
+
+				if cl.isChange {
+					line[i] = f.styles.TextPrice(cl.changePercent, text)
+
+					continue
+				}
+
+				line[i] = f.styles.Text(text)
+			}
+
+			sb.WriteString("\n" + strings.TrimRight(strings.Join(line, "  "), " "))
+		}
+
+		sections = append(sections, sb.String())
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func pad(text string, width int, alignRight bool) string {
+
+	padding := strings.Repeat(" ", max(width-ansi.PrintableRuneWidth(text), 0))
+
+	if alignRight {
+		return padding + text
+	}
+
+	return text + padding
+}
+
+// markdownFormatter renders GitHub flavored Markdown tables
+type markdownFormatter struct{}
+
+func (f markdownFormatter) format(tables []table) string {
+
+	sections := make([]string, 0, len(tables))
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+
+	for _, t := range tables {
+		var sb strings.Builder
+
+		if t.title != "" {
+			sb.WriteString("## " + escape.Replace(t.title) + "\n\n")
+		}
+
+		header := make([]string, len(t.columns))
+		divider := make([]string, len(t.columns))
+
+		for i, col := range t.columns {
+			header[i] = escape.Replace(col.name)
+			divider[i] = "---"
+
+			if col.alignRight {
+				divider[i] = "---:"
+			}
+		}
+
+		sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+		sb.WriteString("| " + strings.Join(divider, " | ") + " |")
+
+		for _, row := range t.rows {
+			line := make([]string, len(row))
+			for i, cl := range row {
+				line[i] = escape.Replace(cl.text)
+			}
+
+			sb.WriteString("\n| " + strings.Join(line, " | ") + " |")
+		}
+
+		sections = append(sections, sb.String())
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// htmlFormatter renders a self-contained HTML page with inline styles
+type htmlFormatter struct {
+	now time.Time
+}
+
+const htmlStyle = `body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif;margin:2em;color:#24292f}` +
+	`table{border-collapse:collapse;margin-bottom:2em}` +
+	`th,td{padding:0.3em 0.8em;border-bottom:1px solid #d0d7de;text-align:left}` +
+	`th{color:#57606a;font-weight:600}` +
+	`.num{text-align:right;font-variant-numeric:tabular-nums}` +
+	`.up{color:#1a7f37}.down{color:#cf222e}` +
+	`.time{color:#57606a}`
+
+func (f htmlFormatter) format(tables []table) string {
+
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>ticker</title>\n")
+	sb.WriteString("<style>" + htmlStyle + "</style>\n</head>\n<body>\n")
+	sb.WriteString("<h1>ticker</h1>\n")
+	sb.WriteString("<p class=\"time\">Generated <time datetime=\"" + f.now.Format(time.RFC3339) + "\">" + f.now.Format("2006-01-02 15:04:05 MST") + "</time></p>\n")
+
+	for _, t := range tables {
+		if t.title != "" {
+			sb.WriteString("<h2>" + html.EscapeString(t.title) + "</h2>\n")
+		}
+
+		sb.WriteString("<table>\n<thead>\n<tr>")
+
+		for _, col := range t.columns {
+			sb.WriteString("<th" + htmlClass(col.alignRight, cell{}) + ">" + html.EscapeString(col.name) + "</th>")
+		}
+
+		sb.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+		for _, row := range t.rows {
+			sb.WriteString("<tr>")
+
+			for i, cl := range row {
+				sb.WriteString("<td" + htmlClass(t.columns[i].alignRight, cl) + ">" + html.EscapeString(cl.text) + "</td>")
+			}
+
+			sb.WriteString("</tr>\n")
+		}
+
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	sb.WriteString("</body>\n</html>")
+
+	return sb.String()
+}
+
+// htmlClass returns the class attribute to align numbers and color changes
+func htmlClass(alignRight bool, cl cell) string {
+
+	classes := make([]string, 0, 2)
+
+	if alignRight {
+		classes = append(classes, "num")
+	}
+
+	if cl.isChange && cl.changePercent > 0 {
+		classes = append(classes, "up")
+	}
+
+	if cl.isChange && cl.changePercent < 0 {
+		classes = append(classes, "down")
+	}
+
+	if len(classes) == 0 {
+		return ""
+	}
+
+	return " class=\"" + strings.Join(classes, " ") + "\""
+}
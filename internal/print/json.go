@@ -0,0 +1,437 @@
+package print //nolint:predeclared
+
+import (
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// SchemaVersionTyped is the JSON schema version with numeric values and all quote, position, currency, and exchange fields
+const SchemaVersionTyped = 2
+
+type jsonAssetsDocument struct {
+	SchemaVersion int         `json:"schema_version"`
+	Assets        []jsonAsset `json:"assets"`
+}
+
+type jsonSummaryDocument struct {
+	SchemaVersion int              `json:"schema_version"`
+	Summary       jsonSummaryTyped `json:"summary"`
+}
+
+type jsonLotsDocument struct {
+	SchemaVersion int            `json:"schema_version"`
+	Lots          []jsonLotTyped `json:"lots"`
+}
+
+type jsonGroupAssetsDocument struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Groups        []jsonGroupAssetsTyped `json:"groups"`
+}
+
+type jsonGroupAssetsTyped struct {
+	Name   string      `json:"name"`
+	Assets []jsonAsset `json:"assets"`
+}
+
+type jsonGroupSummariesDocument struct {
+	SchemaVersion int                     `json:"schema_version"`
+	Groups        []jsonGroupSummaryTyped `json:"groups"`
+	Total         jsonSummaryTotal        `json:"total"`
+}
+
+type jsonGroupSummaryTyped struct {
+	Name    string           `json:"name"`
+	Summary jsonSummaryTyped `json:"summary"`
+}
+
+// jsonSummaryTotal is the sum of the summaries of all groups in the configured currency
+type jsonSummaryTotal struct {
+	Currency           string  `json:"currency"` // Empty when no currency is configured
+	Value              float64 `json:"value"`
+	Cost               float64 `json:"cost"`
+	DayChangeAmount    float64 `json:"day_change_amount"`
+	DayChangePercent   float64 `json:"day_change_percent"`
+	TotalChangeAmount  float64 `json:"total_change_amount"`
+	TotalChangePercent float64 `json:"total_change_percent"`
+	Realized           float64 `json:"realized"`
+}
+
+type jsonGroupLotsDocument struct {
+	SchemaVersion int                  `json:"schema_version"`
+	Groups        []jsonGroupLotsTyped `json:"groups"`
+}
+
+type jsonGroupLotsTyped struct {
+	Name string         `json:"name"`
+	Lots []jsonLotTyped `json:"lots"`
+}
+
+type jsonAsset struct {
+	Symbol        string            `json:"symbol"`
+	Name          string            `json:"name"`
+	Class         string            `json:"class"`
+	Source        string            `json:"source"`
+	Currency      jsonCurrency      `json:"currency"`
+	Quote         jsonQuote         `json:"quote"`
+	QuoteExtended jsonQuoteExtended `json:"quote_extended"`
+	QuoteDepth    *jsonQuoteDepth   `json:"quote_depth"`   // Null when there is no best bid and ask
+	QuoteFutures  *jsonQuoteFutures `json:"quote_futures"` // Null when the asset is not a futures contract
+	Position      *jsonPosition     `json:"position"`      // Null when there are no lots for the symbol
+	Exchange      jsonExchange      `json:"exchange"`
+}
+
+type jsonCurrency struct {
+	Code          string `json:"code"`
+	ConvertedCode string `json:"converted_code"`
+}
+
+type jsonQuote struct {
+	Price          float64 `json:"price"`
+	PricePrevClose float64 `json:"price_prev_close"`
+	PriceOpen      float64 `json:"price_open"`
+	PriceDayHigh   float64 `json:"price_day_high"`
+	PriceDayLow    float64 `json:"price_day_low"`
+	Change         float64 `json:"change"`
+	ChangePercent  float64 `json:"change_percent"`
+}
+
+type jsonQuoteExtended struct {
+	FiftyTwoWeekHigh float64 `json:"fifty_two_week_high"`
+	FiftyTwoWeekLow  float64 `json:"fifty_two_week_low"`
+	MarketCap        float64 `json:"market_cap"`
+	Volume           float64 `json:"volume"`
+}
+
+type jsonQuoteDepth struct {
+	BidPrice      float64 `json:"bid_price"`
+	BidSize       float64 `json:"bid_size"`
+	AskPrice      float64 `json:"ask_price"`
+	AskSize       float64 `json:"ask_size"`
+	Spread        float64 `json:"spread"`
+	SpreadPercent float64 `json:"spread_percent"`
+}
+
+type jsonQuoteFutures struct {
+	SymbolUnderlying string  `json:"symbol_underlying"`
+	IndexPrice       float64 `json:"index_price"`
+	Basis            float64 `json:"basis"`
+	OpenInterest     float64 `json:"open_interest"`
+	Expiry           string  `json:"expiry"`
+	ContractSize     float64 `json:"contract_size"`
+}
+
+type jsonPosition struct {
+	Quantity           float64 `json:"quantity"`
+	Value              float64 `json:"value"`
+	Cost               float64 `json:"cost"`
+	UnitValue          float64 `json:"unit_value"`
+	UnitCost           float64 `json:"unit_cost"`
+	Weight             float64 `json:"weight"`
+	DayChangeAmount    float64 `json:"day_change_amount"`
+	DayChangePercent   float64 `json:"day_change_percent"`
+	TotalChangeAmount  float64 `json:"total_change_amount"`
+	TotalChangePercent float64 `json:"total_change_percent"`
+	Realized           float64 `json:"realized"`
+	AnnualizedReturn   float64 `json:"annualized_return"`
+}
+
+type jsonExchange struct {
+	Name                    string  `json:"name"`
+	State                   string  `json:"state"`
+	Delay                   float64 `json:"delay"`
+	DelayText               string  `json:"delay_text"`
+	IsActive                bool    `json:"is_active"`
+	IsRegularTradingSession bool    `json:"is_regular_trading_session"`
+}
+
+type jsonSummaryTyped struct {
+	Value              float64        `json:"value"`
+	Cost               float64        `json:"cost"`
+	DayChangeAmount    float64        `json:"day_change_amount"`
+	DayChangePercent   float64        `json:"day_change_percent"`
+	TotalChangeAmount  float64        `json:"total_change_amount"`
+	TotalChangePercent float64        `json:"total_change_percent"`
+	Realized           float64        `json:"realized"`
+	AnnualizedReturn   float64        `json:"annualized_return"`
+	Benchmark          *jsonBenchmark `json:"benchmark"` // Null when the group has no benchmark
+}
+
+type jsonBenchmark struct {
+	Symbol          string  `json:"symbol"`
+	ChangePercent   float64 `json:"change_percent"`
+	RelativePercent float64 `json:"relative_percent"`
+}
+
+type jsonLotTyped struct {
+	Symbol             string  `json:"symbol"`
+	Name               string  `json:"name"`
+	Currency           string  `json:"currency"`
+	Date               *string `json:"date"`
+	Quantity           float64 `json:"quantity"`
+	UnitCost           float64 `json:"unit_cost"`
+	Cost               float64 `json:"cost"`
+	Value              float64 `json:"value"`
+	TotalChangeAmount  float64 `json:"total_change_amount"`
+	TotalChangePercent float64 `json:"total_change_percent"`
+	DaysHeld           *int    `json:"days_held"`
+	HoldingPeriod      *string `json:"holding_period"`
+}
+
+func convertAssetsToTypedJSON(assets []c.Asset) string {
+	document := jsonAssetsDocument{
+		SchemaVersion: SchemaVersionTyped,
+		Assets:        make([]jsonAsset, 0, len(assets)),
+	}
+
+	for _, a := range assets {
+		document.Assets = append(document.Assets, newJSONAsset(a))
+	}
+
+	return marshal(document)
+}
+
+func convertSummaryToTypedJSON(summary asset.PositionSummary) string {
+	document := jsonSummaryDocument{
+		SchemaVersion: SchemaVersionTyped,
+		Summary:       newJSONSummaryTyped(summary),
+	}
+
+	return marshal(document)
+}
+
+func convertGroupAssetsToTypedJSON(groups []groupAssets) string {
+	document := jsonGroupAssetsDocument{
+		SchemaVersion: SchemaVersionTyped,
+		Groups:        make([]jsonGroupAssetsTyped, 0, len(groups)),
+	}
+
+	for _, group := range groups {
+		groupAssets := jsonGroupAssetsTyped{Name: group.name, Assets: make([]jsonAsset, 0, len(group.assets))}
+
+		for _, a := range group.assets {
+			groupAssets.Assets = append(groupAssets.Assets, newJSONAsset(a))
+		}
+
+		document.Groups = append(document.Groups, groupAssets)
+	}
+
+	return marshal(document)
+}
+
+func convertGroupSummariesToTypedJSON(groups []groupAssets, total asset.PositionSummary, currency string) string {
+	document := jsonGroupSummariesDocument{
+		SchemaVersion: SchemaVersionTyped,
+		Groups:        make([]jsonGroupSummaryTyped, 0, len(groups)),
+		Total: jsonSummaryTotal{
+			Currency:           currency,
+			Value:              total.Value,
+			Cost:               total.Cost,
+			DayChangeAmount:    total.DayChange.Amount,
+			DayChangePercent:   total.DayChange.Percent,
+			TotalChangeAmount:  total.TotalChange.Amount,
+			TotalChangePercent: total.TotalChange.Percent,
+			Realized:           total.Realized,
+		},
+	}
+
+	for _, group := range groups {
+		document.Groups = append(document.Groups, jsonGroupSummaryTyped{
+			Name:    group.name,
+			Summary: newJSONSummaryTyped(group.positionSummary),
+		})
+	}
+
+	return marshal(document)
+}
+
+func convertGroupLotsToTypedJSON(groups []groupLots) string {
+	document := jsonGroupLotsDocument{
+		SchemaVersion: SchemaVersionTyped,
+		Groups:        make([]jsonGroupLotsTyped, 0, len(groups)),
+	}
+
+	for _, group := range groups {
+		groupLots := jsonGroupLotsTyped{Name: group.name, Lots: make([]jsonLotTyped, 0, len(group.lotPositions))}
+
+		for _, lot := range group.lotPositions {
+			groupLots.Lots = append(groupLots.Lots, newJSONLotTyped(lot))
+		}
+
+		document.Groups = append(document.Groups, groupLots)
+	}
+
+	return marshal(document)
+}
+
+func newJSONSummaryTyped(summary asset.PositionSummary) jsonSummaryTyped {
+	row := jsonSummaryTyped{
+		Value:              summary.Value,
+		Cost:               summary.Cost,
+		DayChangeAmount:    summary.DayChange.Amount,
+		DayChangePercent:   summary.DayChange.Percent,
+		TotalChangeAmount:  summary.TotalChange.Amount,
+		TotalChangePercent: summary.TotalChange.Percent,
+		Realized:           summary.Realized,
+		AnnualizedReturn:   summary.AnnualizedReturn,
+	}
+
+	if summary.Benchmark.Symbol != "" {
+		row.Benchmark = &jsonBenchmark{
+			Symbol:          summary.Benchmark.Symbol,
+			ChangePercent:   summary.Benchmark.ChangePercent,
+			RelativePercent: summary.Benchmark.RelativePercent,
+		}
+	}
+
+	return row
+}
+
+func convertLotsToTypedJSON(lotPositions []asset.LotPosition) string {
+	document := jsonLotsDocument{
+		SchemaVersion: SchemaVersionTyped,
+		Lots:          make([]jsonLotTyped, 0, len(lotPositions)),
+	}
+
+	for _, lot := range lotPositions {
+		document.Lots = append(document.Lots, newJSONLotTyped(lot))
+	}
+
+	return marshal(document)
+}
+
+func newJSONLotTyped(lot asset.LotPosition) jsonLotTyped {
+	row := jsonLotTyped{
+		Symbol:             lot.Symbol,
+		Name:               lot.Name,
+		Currency:           lot.Currency,
+		Quantity:           lot.Quantity,
+		UnitCost:           lot.UnitCost,
+		Cost:               lot.Cost,
+		Value:              lot.Value,
+		TotalChangeAmount:  lot.TotalChange.Amount,
+		TotalChangePercent: lot.TotalChange.Percent,
+	}
+
+	if lot.DaysHeld >= 0 {
+		date := lot.Date
+		daysHeld := lot.DaysHeld
+		holdingPeriod := string(lot.HoldingPeriod)
+		row.Date = &date
+		row.DaysHeld = &daysHeld
+		row.HoldingPeriod = &holdingPeriod
+	}
+
+	return row
+}
+
+func newJSONAsset(a c.Asset) jsonAsset {
+	row := jsonAsset{
+		Symbol: a.Symbol,
+		Name:   a.Name,
+		Class:  getClassName(a.Class),
+		Source: a.QuoteSource.String(),
+		Currency: jsonCurrency{
+			Code:          a.Currency.FromCurrencyCode,
+			ConvertedCode: a.Currency.ToCurrencyCode,
+		},
+		Quote: jsonQuote{
+			Price:          a.QuotePrice.Price,
+			PricePrevClose: a.QuotePrice.PricePrevClose,
+			PriceOpen:      a.QuotePrice.PriceOpen,
+			PriceDayHigh:   a.QuotePrice.PriceDayHigh,
+			PriceDayLow:    a.QuotePrice.PriceDayLow,
+			Change:         a.QuotePrice.Change,
+			ChangePercent:  a.QuotePrice.ChangePercent,
+		},
+		QuoteExtended: jsonQuoteExtended{
+			FiftyTwoWeekHigh: a.QuoteExtended.FiftyTwoWeekHigh,
+			FiftyTwoWeekLow:  a.QuoteExtended.FiftyTwoWeekLow,
+			MarketCap:        a.QuoteExtended.MarketCap,
+			Volume:           a.QuoteExtended.Volume,
+		},
+		Exchange: jsonExchange{
+			Name:                    a.Exchange.Name,
+			State:                   getExchangeStateName(a.Exchange.State),
+			Delay:                   a.Exchange.Delay,
+			DelayText:               a.Exchange.DelayText,
+			IsActive:                a.Exchange.IsActive,
+			IsRegularTradingSession: a.Exchange.IsRegularTradingSession,
+		},
+	}
+
+	if a.QuoteDepth.BidPrice != 0 || a.QuoteDepth.AskPrice != 0 {
+		row.QuoteDepth = &jsonQuoteDepth{
+			BidPrice:      a.QuoteDepth.BidPrice,
+			BidSize:       a.QuoteDepth.BidSize,
+			AskPrice:      a.QuoteDepth.AskPrice,
+			AskSize:       a.QuoteDepth.AskSize,
+			Spread:        a.QuoteDepth.Spread,
+			SpreadPercent: a.QuoteDepth.SpreadPercent,
+		}
+	}
+
+	if a.Class == c.AssetClassFuturesContract {
+		row.QuoteFutures = &jsonQuoteFutures{
+			SymbolUnderlying: a.QuoteFutures.SymbolUnderlying,
+			IndexPrice:       a.QuoteFutures.IndexPrice,
+			Basis:            a.QuoteFutures.Basis,
+			OpenInterest:     a.QuoteFutures.OpenInterest,
+			Expiry:           a.QuoteFutures.Expiry,
+			ContractSize:     a.QuoteFutures.ContractSize,
+		}
+	}
+
+	if a.Position.Quantity != 0 {
+		row.Position = &jsonPosition{
+			Quantity:           a.Position.Quantity,
+			Value:              a.Position.Value,
+			Cost:               a.Position.Cost,
+			UnitValue:          a.Position.UnitValue,
+			UnitCost:           a.Position.UnitCost,
+			Weight:             a.Position.Weight,
+			DayChangeAmount:    a.Position.DayChange.Amount,
+			DayChangePercent:   a.Position.DayChange.Percent,
+			TotalChangeAmount:  a.Position.TotalChange.Amount,
+			TotalChangePercent: a.Position.TotalChange.Percent,
+			Realized:           a.Position.Realized,
+			AnnualizedReturn:   a.Position.AnnualizedReturn,
+		}
+	}
+
+	return row
+}
+
+func getClassName(class c.AssetClass) string {
+	switch class {
+	case c.AssetClassCash:
+		return "cash"
+	case c.AssetClassStock:
+		return "stock"
+	case c.AssetClassCryptocurrency:
+		return "cryptocurrency"
+	case c.AssetClassPrivateSecurity:
+		return "private-security"
+	case c.AssetClassFuturesContract:
+		return "futures-contract"
+	case c.AssetClassCurrency:
+		return "currency"
+	case c.AssetClassUnknown:
+	}
+
+	return "unknown"
+}
+
+func getExchangeStateName(state c.ExchangeState) string {
+	switch state {
+	case c.ExchangeStateOpen:
+		return "open"
+	case c.ExchangeStatePremarket:
+		return "premarket"
+	case c.ExchangeStatePostmarket:
+		return "postmarket"
+	case c.ExchangeStateClosed:
+		return "closed"
+	}
+
+	return "unknown"
+}
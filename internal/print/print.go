@@ -2,13 +2,19 @@ package print //nolint:predeclared
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/achannarasappa/ticker/v5/internal/asset"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+	"github.com/achannarasappa/ticker/v5/internal/notify"
 	"github.com/achannarasappa/ticker/v5/internal/ui/util"
 
 	"github.com/spf13/cobra"
@@ -16,9 +22,18 @@ import (
 
 // Options to configure print behavior
 type Options struct {
-	Format string
+	Format           string
+	SchemaVersion    int    // JSON schema version; defaults to 1 with values formatted as strings
+	IncludeWatchlist bool   // Include assets without a position
+	Group            string // Name of the group to print; defaults to the first group
+	AllGroups        bool   // Print every group with output nested by group
+	Watch            bool   // Stream a line of JSON for each asset update and summary recalculation until interrupted
+	Notify           bool   // Deliver notifications for notification rules met by the printed assets
 }
 
+// groupTotal is the name of the row with the total across all groups in CSV output
+const groupTotal = "total"
+
 type jsonRow struct {
 	Name     string `json:"name"`
 	Symbol   string `json:"symbol"`
@@ -38,31 +53,162 @@ type jsonSummary struct {
 	TotalChangePercent string `json:"total_change_percent"`
 }
 
-func convertAssetsToCSV(assets []c.Asset) string {
-	rows := [][]string{
-		{"name", "symbol", "price", "value", "cost", "quantity", "weight"},
+type jsonLot struct {
+	Symbol             string `json:"symbol"`
+	Name               string `json:"name"`
+	Currency           string `json:"currency"`
+	Date               string `json:"date"`
+	Quantity           string `json:"quantity"`
+	UnitCost           string `json:"unit_cost"`
+	Cost               string `json:"cost"`
+	Value              string `json:"value"`
+	TotalChangeAmount  string `json:"total_change_amount"`
+	TotalChangePercent string `json:"total_change_percent"`
+	DaysHeld           string `json:"days_held"`
+	HoldingPeriod      string `json:"holding_period"`
+}
+
+type jsonGroupRows struct {
+	Group  string    `json:"group"`
+	Assets []jsonRow `json:"assets"`
+}
+
+type jsonGroupSummary struct {
+	Group string `json:"group"`
+	jsonSummary
+}
+
+type jsonGroupSummaries struct {
+	Groups []jsonGroupSummary `json:"groups"`
+	Total  jsonSummary        `json:"total"`
+}
+
+type jsonGroupLots struct {
+	Group string    `json:"group"`
+	Lots  []jsonLot `json:"lots"`
+}
+
+// groupAssets is the assets and position summary of a group to print
+type groupAssets struct {
+	name            string
+	assets          []c.Asset
+	positionSummary asset.PositionSummary
+}
+
+// groupLots is the lots of a group to print
+type groupLots struct {
+	name         string
+	lotPositions []asset.LotPosition
+}
+
+// filterAssets returns the assets with a position and, when includeWatchlist is set, all other assets
+func filterAssets(assets []c.Asset, includeWatchlist bool) []c.Asset {
+
+	if includeWatchlist {
+		return assets
 	}
 
+	filtered := make([]c.Asset, 0, len(assets))
+
 	for _, asset := range assets {
 		if asset.Position.Quantity > 0 {
-			rows = append(rows, []string{
-				asset.Name,
-				asset.Symbol,
-				util.ConvertFloatToString(asset.QuotePrice.Price, true),
-				util.ConvertFloatToString(asset.Position.Value, true),
-				util.ConvertFloatToString(asset.Position.Cost, true),
-				util.ConvertFloatToString(asset.Position.Quantity, true),
-				util.ConvertFloatToString(asset.Position.Weight, true),
-			})
+			filtered = append(filtered, asset)
 		}
 	}
 
-	b := new(bytes.Buffer)
-	w := csv.NewWriter(b)
-	//nolint:errcheck
-	w.WriteAll(rows)
+	return filtered
+}
 
-	return b.String()
+func getAssetCSVRecord(asset c.Asset) []string {
+	return []string{
+		asset.Name,
+		asset.Symbol,
+		util.ConvertFloatToString(asset.QuotePrice.Price, true),
+		util.ConvertFloatToString(asset.Position.Value, true),
+		util.ConvertFloatToString(asset.Position.Cost, true),
+		util.ConvertFloatToString(asset.Position.Quantity, true),
+		util.ConvertFloatToString(asset.Position.Weight, true),
+	}
+}
+
+func newJSONRow(asset c.Asset) jsonRow {
+	return jsonRow{
+		Name:     asset.Name,
+		Symbol:   asset.Symbol,
+		Price:    fmt.Sprintf("%f", asset.QuotePrice.Price),
+		Value:    fmt.Sprintf("%f", asset.Position.Value),
+		Cost:     fmt.Sprintf("%f", asset.Position.Cost),
+		Quantity: fmt.Sprintf("%f", asset.Position.Quantity),
+		Weight:   fmt.Sprintf("%f", asset.Position.Weight),
+	}
+}
+
+func getSummaryCSVRecord(summary asset.PositionSummary) []string {
+	return []string{
+		fmt.Sprintf("%f", summary.Value),
+		fmt.Sprintf("%f", summary.Cost),
+		fmt.Sprintf("%f", summary.DayChange.Amount),
+		fmt.Sprintf("%f", summary.DayChange.Percent),
+		fmt.Sprintf("%f", summary.TotalChange.Amount),
+		fmt.Sprintf("%f", summary.TotalChange.Percent),
+	}
+}
+
+func newJSONSummary(summary asset.PositionSummary) jsonSummary {
+	return jsonSummary{
+		TotalValue:         fmt.Sprintf("%f", summary.Value),
+		TotalCost:          fmt.Sprintf("%f", summary.Cost),
+		DayChangeAmount:    fmt.Sprintf("%f", summary.DayChange.Amount),
+		DayChangePercent:   fmt.Sprintf("%f", summary.DayChange.Percent),
+		TotalChangeAmount:  fmt.Sprintf("%f", summary.TotalChange.Amount),
+		TotalChangePercent: fmt.Sprintf("%f", summary.TotalChange.Percent),
+	}
+}
+
+func getLotCSVRecord(lot asset.LotPosition) []string {
+	return []string{
+		lot.Symbol,
+		lot.Name,
+		lot.Currency,
+		lot.Date,
+		util.ConvertFloatToString(lot.Quantity, true),
+		util.ConvertFloatToString(lot.UnitCost, true),
+		util.ConvertFloatToString(lot.Cost, true),
+		util.ConvertFloatToString(lot.Value, true),
+		util.ConvertFloatToString(lot.TotalChange.Amount, true),
+		util.ConvertFloatToString(lot.TotalChange.Percent, true),
+		formatDaysHeld(lot.DaysHeld),
+		string(lot.HoldingPeriod),
+	}
+}
+
+func newJSONLot(lot asset.LotPosition) jsonLot {
+	return jsonLot{
+		Symbol:             lot.Symbol,
+		Name:               lot.Name,
+		Currency:           lot.Currency,
+		Date:               lot.Date,
+		Quantity:           fmt.Sprintf("%f", lot.Quantity),
+		UnitCost:           fmt.Sprintf("%f", lot.UnitCost),
+		Cost:               fmt.Sprintf("%f", lot.Cost),
+		Value:              fmt.Sprintf("%f", lot.Value),
+		TotalChangeAmount:  fmt.Sprintf("%f", lot.TotalChange.Amount),
+		TotalChangePercent: fmt.Sprintf("%f", lot.TotalChange.Percent),
+		DaysHeld:           formatDaysHeld(lot.DaysHeld),
+		HoldingPeriod:      string(lot.HoldingPeriod),
+	}
+}
+
+func convertAssetsToCSV(assets []c.Asset) string {
+	rows := [][]string{
+		{"name", "symbol", "price", "value", "cost", "quantity", "weight"},
+	}
+
+	for _, asset := range assets {
+		rows = append(rows, getAssetCSVRecord(asset))
+	}
+
+	return writeCSV(rows)
 
 }
 
@@ -70,65 +216,143 @@ func convertAssetsToJSON(assets []c.Asset) string {
 	var rows []jsonRow
 
 	for _, asset := range assets {
-		if asset.Position.Quantity > 0 {
-			rows = append(rows, jsonRow{
-				Name:     asset.Name,
-				Symbol:   asset.Symbol,
-				Price:    fmt.Sprintf("%f", asset.QuotePrice.Price),
-				Value:    fmt.Sprintf("%f", asset.Position.Value),
-				Cost:     fmt.Sprintf("%f", asset.Position.Cost),
-				Quantity: fmt.Sprintf("%f", asset.Position.Quantity),
-				Weight:   fmt.Sprintf("%f", asset.Position.Weight),
-			})
-		}
+		rows = append(rows, newJSONRow(asset))
 	}
 
 	if len(rows) == 0 {
 		return "[]"
 	}
 
-	out, err := json.Marshal(rows)
+	return marshal(rows)
 
-	if err != nil {
-		return err.Error()
+}
+
+func convertGroupAssetsToCSV(groups []groupAssets) string {
+	rows := [][]string{
+		{"group", "name", "symbol", "price", "value", "cost", "quantity", "weight"},
 	}
 
-	return string(out)
+	for _, group := range groups {
+		for _, asset := range group.assets {
+			rows = append(rows, append([]string{group.name}, getAssetCSVRecord(asset)...))
+		}
+	}
 
+	return writeCSV(rows)
 }
 
-func convertSummaryToJSON(summary asset.PositionSummary) string {
-	row := jsonSummary{
-		TotalValue:         fmt.Sprintf("%f", summary.Value),
-		TotalCost:          fmt.Sprintf("%f", summary.Cost),
-		DayChangeAmount:    fmt.Sprintf("%f", summary.DayChange.Amount),
-		DayChangePercent:   fmt.Sprintf("%f", summary.DayChange.Percent),
-		TotalChangeAmount:  fmt.Sprintf("%f", summary.TotalChange.Amount),
-		TotalChangePercent: fmt.Sprintf("%f", summary.TotalChange.Percent),
-	}
+func convertGroupAssetsToJSON(groups []groupAssets) string {
+	rows := make([]jsonGroupRows, 0, len(groups))
 
-	out, err := json.Marshal(row)
+	for _, group := range groups {
+		groupRows := jsonGroupRows{Group: group.name, Assets: make([]jsonRow, 0, len(group.assets))}
 
-	if err != nil {
-		return err.Error()
+		for _, asset := range group.assets {
+			groupRows.Assets = append(groupRows.Assets, newJSONRow(asset))
+		}
+
+		rows = append(rows, groupRows)
 	}
 
-	return string(out)
+	return marshal(rows)
+}
+
+func convertSummaryToJSON(summary asset.PositionSummary) string {
+	return marshal(newJSONSummary(summary))
 }
 
 func convertSummaryToCSV(summary asset.PositionSummary) string {
 	rows := [][]string{
 		{"total_value", "total_cost", "day_change_amount", "day_change_percent", "total_change_amount", "total_change_percent"},
-		{
-			fmt.Sprintf("%f", summary.Value),
-			fmt.Sprintf("%f", summary.Cost),
-			fmt.Sprintf("%f", summary.DayChange.Amount),
-			fmt.Sprintf("%f", summary.DayChange.Percent),
-			fmt.Sprintf("%f", summary.TotalChange.Amount),
-			fmt.Sprintf("%f", summary.TotalChange.Percent),
-		},
+		getSummaryCSVRecord(summary),
 	}
 
+	return writeCSV(rows)
+}
+
+func convertGroupSummariesToJSON(groups []groupAssets, total asset.PositionSummary) string {
+	summaries := jsonGroupSummaries{
+		Groups: make([]jsonGroupSummary, 0, len(groups)),
+		Total:  newJSONSummary(total),
+	}
+
+	for _, group := range groups {
+		summaries.Groups = append(summaries.Groups, jsonGroupSummary{
+			Group:       group.name,
+			jsonSummary: newJSONSummary(group.positionSummary),
+		})
+	}
+
+	return marshal(summaries)
+}
+
+func convertGroupSummariesToCSV(groups []groupAssets, total asset.PositionSummary) string {
+	rows := [][]string{
+		{"group", "total_value", "total_cost", "day_change_amount", "day_change_percent", "total_change_amount", "total_change_percent"},
+	}
+
+	for _, group := range groups {
+		rows = append(rows, append([]string{group.name}, getSummaryCSVRecord(group.positionSummary)...))
+	}
+
+	rows = append(rows, append([]string{groupTotal}, getSummaryCSVRecord(total)...))
+
+	return writeCSV(rows)
+}
+
+func convertLotsToCSV(lotPositions []asset.LotPosition) string {
+	rows := [][]string{
+		{"symbol", "name", "currency", "date", "quantity", "unit_cost", "cost", "value", "total_change_amount", "total_change_percent", "days_held", "holding_period"},
+	}
+
+	for _, lot := range lotPositions {
+		rows = append(rows, getLotCSVRecord(lot))
+	}
+
+	return writeCSV(rows)
+}
+
+func convertLotsToJSON(lotPositions []asset.LotPosition) string {
+	rows := make([]jsonLot, 0, len(lotPositions))
+
+	for _, lot := range lotPositions {
+		rows = append(rows, newJSONLot(lot))
+	}
+
+	return marshal(rows)
+}
+
+func convertGroupLotsToCSV(groups []groupLots) string {
+	rows := [][]string{
+		{"group", "symbol", "name", "currency", "date", "quantity", "unit_cost", "cost", "value", "total_change_amount", "total_change_percent", "days_held", "holding_period"},
+	}
+
+	for _, group := range groups {
+		for _, lot := range group.lotPositions {
+			rows = append(rows, append([]string{group.name}, getLotCSVRecord(lot)...))
+		}
+	}
+
+	return writeCSV(rows)
+}
+
+func convertGroupLotsToJSON(groups []groupLots) string {
+	rows := make([]jsonGroupLots, 0, len(groups))
+
+	for _, group := range groups {
+		groupRows := jsonGroupLots{Group: group.name, Lots: make([]jsonLot, 0, len(group.lotPositions))}
+
+		for _, lot := range group.lotPositions {
+			groupRows.Lots = append(groupRows.Lots, newJSONLot(lot))
+		}
+
+		rows = append(rows, groupRows)
+	}
+
+	return marshal(rows)
+}
+
+func writeCSV(rows [][]string) string {
 	b := new(bytes.Buffer)
 	w := csv.NewWriter(b)
 	//nolint:errcheck
@@ -137,33 +361,191 @@ func convertSummaryToCSV(summary asset.PositionSummary) string {
 	return b.String()
 }
 
+func marshal(v interface{}) string {
+	out, err := json.Marshal(v)
+
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(out)
+}
+
+// formatDaysHeld returns an empty string for lots without an acquisition date
+func formatDaysHeld(daysHeld int) string {
+	if daysHeld < 0 {
+		return ""
+	}
+
+	return strconv.Itoa(daysHeld)
+}
+
+// validateOptions returns an error when the format or JSON schema version is not supported or the group options conflict
+func validateOptions(options *Options) error {
+	switch options.Format {
+	case "", formatJSON, formatCSV, formatTable, formatMarkdown, formatHTML:
+	default:
+		return fmt.Errorf("invalid option: format '%s' is not supported, set json, csv, table, markdown, or html", options.Format) //nolint:goerr113
+	}
+
+	if options.SchemaVersion < 0 || options.SchemaVersion > SchemaVersionTyped {
+		return fmt.Errorf("invalid option: schema version %d is not supported, set 1 or %d", options.SchemaVersion, SchemaVersionTyped) //nolint:goerr113
+	}
+
+	if options.AllGroups && options.Group != "" {
+		return errors.New("invalid option: group and all-groups can not be set together")
+	}
+
+	return nil
+}
+
+// getAssetGroups returns every group when all groups are selected, the group with the selected name, or the first group
+func getAssetGroups(ctx *c.Context, options *Options) ([]c.AssetGroup, error) {
+
+	if options.AllGroups {
+		return ctx.Groups, nil
+	}
+
+	if options.Group == "" {
+		return ctx.Groups[:1], nil
+	}
+
+	for _, assetGroup := range ctx.Groups {
+		if strings.EqualFold(assetGroup.Name, options.Group) {
+			return []c.AssetGroup{assetGroup}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid option: group '%s' not found", options.Group) //nolint:goerr113
+}
+
+// getAssetGroupQuotes validates the options and synchronously gets quotes for each selected group
+func getAssetGroupQuotes(dep *c.Dependencies, ctx *c.Context, options *Options) ([]c.AssetGroupQuote, error) {
+
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
+
+	assetGroups, err := getAssetGroups(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	assetGroupQuotes := make([]c.AssetGroupQuote, 0, len(assetGroups))
+	monitors, _ := mon.NewMonitor(mon.NewConfigMonitor(*dep, *ctx))
+
+	for i, assetGroup := range assetGroups {
+		monitors.SetAssetGroup(assetGroup, i) //nolint:errcheck
+		assetGroupQuotes = append(assetGroupQuotes, monitors.GetAssetGroupQuote())
+	}
+
+	return assetGroupQuotes, nil
+}
+
+// getGroupAssets returns the assets and position summary for each selected group and delivers notifications for them when requested
+func getGroupAssets(dep *c.Dependencies, ctx *c.Context, options *Options) ([]groupAssets, error) {
+
+	assetGroupQuotes, err := getAssetGroupQuotes(dep, ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]groupAssets, 0, len(assetGroupQuotes))
+	notifier := getNotifier(*ctx, options)
+
+	for _, assetGroupQuote := range assetGroupQuotes {
+		assets, positionSummary := asset.GetAssets(*ctx, assetGroupQuote)
+
+		if notifier != nil {
+			notifier.Notify(assetGroupQuote.AssetQuotes, assets, positionSummary)
+		}
+
+		groups = append(groups, groupAssets{
+			name:            assetGroupQuote.AssetGroup.Name,
+			assets:          assets,
+			positionSummary: positionSummary,
+		})
+	}
+
+	// Wait for delivery to finish before the command exits
+	if notifier != nil {
+		notifier.Wait()
+	}
+
+	return groups, nil
+}
+
+// getNotifier returns a notifier shared by all groups when notifications are requested and rules are configured
+func getNotifier(ctx c.Context, options *Options) *notify.Notifier {
+
+	if !options.Notify || len(ctx.Config.Notify.Rules) == 0 {
+		return nil
+	}
+
+	return notify.NewNotifier(notify.NewConfigNotifier(ctx))
+}
+
 // Run prints holdings to the terminal
 func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Command, []string) {
-	return func(_ *cobra.Command, _ []string) {
+	return func(cmd *cobra.Command, _ []string) {
 
-		monitors, _ := mon.NewMonitor(mon.ConfigMonitor{
-			RefreshInterval: ctx.Config.RefreshInterval,
-			ConfigMonitorsYahoo: mon.ConfigMonitorsYahoo{
-				BaseURL:           dep.MonitorYahooBaseURL,
-				SessionRootURL:    dep.MonitorYahooSessionRootURL,
-				SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
-				SessionConsentURL: dep.MonitorYahooSessionConsentURL,
-			},
-			ConfigMonitorPriceCoinbase: mon.ConfigMonitorPriceCoinbase{
-				BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
-				StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
-			},
-		})
-		monitors.SetAssetGroup(ctx.Groups[0], 0) //nolint:errcheck
-		assetGroupQuote := monitors.GetAssetGroupQuote()
-		assets, _ := asset.GetAssets(*ctx, assetGroupQuote)
+		if options.Watch {
+			cmdCtx := cmd.Context()
+			if cmdCtx == nil {
+				cmdCtx = context.Background()
+			}
+
+			if err := runWatch(cmdCtx, dep, ctx, options); err != nil {
+				fmt.Println(err)
+			}
+
+			return
+		}
+
+		groups, err := getGroupAssets(dep, ctx, options)
+		if err != nil {
+			fmt.Println(err)
 
-		if options.Format == "csv" {
+			return
+		}
+
+		for i := range groups {
+			groups[i].assets = filterAssets(groups[i].assets, options.IncludeWatchlist)
+		}
+
+		if f, ok := getFormatter(options.Format, ctx.Reference.Styles, time.Now()); ok {
+			fmt.Println(f.format(getAssetTables(groups, options.AllGroups)))
+
+			return
+		}
+
+		if options.AllGroups {
+			switch {
+			case options.Format == formatCSV:
+				fmt.Println(convertGroupAssetsToCSV(groups))
+			case options.SchemaVersion == SchemaVersionTyped:
+				fmt.Println(convertGroupAssetsToTypedJSON(groups))
+			default:
+				fmt.Println(convertGroupAssetsToJSON(groups))
+			}
+
+			return
+		}
+
+		assets := groups[0].assets
+
+		if options.Format == formatCSV {
 			fmt.Println(convertAssetsToCSV(assets))
 
 			return
 		}
 
+		if options.SchemaVersion == SchemaVersionTyped {
+			fmt.Println(convertAssetsToTypedJSON(assets))
+
+			return
+		}
+
 		fmt.Println(convertAssetsToJSON(assets))
 	}
 }
@@ -172,25 +554,110 @@ func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Comm
 func RunSummary(dep *c.Dependencies, ctx *c.Context, options *Options) func(cmd *cobra.Command, args []string) {
 	return func(_ *cobra.Command, _ []string) {
 
-		monitors, _ := mon.NewMonitor(mon.ConfigMonitor{
-			RefreshInterval: ctx.Config.RefreshInterval,
-			ConfigMonitorsYahoo: mon.ConfigMonitorsYahoo{
-				BaseURL:           dep.MonitorYahooBaseURL,
-				SessionRootURL:    dep.MonitorYahooSessionRootURL,
-				SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
-				SessionConsentURL: dep.MonitorYahooSessionConsentURL,
-			},
-		})
-		monitors.SetAssetGroup(ctx.Groups[0], 0) //nolint:errcheck
-		assetGroupQuote := monitors.GetAssetGroupQuote()
-		_, positionSummary := asset.GetAssets(*ctx, assetGroupQuote)
+		groups, err := getGroupAssets(dep, ctx, options)
+		if err != nil {
+			fmt.Println(err)
+
+			return
+		}
 
-		if options.Format == "csv" {
+		positionSummaries := make([]asset.PositionSummary, 0, len(groups))
+		for _, group := range groups {
+			positionSummaries = append(positionSummaries, group.positionSummary)
+		}
+		total := asset.GetTotalPositionSummary(positionSummaries)
+
+		if f, ok := getFormatter(options.Format, ctx.Reference.Styles, time.Now()); ok {
+			fmt.Println(f.format(getSummaryTables(groups, total, options.AllGroups)))
+
+			return
+		}
+
+		if options.AllGroups {
+			switch {
+			case options.Format == formatCSV:
+				fmt.Println(convertGroupSummariesToCSV(groups, total))
+			case options.SchemaVersion == SchemaVersionTyped:
+				fmt.Println(convertGroupSummariesToTypedJSON(groups, total, ctx.Config.Currency))
+			default:
+				fmt.Println(convertGroupSummariesToJSON(groups, total))
+			}
+
+			return
+		}
+
+		positionSummary := groups[0].positionSummary
+
+		if options.Format == formatCSV {
 			fmt.Println(convertSummaryToCSV(positionSummary))
 
 			return
 		}
 
+		if options.SchemaVersion == SchemaVersionTyped {
+			fmt.Println(convertSummaryToTypedJSON(positionSummary))
+
+			return
+		}
+
 		fmt.Println(convertSummaryToJSON(positionSummary))
 	}
 }
+
+// RunLots handles the print lots command
+func RunLots(dep *c.Dependencies, ctx *c.Context, options *Options) func(cmd *cobra.Command, args []string) {
+	return func(_ *cobra.Command, _ []string) {
+
+		assetGroupQuotes, err := getAssetGroupQuotes(dep, ctx, options)
+		if err != nil {
+			fmt.Println(err)
+
+			return
+		}
+
+		now := time.Now()
+		groups := make([]groupLots, 0, len(assetGroupQuotes))
+
+		for _, assetGroupQuote := range assetGroupQuotes {
+			groups = append(groups, groupLots{
+				name:         assetGroupQuote.AssetGroup.Name,
+				lotPositions: asset.GetLotPositions(*ctx, assetGroupQuote, now),
+			})
+		}
+
+		if f, ok := getFormatter(options.Format, ctx.Reference.Styles, now); ok {
+			fmt.Println(f.format(getLotTables(groups, options.AllGroups)))
+
+			return
+		}
+
+		if options.AllGroups {
+			switch {
+			case options.Format == formatCSV:
+				fmt.Println(convertGroupLotsToCSV(groups))
+			case options.SchemaVersion == SchemaVersionTyped:
+				fmt.Println(convertGroupLotsToTypedJSON(groups))
+			default:
+				fmt.Println(convertGroupLotsToJSON(groups))
+			}
+
+			return
+		}
+
+		lotPositions := groups[0].lotPositions
+
+		if options.Format == formatCSV {
+			fmt.Println(convertLotsToCSV(lotPositions))
+
+			return
+		}
+
+		if options.SchemaVersion == SchemaVersionTyped {
+			fmt.Println(convertLotsToTypedJSON(lotPositions))
+
+			return
+		}
+
+		fmt.Println(convertLotsToJSON(lotPositions))
+	}
+}
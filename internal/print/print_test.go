@@ -1,10 +1,13 @@
 package print_test
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	"github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
@@ -80,6 +83,15 @@ var _ = Describe("Print", func() {
 		}
 
 		inputContext = c.Context{
+			Reference: c.Reference{Styles: c.Styles{
+				Text:      func(v string) string { return v },
+				TextLight: func(v string) string { return v },
+				TextLabel: func(v string) string { return v },
+				TextBold:  func(v string) string { return v },
+				TextLine:  func(v string) string { return v },
+				TextPrice: func(percent float64, text string) string { return text },
+				Tag:       func(v string) string { return v },
+			}},
 			Groups: []c.AssetGroup{
 				{
 					SymbolsBySource: []c.AssetGroupSymbolsBySource{
@@ -145,6 +157,478 @@ var _ = Describe("Print", func() {
 				Expect(output).To(Equal("name,symbol,price,value,cost,quantity,weight\nAlphabet Inc.,GOOG,2838.42,28384.20,10000.00,10.000,96.997\nRoblox Corporation,RBLX,87.880,878.80,500.00,10.000,3.0031\n\n"))
 			})
 		})
+
+		When("the schema version option is set to 2", func() {
+			It("should print the holdings with numeric values and all asset fields", func() {
+				var document map[string]interface{}
+
+				inputOptions := print.Options{
+					SchemaVersion: print.SchemaVersionTyped,
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+
+				Expect(json.Unmarshal([]byte(output), &document)).To(Succeed())
+				Expect(document).To(HaveKeyWithValue("schema_version", 2.0))
+				Expect(document).To(HaveKeyWithValue("assets", HaveLen(2)))
+
+				goog := document["assets"].([]interface{})[0]
+				Expect(goog).To(HaveKeyWithValue("symbol", "GOOG"))
+				Expect(goog).To(HaveKeyWithValue("class", "stock"))
+				Expect(goog).To(HaveKeyWithValue("source", "yahoo"))
+				Expect(goog).To(HaveKeyWithValue("currency", HaveKeyWithValue("code", "USD")))
+				Expect(goog).To(HaveKeyWithValue("quote", And(
+					HaveKeyWithValue("price", 2838.42),
+					HaveKeyWithValue("change", 283.84),
+					HaveKeyWithValue("change_percent", 10.0),
+				)))
+				Expect(goog).To(HaveKeyWithValue("quote_extended", HaveKey("market_cap")))
+				Expect(goog).To(HaveKeyWithValue("quote_futures", BeNil()))
+				Expect(goog).To(HaveKeyWithValue("position", And(
+					HaveKeyWithValue("quantity", 10.0),
+					HaveKeyWithValue("cost", 10000.0),
+					HaveKeyWithValue("day_change_amount", BeNumerically("~", 2838.4, 0.001)),
+				)))
+				Expect(goog).To(HaveKeyWithValue("exchange", And(
+					HaveKeyWithValue("state", "open"),
+					HaveKeyWithValue("is_regular_trading_session", true),
+				)))
+			})
+
+			When("there are no holdings in the default group", func() {
+				It("should print an empty list of assets", func() {
+					inputContext.Groups[0].ConfigAssetGroup.Lots = []c.Lot{}
+					inputOptions := print.Options{
+						SchemaVersion: print.SchemaVersionTyped,
+					}
+					output := getStdout(func() {
+						print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+					})
+					Expect(output).To(Equal("{\"schema_version\":2,\"assets\":[]}\n"))
+				})
+			})
+		})
+
+		When("the include watchlist option is set", func() {
+			BeforeEach(func() {
+				inputContext.Groups[0].ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "GOOG", UnitCost: 1000, Quantity: 10},
+				}
+			})
+
+			It("should print watchlist symbols without a position", func() {
+				var document map[string]interface{}
+
+				inputOptions := print.Options{
+					SchemaVersion:    print.SchemaVersionTyped,
+					IncludeWatchlist: true,
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+
+				Expect(json.Unmarshal([]byte(output), &document)).To(Succeed())
+				Expect(document["assets"]).To(HaveLen(2))
+				Expect(document["assets"].([]interface{})[1]).To(And(
+					HaveKeyWithValue("symbol", "RBLX"),
+					HaveKeyWithValue("position", BeNil()),
+				))
+			})
+
+			When("the format option is set to csv", func() {
+				It("should print watchlist symbols without a position", func() {
+					inputOptions := print.Options{
+						Format:           "csv",
+						IncludeWatchlist: true,
+					}
+					output := getStdout(func() {
+						print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+					})
+					Expect(output).To(ContainSubstring("Roblox Corporation,RBLX,87.880,0.00,0.00,0.00,0.00\n"))
+				})
+			})
+		})
+
+		When("the schema version is not supported", func() {
+			It("should print an error", func() {
+				inputOptions := print.Options{
+					SchemaVersion: 3,
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal("invalid option: schema version 3 is not supported, set 1 or 2\n"))
+			})
+		})
+
+		When("the format option is set to table", func() {
+			It("should print the holdings in aligned columns", func() {
+				inputOptions := print.Options{
+					Format: "table",
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal(strings.Join([]string{
+					"Symbol  Name                  Price         Day Change  Quantity     Value      Cost          Total Change  Weight",
+					"GOOG    Alphabet Inc.       2838.42  ↑ 283.84 (10.00%)     10.00  28384.20  10000.00  ↑ 18384.20 (183.84%)  97.00%",
+					"RBLX    Roblox Corporation    87.88  ↓ -8.79 (-10.00%)     10.00    878.80    500.00     ↑ 378.80 (75.76%)   3.00%",
+					"",
+				}, "\n")))
+			})
+
+			It("should color changes with the color scheme", func() {
+				inputContext.Reference.Styles.TextPrice = func(percent float64, text string) string {
+					if percent < 0 {
+						return "<down>" + text + "</down>"
+					}
+
+					return text
+				}
+				inputOptions := print.Options{
+					Format: "table",
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(ContainSubstring("<down>↓ -8.79 (-10.00%)</down>"))
+			})
+		})
+
+		When("the format option is set to markdown", func() {
+			It("should print the holdings as a Markdown table", func() {
+				inputOptions := print.Options{
+					Format: "markdown",
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal(strings.Join([]string{
+					"| Symbol | Name | Price | Day Change | Quantity | Value | Cost | Total Change | Weight |",
+					"| --- | --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: |",
+					"| GOOG | Alphabet Inc. | 2838.42 | ↑ 283.84 (10.00%) | 10.00 | 28384.20 | 10000.00 | ↑ 18384.20 (183.84%) | 97.00% |",
+					"| RBLX | Roblox Corporation | 87.88 | ↓ -8.79 (-10.00%) | 10.00 | 878.80 | 500.00 | ↑ 378.80 (75.76%) | 3.00% |",
+					"",
+				}, "\n")))
+			})
+		})
+
+		When("the format option is set to html", func() {
+			It("should print the holdings as a self-contained HTML page", func() {
+				inputOptions := print.Options{
+					Format: "html",
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(HavePrefix("<!DOCTYPE html>\n"))
+				Expect(output).To(ContainSubstring("<style>"))
+				Expect(output).To(MatchRegexp(`<p class="time">Generated <time datetime="[^"]+">[^<]+</time></p>`))
+				Expect(output).To(ContainSubstring(`<tr><td>RBLX</td><td>Roblox Corporation</td><td class="num">87.88</td><td class="num down">↓ -8.79 (-10.00%)</td>`))
+				Expect(output).To(HaveSuffix("</html>\n"))
+			})
+		})
+
+		When("the format option is not supported", func() {
+			It("should print an error", func() {
+				inputOptions := print.Options{
+					Format: "xml",
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal("invalid option: format 'xml' is not supported, set json, csv, table, markdown, or html\n"))
+			})
+		})
+
+		When("the watch option is set", func() {
+
+			getWatchStdout := func(options print.Options) []map[string]interface{} {
+				cmdCtx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+				defer cancel()
+
+				cmd := &cobra.Command{}
+				cmd.SetContext(cmdCtx)
+
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &options)(cmd, []string{})
+				})
+
+				events := make([]map[string]interface{}, 0)
+				for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+					var event map[string]interface{}
+					Expect(json.Unmarshal([]byte(line), &event)).To(Succeed(), line)
+					events = append(events, event)
+				}
+
+				return events
+			}
+
+			It("should print a line of JSON for each asset followed by the summary until the command is canceled", func() {
+				events := getWatchStdout(print.Options{Watch: true})
+
+				Expect(len(events)).To(BeNumerically(">=", 3))
+				Expect(events[0]).To(HaveKeyWithValue("type", "asset"))
+				Expect(events[0]).To(HaveKey("time"))
+				Expect(events[0]).To(HaveKeyWithValue("asset", HaveKeyWithValue("symbol", "GOOG")))
+				Expect(events[0]).To(HaveKeyWithValue("asset", HaveKeyWithValue("price", "2838.420000")))
+				Expect(events[1]).To(HaveKeyWithValue("asset", HaveKeyWithValue("symbol", "RBLX")))
+				Expect(events[2]).To(HaveKeyWithValue("type", "summary"))
+				Expect(events[2]).To(HaveKeyWithValue("summary", HaveKeyWithValue("total_value", "29263.000000")))
+				Expect(events[2]).NotTo(HaveKey("schema_version"))
+			})
+
+			When("the schema version option is set to 2", func() {
+				It("should print lines with the typed asset and summary fields", func() {
+					events := getWatchStdout(print.Options{Watch: true, SchemaVersion: 2})
+
+					Expect(len(events)).To(BeNumerically(">=", 3))
+					Expect(events[0]).To(HaveKeyWithValue("schema_version", 2.0))
+					Expect(events[0]).To(HaveKeyWithValue("asset", HaveKeyWithValue("quote", HaveKeyWithValue("price", 2838.42))))
+					Expect(events[2]).To(HaveKeyWithValue("summary", HaveKeyWithValue("value", BeNumerically("~", 29263.0, 0.001))))
+				})
+			})
+
+			When("the format option is set to csv", func() {
+				It("should print an error", func() {
+					inputOptions := print.Options{Watch: true, Format: "csv"}
+					output := getStdout(func() {
+						print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+					})
+					Expect(output).To(Equal("invalid option: watch only supports the json format\n"))
+				})
+			})
+
+			When("the all groups option is set", func() {
+				It("should print an error", func() {
+					inputOptions := print.Options{Watch: true, AllGroups: true}
+					output := getStdout(func() {
+						print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+					})
+					Expect(output).To(Equal("invalid option: watch and all-groups can not be set together\n"))
+				})
+			})
+		})
+
+		When("there are notification rules", func() {
+			It("should deliver notifications for the rules which are met before exiting", func() {
+				var payload map[string]interface{}
+
+				server.RouteToHandler(http.MethodPost, "/webhook",
+					ghttp.CombineHandlers(
+						ghttp.VerifyContentType("application/json"),
+						func(_ http.ResponseWriter, r *http.Request) {
+							json.NewDecoder(r.Body).Decode(&payload) //nolint:errcheck
+						},
+					),
+				)
+
+				inputContext.Config.Notify.Rules = []c.ConfigNotifyRule{
+					{Symbol: "GOOG", PriceAbove: 2000, Webhook: server.URL() + "/webhook"},
+					{Symbol: "RBLX", PriceAbove: 2000, Webhook: server.URL() + "/webhook"},
+				}
+
+				inputOptions := print.Options{Notify: true}
+				getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+
+				Expect(payload).To(HaveKeyWithValue("symbol", "GOOG"))
+				Expect(payload).To(HaveKeyWithValue("rule", "price-above"))
+				Expect(payload).To(HaveKeyWithValue("portfolio", HaveKeyWithValue("value", 29263.0)))
+			})
+
+			When("notifications are not requested", func() {
+				It("should not deliver notifications", func() {
+					server.RouteToHandler(http.MethodPost, "/webhook", ghttp.RespondWith(http.StatusOK, ""))
+
+					inputContext.Config.Notify.Rules = []c.ConfigNotifyRule{
+						{Symbol: "GOOG", PriceAbove: 2000, Webhook: server.URL() + "/webhook"},
+					}
+
+					getStdout(func() {
+						print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+					})
+
+					for _, request := range server.ReceivedRequests() {
+						Expect(request.URL.Path).NotTo(Equal("/webhook"))
+					}
+				})
+			})
+		})
+	})
+
+	Describe("group options", func() {
+
+		BeforeEach(func() {
+			inputContext.Groups[0].ConfigAssetGroup.Name = "default"
+			inputContext.Groups = append(inputContext.Groups, c.AssetGroup{
+				SymbolsBySource: []c.AssetGroupSymbolsBySource{
+					{
+						Source:  c.QuoteSourceYahoo,
+						Symbols: []string{"RBLX"},
+					},
+				},
+				ConfigAssetGroup: c.ConfigAssetGroup{
+					Name: "retirement",
+					Lots: []c.Lot{
+						{Symbol: "RBLX", UnitCost: 40, Quantity: 20},
+					},
+				},
+			})
+		})
+
+		When("the group option is set", func() {
+			It("should print the holdings of the group with the name", func() {
+				inputOptions := print.Options{
+					Format: "csv",
+					Group:  "retirement",
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal("name,symbol,price,value,cost,quantity,weight\nRoblox Corporation,RBLX,87.880,1757.60,800.00,20.000,100.00\n\n"))
+			})
+
+			When("there is no group with the name", func() {
+				It("should print an error", func() {
+					inputOptions := print.Options{
+						Group: "brokerage",
+					}
+					output := getStdout(func() {
+						print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+					})
+					Expect(output).To(Equal("invalid option: group 'brokerage' not found\n"))
+				})
+			})
+
+			When("the all groups option is also set", func() {
+				It("should print an error", func() {
+					inputOptions := print.Options{
+						Group:     "retirement",
+						AllGroups: true,
+					}
+					output := getStdout(func() {
+						print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+					})
+					Expect(output).To(Equal("invalid option: group and all-groups can not be set together\n"))
+				})
+			})
+		})
+
+		When("the all groups option is set", func() {
+			It("should print the holdings nested by group", func() {
+				inputOptions := print.Options{
+					AllGroups: true,
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(HavePrefix(`[{"group":"default","assets":[{"name":"Alphabet Inc.","symbol":"GOOG"`))
+				Expect(output).To(HaveSuffix(`{"group":"retirement","assets":[{"name":"Roblox Corporation","symbol":"RBLX","price":"87.880000","value":"1757.600000","cost":"800.000000","quantity":"20.000000","weight":"100.000000"}]}]` + "\n"))
+			})
+
+			It("should print the holdings in CSV format with the group in each row", func() {
+				inputOptions := print.Options{
+					Format:    "csv",
+					AllGroups: true,
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal(strings.Join([]string{
+					"group,name,symbol,price,value,cost,quantity,weight",
+					"default,Alphabet Inc.,GOOG,2838.42,28384.20,10000.00,10.000,96.997",
+					"default,Roblox Corporation,RBLX,87.880,878.80,500.00,10.000,3.0031",
+					"retirement,Roblox Corporation,RBLX,87.880,1757.60,800.00,20.000,100.00",
+					"",
+					"",
+				}, "\n")))
+			})
+
+			It("should print the summary of each group and the total across groups", func() {
+				inputOptions := print.Options{
+					Format:    "csv",
+					AllGroups: true,
+				}
+				output := getStdout(func() {
+					print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal(strings.Join([]string{
+					"group,total_value,total_cost,day_change_amount,day_change_percent,total_change_amount,total_change_percent",
+					"default,29263.000000,10500.000000,2750.500000,9.399241,18763.000000,178.695238",
+					"retirement,1757.600000,800.000000,-175.800000,-10.002276,957.600000,119.700000",
+					"total,31020.600000,11300.000000,2574.700000,8.299968,19720.600000,174.518584",
+					"",
+					"",
+				}, "\n")))
+			})
+
+			It("should print the summary of each group and the total across groups in the typed JSON schema", func() {
+				var document map[string]interface{}
+
+				inputOptions := print.Options{
+					SchemaVersion: print.SchemaVersionTyped,
+					AllGroups:     true,
+				}
+				output := getStdout(func() {
+					print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+
+				Expect(json.Unmarshal([]byte(output), &document)).To(Succeed())
+				Expect(document).To(HaveKeyWithValue("groups", HaveLen(2)))
+				Expect(document["groups"].([]interface{})[1]).To(And(
+					HaveKeyWithValue("name", "retirement"),
+					HaveKeyWithValue("summary", HaveKeyWithValue("cost", 800.0)),
+				))
+				Expect(document).To(HaveKeyWithValue("total", And(
+					HaveKeyWithValue("value", BeNumerically("~", 31020.6, 0.001)),
+					HaveKeyWithValue("cost", 11300.0),
+				)))
+			})
+
+			It("should print the summary of each group and the total across groups as a table", func() {
+				inputOptions := print.Options{
+					Format:    "markdown",
+					AllGroups: true,
+				}
+				output := getStdout(func() {
+					print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(Equal(strings.Join([]string{
+					"| Group | Value | Cost | Day Change | Total Change | Realized |",
+					"| --- | ---: | ---: | ---: | ---: | ---: |",
+					"| default | 29263.00 | 10500.00 | ↑ 2750.50 (9.40%) | ↑ 18763.00 (178.70%) | 0.00 |",
+					"| retirement | 1757.60 | 800.00 | ↓ -175.80 (-10.00%) | ↑ 957.60 (119.70%) | 0.00 |",
+					"| Total | 31020.60 | 11300.00 | ↑ 2574.70 (8.30%) | ↑ 19720.60 (174.52%) | 0.00 |",
+					"",
+				}, "\n")))
+			})
+
+			It("should print a table of lots titled with the name of each group", func() {
+				inputOptions := print.Options{
+					Format:    "table",
+					AllGroups: true,
+				}
+				output := getStdout(func() {
+					print.RunLots(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(HavePrefix("default\nSymbol  Name"))
+				Expect(output).To(ContainSubstring("\n\nretirement\nSymbol  Name"))
+			})
+
+			It("should print the lots nested by group", func() {
+				inputOptions := print.Options{
+					AllGroups: true,
+				}
+				output := getStdout(func() {
+					print.RunLots(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(HavePrefix(`[{"group":"default","lots":[{"symbol":"GOOG"`))
+				Expect(output).To(ContainSubstring(`{"group":"retirement","lots":[{"symbol":"RBLX","name":"Roblox Corporation","currency":"USD","date":"","quantity":"20.000000"`))
+			})
+		})
 	})
 
 	Describe("RunSummary", func() {
@@ -168,6 +652,79 @@ var _ = Describe("Print", func() {
 			})
 		})
 
+		When("the schema version option is set to 2", func() {
+			It("should print the holdings summary with numeric values", func() {
+				var document map[string]interface{}
+
+				inputOptions := print.Options{
+					SchemaVersion: print.SchemaVersionTyped,
+				}
+				output := getStdout(func() {
+					print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+
+				Expect(json.Unmarshal([]byte(output), &document)).To(Succeed())
+				Expect(document).To(HaveKeyWithValue("schema_version", 2.0))
+				Expect(document).To(HaveKeyWithValue("summary", And(
+					HaveKeyWithValue("value", 29263.0),
+					HaveKeyWithValue("cost", 10500.0),
+					HaveKeyWithValue("day_change_amount", BeNumerically("~", 2750.5, 0.001)),
+					HaveKeyWithValue("day_change_percent", BeNumerically("~", 9.399241, 0.000001)),
+					HaveKeyWithValue("total_change_amount", 18763.0),
+					HaveKeyWithValue("realized", 0.0),
+					HaveKeyWithValue("benchmark", BeNil()),
+				)))
+			})
+		})
+
+	})
+
+	Describe("RunLots", func() {
+
+		BeforeEach(func() {
+			inputContext.Groups[0].ConfigAssetGroup.Lots = []c.Lot{
+				{Symbol: "GOOG", UnitCost: 1000, Quantity: 5, Date: "2020-01-02"},
+				{Symbol: "GOOG", UnitCost: 1500, Quantity: 5, FixedCost: 10},
+				{Symbol: "RBLX", UnitCost: 50, Quantity: 10},
+			}
+		})
+
+		It("should print each lot in JSON format", func() {
+			output := getStdout(func() {
+				print.RunLots(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+			})
+			Expect(output).To(MatchRegexp(`^\[\{"symbol":"GOOG","name":"Alphabet Inc\.","currency":"USD","date":"2020-01-02","quantity":"5\.000000","unit_cost":"1000\.000000","cost":"5000\.000000","value":"14192\.100000","total_change_amount":"9192\.100000","total_change_percent":"183\.842000","days_held":"\d+","holding_period":"long-term"\},`))
+			Expect(output).To(ContainSubstring(`{"symbol":"GOOG","name":"Alphabet Inc.","currency":"USD","date":"","quantity":"5.000000","unit_cost":"1500.000000","cost":"7510.000000","value":"14192.100000","total_change_amount":"6682.100000","total_change_percent":"88.976032","days_held":"","holding_period":""}`))
+			Expect(output).To(ContainSubstring(`{"symbol":"RBLX","name":"Roblox Corporation"`))
+		})
+
+		When("the format option is set to csv", func() {
+			It("should print each lot in CSV format", func() {
+				inputOptions := print.Options{
+					Format: "csv",
+				}
+				output := getStdout(func() {
+					print.RunLots(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(HavePrefix("symbol,name,currency,date,quantity,unit_cost,cost,value,total_change_amount,total_change_percent,days_held,holding_period\n"))
+				Expect(output).To(ContainSubstring("RBLX,Roblox Corporation,USD,,10.000,50.000,500.00,878.80,378.80,75.760,,\n"))
+			})
+		})
+
+		When("the schema version option is set to 2", func() {
+			It("should print each lot with numeric values and null for missing acquisition dates", func() {
+				inputOptions := print.Options{
+					SchemaVersion: print.SchemaVersionTyped,
+				}
+				output := getStdout(func() {
+					print.RunLots(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(MatchRegexp(`^\{"schema_version":2,"lots":\[\{"symbol":"GOOG","name":"Alphabet Inc\.","currency":"USD","date":"2020-01-02","quantity":5,"unit_cost":1000,"cost":5000,"value":14192\.1,"total_change_amount":9192\.1,"total_change_percent":183\.842,"days_held":\d+,"holding_period":"long-term"\},`))
+				Expect(output).To(ContainSubstring(`{"symbol":"RBLX","name":"Roblox Corporation","currency":"USD","date":null,"quantity":10,"unit_cost":50,"cost":500,"value":878.8,`))
+				Expect(output).To(HaveSuffix(`"days_held":null,"holding_period":null}]}` + "\n"))
+			})
+		})
+
 	})
 
 })
@@ -0,0 +1,249 @@
+package print //nolint:predeclared
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+	"github.com/achannarasappa/ticker/v5/internal/notify"
+)
+
+const (
+	watchEventAsset   = "asset"
+	watchEventSummary = "summary"
+)
+
+// jsonWatchEvent is a line of NDJSON output for an asset update or a summary recalculation
+type jsonWatchEvent struct {
+	SchemaVersion int         `json:"schema_version,omitempty"` // Set only for schema version 2
+	Type          string      `json:"type"`
+	Time          time.Time   `json:"time"`
+	Group         string      `json:"group"`
+	Asset         interface{} `json:"asset,omitempty"`
+	Summary       interface{} `json:"summary,omitempty"`
+}
+
+// watcher keeps the latest quotes for a group and writes an event each time an asset or the group is updated
+type watcher struct {
+	ctx               c.Context
+	options           *Options
+	encoder           *json.Encoder
+	notifier          *notify.Notifier
+	assetGroup        c.AssetGroup
+	assetQuotes       []c.AssetQuote
+	assetQuotesLookup map[string]int
+	stopped           bool
+	mu                sync.Mutex
+}
+
+func newWatcher(ctx c.Context, options *Options, assetGroup c.AssetGroup, w io.Writer) *watcher {
+
+	wa := &watcher{
+		ctx:               ctx,
+		options:           options,
+		encoder:           json.NewEncoder(w),
+		assetGroup:        assetGroup,
+		assetQuotesLookup: make(map[string]int),
+	}
+
+	wa.notifier = getNotifier(ctx, options)
+
+	return wa
+}
+
+// getConfigUpdateFns returns callbacks which write events for updated quotes
+func (w *watcher) getConfigUpdateFns() mon.ConfigUpdateFns {
+	return mon.ConfigUpdateFns{
+		OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, _ int) {
+			w.setAssetQuote(symbol, assetQuote)
+		},
+		OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, _ int) {
+			w.setAssetGroupQuote(assetGroupQuote)
+		},
+	}
+}
+
+// setAssetGroupQuote replaces all quotes and writes an event for each asset followed by the summary
+func (w *watcher) setAssetGroupQuote(assetGroupQuote c.AssetGroupQuote) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Skip quotes for currency rates received before the group is set on the monitor
+	if w.stopped || len(assetGroupQuote.AssetGroup.SymbolsBySource) == 0 {
+		return
+	}
+
+	w.assetQuotes = assetGroupQuote.AssetQuotes
+	w.assetQuotesLookup = make(map[string]int, len(w.assetQuotes))
+	for i, assetQuote := range w.assetQuotes {
+		w.assetQuotesLookup[assetQuote.Symbol] = i
+	}
+
+	assets, positionSummary := asset.GetAssets(w.ctx, assetGroupQuote)
+	now := time.Now()
+
+	for _, a := range filterAssets(assets, w.options.IncludeWatchlist) {
+		w.write(w.newAssetEvent(a, now))
+	}
+
+	w.write(w.newSummaryEvent(positionSummary, now))
+	w.notify(assets, positionSummary)
+}
+
+// setAssetQuote updates the quote for a symbol and writes an event for the asset followed by the recalculated summary
+func (w *watcher) setAssetQuote(symbol string, assetQuote c.AssetQuote) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		return
+	}
+
+	i, ok := w.assetQuotesLookup[symbol]
+	if !ok || i >= len(w.assetQuotes) || w.assetQuotes[i].Symbol != symbol {
+		return
+	}
+
+	w.assetQuotes[i] = assetQuote
+
+	assets, positionSummary := asset.GetAssets(w.ctx, c.AssetGroupQuote{
+		AssetQuotes: w.assetQuotes,
+		AssetGroup:  w.assetGroup,
+	})
+	now := time.Now()
+
+	for _, a := range filterAssets(assets, w.options.IncludeWatchlist) {
+		if a.Symbol == symbol {
+			w.write(w.newAssetEvent(a, now))
+		}
+	}
+
+	w.write(w.newSummaryEvent(positionSummary, now))
+	w.notify(assets, positionSummary)
+}
+
+// stop prevents events from being written after the command exits and waits for notifications to be delivered
+func (w *watcher) stop() {
+
+	w.mu.Lock()
+	w.stopped = true
+	w.mu.Unlock()
+
+	if w.notifier != nil {
+		w.notifier.Wait()
+	}
+}
+
+func (w *watcher) notify(assets []c.Asset, positionSummary asset.PositionSummary) {
+	if w.notifier != nil {
+		w.notifier.Notify(w.assetQuotes, assets, positionSummary)
+	}
+}
+
+func (w *watcher) newAssetEvent(a c.Asset, now time.Time) jsonWatchEvent {
+
+	event := jsonWatchEvent{Type: watchEventAsset, Time: now, Group: w.assetGroup.Name}
+
+	if w.options.SchemaVersion == SchemaVersionTyped {
+		event.SchemaVersion = SchemaVersionTyped
+		event.Asset = newJSONAsset(a)
+
+		return event
+	}
+
+	event.Asset = newJSONRow(a)
+
+	return event
+}
+
+func (w *watcher) newSummaryEvent(positionSummary asset.PositionSummary, now time.Time) jsonWatchEvent {
+
+	event := jsonWatchEvent{Type: watchEventSummary, Time: now, Group: w.assetGroup.Name}
+
+	if w.options.SchemaVersion == SchemaVersionTyped {
+		event.SchemaVersion = SchemaVersionTyped
+		event.Summary = newJSONSummaryTyped(positionSummary)
+
+		return event
+	}
+
+	event.Summary = newJSONSummary(positionSummary)
+
+	return event
+}
+
+// write encodes the event as a single line of JSON
+func (w *watcher) write(event jsonWatchEvent) {
+	//nolint:errcheck
+	w.encoder.Encode(event)
+}
+
+// validateWatchOptions returns an error when an option can not be used to stream updates
+func validateWatchOptions(options *Options) error {
+
+	if options.Format != "" && options.Format != formatJSON {
+		return errors.New("invalid option: watch only supports the json format")
+	}
+
+	if options.AllGroups {
+		return errors.New("invalid option: watch and all-groups can not be set together")
+	}
+
+	return nil
+}
+
+// runWatch streams updates for the selected group as NDJSON until the command is interrupted or its context is canceled
+func runWatch(cmdCtx context.Context, dep *c.Dependencies, ctx *c.Context, options *Options) error {
+
+	if err := validateOptions(options); err != nil {
+		return err
+	}
+
+	if err := validateWatchOptions(options); err != nil {
+		return err
+	}
+
+	assetGroups, err := getAssetGroups(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	monitors, err := mon.NewMonitor(mon.NewConfigMonitor(*dep, *ctx))
+	if err != nil {
+		return err
+	}
+
+	watchCtx, cancel := signal.NotifyContext(cmdCtx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	w := newWatcher(*ctx, options, assetGroups[0], os.Stdout)
+	defer w.stop()
+
+	err = monitors.SetOnUpdate(w.getConfigUpdateFns())
+	if err != nil {
+		return err
+	}
+
+	monitors.Start()
+	defer monitors.Stop()
+
+	err = monitors.SetAssetGroup(assetGroups[0], 0)
+	if err != nil {
+		return err
+	}
+
+	<-watchCtx.Done()
+
+	return nil
+}
@@ -0,0 +1,398 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/history"
+	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+)
+
+const (
+	speedMin = 0.25
+	speedMax = 64.0
+)
+
+// Options to configure replay behavior
+type Options struct {
+	Path  string
+	Speed float64
+	Start time.Duration
+}
+
+// Status represents the state of playback
+type Status struct {
+	Position time.Time
+	Speed    float64
+	Paused   bool
+	Done     bool
+}
+
+// Player plays back recorded quotes through the same callbacks used by the monitor
+type Player struct {
+	quotes                  []history.Quote
+	index                   int
+	position                time.Time
+	speed                   float64
+	paused                  bool
+	tickInterval            time.Duration
+	assetGroup              c.AssetGroup
+	assetGroupSymbols       map[string]bool
+	assetGroupVersionVector int
+	assetGroupPending       bool
+	assetGroupIsSet         bool
+	assetQuotes             map[string]c.AssetQuote
+	onUpdateAssetQuote      func(symbol string, assetQuote c.AssetQuote, versionVector int)
+	onUpdateAssetGroupQuote func(assetGroupQuote c.AssetGroupQuote, versionVector int)
+	ctx                     context.Context
+	cancel                  context.CancelFunc
+	mu                      sync.Mutex
+}
+
+// Config contains the required configuration for the player
+type Config struct {
+	Quotes []history.Quote // Recorded quotes ordered from oldest to newest
+}
+
+// Option defines an option for configuring the player
+type Option func(*Player)
+
+// NewPlayer creates a player positioned at the start of the recording
+func NewPlayer(config Config, opts ...Option) (*Player, error) {
+
+	if len(config.Quotes) == 0 {
+		return nil, errors.New("recording has no quotes")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := &Player{
+		quotes:                  config.Quotes,
+		position:                config.Quotes[0].Time,
+		speed:                   1.0,
+		tickInterval:            100 * time.Millisecond,
+		assetGroupSymbols:       make(map[string]bool),
+		assetQuotes:             make(map[string]c.AssetQuote),
+		onUpdateAssetQuote:      func(string, c.AssetQuote, int) {},
+		onUpdateAssetGroupQuote: func(c.AssetGroupQuote, int) {},
+		ctx:                     ctx,
+		cancel:                  cancel,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.seek(p.position)
+
+	return p, nil
+}
+
+// WithSpeed sets the playback speed as a multiple of the recorded speed
+func WithSpeed(speed float64) Option {
+	return func(p *Player) {
+		if speed > 0 {
+			p.speed = clampSpeed(speed)
+		}
+	}
+}
+
+// WithStart sets the offset from the beginning of the recording to start playback from
+func WithStart(start time.Duration) Option {
+	return func(p *Player) {
+		p.position = p.quotes[0].Time.Add(start)
+	}
+}
+
+// WithTickInterval sets how often playback advances
+func WithTickInterval(tickInterval time.Duration) Option {
+	return func(p *Player) {
+		if tickInterval > 0 {
+			p.tickInterval = tickInterval
+		}
+	}
+}
+
+// SetOnUpdate sets the callback functions for when asset quotes are updated
+func (p *Player) SetOnUpdate(config mon.ConfigUpdateFns) error {
+
+	if config.OnUpdateAssetQuote == nil || config.OnUpdateAssetGroupQuote == nil {
+		return errors.New("onUpdateAssetQuote and onUpdateAssetGroupQuote must be set")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onUpdateAssetQuote = config.OnUpdateAssetQuote
+	p.onUpdateAssetGroupQuote = config.OnUpdateAssetGroupQuote
+
+	return nil
+}
+
+// SetAssetGroup sets the asset group to play quotes for; all recorded symbols are played when the group has no symbols
+func (p *Player) SetAssetGroup(assetGroup c.AssetGroup, versionVector int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	symbols := make(map[string]bool)
+
+	for _, symbol := range assetGroup.Watchlist {
+		symbols[strings.ToUpper(symbol)] = true
+	}
+
+	for _, lot := range assetGroup.Lots {
+		symbols[strings.ToUpper(lot.Symbol)] = true
+	}
+
+	for _, transaction := range assetGroup.Transactions {
+		symbols[strings.ToUpper(transaction.Symbol)] = true
+	}
+
+	if assetGroup.Benchmark != "" {
+		symbols[strings.ToUpper(assetGroup.Benchmark)] = true
+	}
+
+	p.assetGroup = assetGroup
+	p.assetGroupSymbols = symbols
+	p.assetGroupVersionVector = versionVector
+	p.assetGroupPending = true
+	p.assetGroupIsSet = true
+
+	return nil
+}
+
+// GetPriceHistory returns the recorded prices for a symbol up to the current position in the recording
+func (p *Player) GetPriceHistory(assetQuote c.AssetQuote, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	priceHistory := c.PriceHistory{
+		Symbol:   assetQuote.Symbol,
+		Currency: assetQuote.Currency.FromCurrencyCode,
+		Range:    historyRange,
+		Interval: interval,
+		Candles:  make([]c.PriceCandle, 0),
+	}
+
+	for _, quote := range p.quotes[:p.index] {
+		if quote.Symbol != assetQuote.Symbol {
+			continue
+		}
+
+		priceHistory.Candles = append(priceHistory.Candles, c.PriceCandle{
+			Time:   quote.Time,
+			Open:   quote.Price,
+			High:   quote.Price,
+			Low:    quote.Price,
+			Close:  quote.Price,
+			Volume: quote.Volume,
+		})
+	}
+
+	return priceHistory, nil
+}
+
+// Start starts playback
+func (p *Player) Start() {
+	go p.run()
+}
+
+// Stop stops playback
+func (p *Player) Stop() {
+	p.cancel()
+}
+
+// TogglePause pauses playback if it is playing and resumes it if it is paused
+func (p *Player) TogglePause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.paused = !p.paused
+}
+
+// SetSpeed sets the playback speed as a multiple of the recorded speed
+func (p *Player) SetSpeed(speed float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.speed = clampSpeed(speed)
+}
+
+// Seek moves the position in the recording forward or backward by an offset and replaces all quotes with those at the new position
+func (p *Player) Seek(offset time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seek(p.position.Add(offset))
+}
+
+// Status returns the current state of playback
+func (p *Player) Status() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return Status{
+		Position: p.position,
+		Speed:    p.speed,
+		Paused:   p.paused,
+		Done:     p.index == len(p.quotes),
+	}
+}
+
+func (p *Player) run() {
+
+	ticker := time.NewTicker(p.tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.advance()
+		}
+	}
+}
+
+// advance moves the position forward by one tick and sends quotes recorded up to the new position
+func (p *Player) advance() {
+	p.mu.Lock()
+
+	assetQuotes := make([]c.AssetQuote, 0)
+
+	if !p.paused {
+		p.position = p.position.Add(time.Duration(float64(p.tickInterval) * p.speed))
+
+		for p.index < len(p.quotes) && !p.quotes[p.index].Time.After(p.position) {
+			quote := p.quotes[p.index]
+			_, exists := p.assetQuotes[quote.Symbol]
+			p.assetQuotes[quote.Symbol] = transformQuote(quote)
+			p.index++
+
+			if !p.isInAssetGroup(quote.Symbol) {
+				continue
+			}
+
+			// Symbols seen for the first time are not yet known to the UI so all quotes are sent together instead
+			if !exists {
+				p.assetGroupPending = true
+			}
+
+			assetQuotes = append(assetQuotes, p.assetQuotes[quote.Symbol])
+		}
+
+		if p.index == len(p.quotes) {
+			p.position = p.quotes[len(p.quotes)-1].Time
+		}
+	}
+
+	versionVector := p.assetGroupVersionVector
+	onUpdateAssetQuote := p.onUpdateAssetQuote
+	onUpdateAssetGroupQuote := p.onUpdateAssetGroupQuote
+
+	// Quotes are not sent until the asset group is set since the UI has nothing to show them in
+	if !p.assetGroupIsSet {
+		p.mu.Unlock()
+
+		return
+	}
+
+	if p.assetGroupPending {
+		p.assetGroupPending = false
+		assetGroupQuote := p.getAssetGroupQuote()
+		p.mu.Unlock()
+
+		onUpdateAssetGroupQuote(assetGroupQuote, versionVector)
+
+		return
+	}
+
+	p.mu.Unlock()
+
+	// Callbacks are run outside of the lock since they may block until the UI has handled the previous update
+	for _, assetQuote := range assetQuotes {
+		onUpdateAssetQuote(assetQuote.Symbol, assetQuote, versionVector)
+	}
+}
+
+// seek replaces all quotes with the latest quote for each symbol at or before the position
+func (p *Player) seek(position time.Time) {
+
+	first := p.quotes[0].Time
+	last := p.quotes[len(p.quotes)-1].Time
+
+	if position.Before(first) {
+		position = first
+	}
+
+	if position.After(last) {
+		position = last
+	}
+
+	p.position = position
+	p.index = 0
+	p.assetQuotes = make(map[string]c.AssetQuote)
+
+	for p.index < len(p.quotes) && !p.quotes[p.index].Time.After(position) {
+		p.assetQuotes[p.quotes[p.index].Symbol] = transformQuote(p.quotes[p.index])
+		p.index++
+	}
+
+	p.assetGroupPending = true
+}
+
+func (p *Player) isInAssetGroup(symbol string) bool {
+	return len(p.assetGroupSymbols) == 0 || p.assetGroupSymbols[strings.ToUpper(symbol)]
+}
+
+func (p *Player) getAssetGroupQuote() c.AssetGroupQuote {
+
+	assetQuotes := make([]c.AssetQuote, 0)
+
+	for symbol, assetQuote := range p.assetQuotes {
+		if p.isInAssetGroup(symbol) {
+			assetQuotes = append(assetQuotes, assetQuote)
+		}
+	}
+
+	sort.Slice(assetQuotes, func(i, j int) bool {
+		return assetQuotes[i].Symbol < assetQuotes[j].Symbol
+	})
+
+	return c.AssetGroupQuote{
+		AssetGroup:  p.assetGroup,
+		AssetQuotes: assetQuotes,
+	}
+}
+
+func transformQuote(quote history.Quote) c.AssetQuote {
+	return c.AssetQuote{
+		Name:   quote.Symbol,
+		Symbol: quote.Symbol,
+		Currency: c.Currency{
+			FromCurrencyCode: quote.Currency,
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          quote.Price,
+			PricePrevClose: quote.Price - quote.Change,
+			Change:         quote.Change,
+			ChangePercent:  quote.ChangePercent,
+		},
+		QuoteExtended: c.QuoteExtended{
+			Volume: quote.Volume,
+		},
+		Exchange: c.Exchange{
+			IsActive:                true,
+			IsRegularTradingSession: true,
+		},
+	}
+}
+
+func clampSpeed(speed float64) float64 {
+	return min(max(speed, speedMin), speedMax)
+}
@@ -0,0 +1,276 @@
+package replay_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/history"
+	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+	"github.com/achannarasappa/ticker/v5/internal/replay"
+)
+
+var _ = Describe("Replay", func() {
+
+	var (
+		start               time.Time
+		quotes              []history.Quote
+		mu                  sync.Mutex
+		assetQuotes         []c.AssetQuote
+		assetGroupQuotes    []c.AssetGroupQuote
+		versionVectors      []int
+		configUpdateFns     mon.ConfigUpdateFns
+		getAssetQuotes      func() []c.AssetQuote
+		getAssetGroupQuotes func() []c.AssetGroupQuote
+	)
+
+	matchPrices := func(prices g.Keys) OmegaMatcher {
+		return WithTransform(func(assetGroupQuote c.AssetGroupQuote) map[string]float64 {
+			output := make(map[string]float64)
+			for _, assetQuote := range assetGroupQuote.AssetQuotes {
+				output[assetQuote.Symbol] = assetQuote.QuotePrice.Price
+			}
+
+			return output
+		}, g.MatchAllKeys(prices))
+	}
+
+	BeforeEach(func() {
+		start = time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+		quotes = []history.Quote{
+			{Time: start, Symbol: "AAPL", Currency: "USD", Price: 150, Change: 2, ChangePercent: 1.35, Volume: 1000},
+			{Time: start.Add(time.Second), Symbol: "MSFT", Currency: "USD", Price: 410},
+			{Time: start.Add(2 * time.Second), Symbol: "AAPL", Currency: "USD", Price: 151, Change: 3, ChangePercent: 2.02},
+		}
+		assetQuotes = make([]c.AssetQuote, 0)
+		assetGroupQuotes = make([]c.AssetGroupQuote, 0)
+		versionVectors = make([]int, 0)
+		configUpdateFns = mon.ConfigUpdateFns{
+			OnUpdateAssetQuote: func(_ string, assetQuote c.AssetQuote, versionVector int) {
+				mu.Lock()
+				defer mu.Unlock()
+				assetQuotes = append(assetQuotes, assetQuote)
+				versionVectors = append(versionVectors, versionVector)
+			},
+			OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {
+				mu.Lock()
+				defer mu.Unlock()
+				assetGroupQuotes = append(assetGroupQuotes, assetGroupQuote)
+				versionVectors = append(versionVectors, versionVector)
+			},
+		}
+		getAssetQuotes = func() []c.AssetQuote {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return append([]c.AssetQuote{}, assetQuotes...)
+		}
+		getAssetGroupQuotes = func() []c.AssetGroupQuote {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return append([]c.AssetGroupQuote{}, assetGroupQuotes...)
+		}
+	})
+
+	Describe("NewPlayer", func() {
+
+		When("the recording has no quotes", func() {
+			It("should return an error", func() {
+				_, err := replay.NewPlayer(replay.Config{Quotes: []history.Quote{}})
+
+				Expect(err).To(MatchError("recording has no quotes"))
+			})
+		})
+
+		It("should start at the beginning of the recording", func() {
+			player, _ := replay.NewPlayer(replay.Config{Quotes: quotes})
+
+			Expect(player.Status()).To(Equal(replay.Status{Position: start, Speed: 1.0}))
+		})
+
+		When("the start option is set", func() {
+			It("should start from the offset into the recording", func() {
+				player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithStart(1500*time.Millisecond))
+
+				Expect(player.Status().Position).To(Equal(start.Add(1500 * time.Millisecond)))
+			})
+
+			It("should not start past the end of the recording", func() {
+				player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithStart(time.Hour))
+
+				Expect(player.Status()).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Position": Equal(start.Add(2 * time.Second)),
+					"Done":     BeTrue(),
+				}))
+			})
+		})
+
+	})
+
+	Describe("SetOnUpdate", func() {
+
+		When("a required callback is not set", func() {
+			It("should return an error", func() {
+				player, _ := replay.NewPlayer(replay.Config{Quotes: quotes})
+
+				err := player.SetOnUpdate(mon.ConfigUpdateFns{OnUpdateAssetQuote: configUpdateFns.OnUpdateAssetQuote})
+
+				Expect(err).To(MatchError("onUpdateAssetQuote and onUpdateAssetGroupQuote must be set"))
+			})
+		})
+
+	})
+
+	Describe("Start", func() {
+
+		It("should play the recorded quotes in order through the monitor callbacks", func() {
+			player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithTickInterval(time.Millisecond), replay.WithSpeed(64))
+			player.SetOnUpdate(configUpdateFns)                                                                              //nolint:errcheck
+			player.SetAssetGroup(c.AssetGroup{ConfigAssetGroup: c.ConfigAssetGroup{Watchlist: []string{"AAPL", "MSFT"}}}, 3) //nolint:errcheck
+			player.Start()
+			defer player.Stop()
+
+			Eventually(player.Status).Should(g.MatchFields(g.IgnoreExtras, g.Fields{"Done": BeTrue()}))
+			Eventually(getAssetQuotes).Should(HaveLen(1))
+
+			Expect(getAssetGroupQuotes()).To(HaveExactElements(
+				matchPrices(g.Keys{"AAPL": Equal(150.0)}),
+				matchPrices(g.Keys{"AAPL": Equal(150.0), "MSFT": Equal(410.0)}),
+			))
+			Expect(getAssetQuotes()).To(HaveExactElements(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol": Equal("AAPL"),
+					"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Price":          Equal(151.0),
+						"PricePrevClose": Equal(148.0),
+						"ChangePercent":  Equal(2.02),
+					}),
+				}),
+			))
+			Expect(versionVectors).To(HaveEach(3))
+		})
+
+		When("the asset group has symbols which are not in the recording", func() {
+			It("should only play quotes for symbols in the asset group", func() {
+				player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithTickInterval(time.Millisecond), replay.WithSpeed(64))
+				player.SetOnUpdate(configUpdateFns)                                                                          //nolint:errcheck
+				player.SetAssetGroup(c.AssetGroup{ConfigAssetGroup: c.ConfigAssetGroup{Lots: []c.Lot{{Symbol: "msft"}}}}, 0) //nolint:errcheck
+				player.Start()
+				defer player.Stop()
+
+				Eventually(player.Status).Should(g.MatchFields(g.IgnoreExtras, g.Fields{"Done": BeTrue()}))
+				Eventually(getAssetGroupQuotes).Should(HaveLen(2))
+
+				Expect(getAssetGroupQuotes()).To(HaveExactElements(
+					matchPrices(g.Keys{}),
+					matchPrices(g.Keys{"MSFT": Equal(410.0)}),
+				))
+				Expect(getAssetQuotes()).To(BeEmpty())
+			})
+		})
+
+		When("the asset group is not set", func() {
+			It("should not play any quotes", func() {
+				player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithTickInterval(time.Millisecond), replay.WithSpeed(64))
+				player.SetOnUpdate(configUpdateFns) //nolint:errcheck
+				player.Start()
+				defer player.Stop()
+
+				Eventually(player.Status).Should(g.MatchFields(g.IgnoreExtras, g.Fields{"Done": BeTrue()}))
+
+				Consistently(getAssetGroupQuotes, 20*time.Millisecond).Should(BeEmpty())
+				Expect(getAssetQuotes()).To(BeEmpty())
+			})
+		})
+
+	})
+
+	Describe("TogglePause", func() {
+
+		It("should stop advancing the position while paused and send the quotes at the current position", func() {
+			player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithTickInterval(time.Millisecond), replay.WithSpeed(64))
+			player.SetOnUpdate(configUpdateFns)     //nolint:errcheck
+			player.SetAssetGroup(c.AssetGroup{}, 0) //nolint:errcheck
+			player.TogglePause()
+			player.Start()
+			defer player.Stop()
+
+			Eventually(getAssetGroupQuotes).Should(HaveExactElements(matchPrices(g.Keys{"AAPL": Equal(150.0)})))
+			Consistently(player.Status, 20*time.Millisecond).Should(Equal(replay.Status{Position: start, Speed: 64, Paused: true}))
+
+			player.TogglePause()
+
+			Eventually(player.Status).Should(g.MatchFields(g.IgnoreExtras, g.Fields{"Paused": BeFalse(), "Done": BeTrue()}))
+		})
+
+	})
+
+	Describe("SetSpeed", func() {
+
+		It("should set the playback speed within the supported range", func() {
+			player, _ := replay.NewPlayer(replay.Config{Quotes: quotes})
+
+			player.SetSpeed(4)
+			Expect(player.Status().Speed).To(Equal(4.0))
+
+			player.SetSpeed(1000)
+			Expect(player.Status().Speed).To(Equal(64.0))
+
+			player.SetSpeed(0.01)
+			Expect(player.Status().Speed).To(Equal(0.25))
+		})
+
+	})
+
+	Describe("Seek", func() {
+
+		It("should move the position and send the latest quote for each symbol at the new position", func() {
+			player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithTickInterval(time.Millisecond))
+			player.SetOnUpdate(configUpdateFns)     //nolint:errcheck
+			player.SetAssetGroup(c.AssetGroup{}, 0) //nolint:errcheck
+			player.TogglePause()
+			player.Start()
+			defer player.Stop()
+
+			Eventually(getAssetGroupQuotes).Should(HaveLen(1))
+
+			player.Seek(1500 * time.Millisecond)
+
+			Expect(player.Status().Position).To(Equal(start.Add(1500 * time.Millisecond)))
+			Eventually(getAssetGroupQuotes).Should(HaveLen(2))
+			Expect(getAssetGroupQuotes()[1]).To(matchPrices(g.Keys{"AAPL": Equal(150.0), "MSFT": Equal(410.0)}))
+
+			player.Seek(-time.Hour)
+
+			Expect(player.Status().Position).To(Equal(start))
+			Eventually(getAssetGroupQuotes).Should(HaveLen(3))
+			Expect(getAssetGroupQuotes()[2]).To(matchPrices(g.Keys{"AAPL": Equal(150.0)}))
+		})
+
+	})
+
+	Describe("GetPriceHistory", func() {
+
+		It("should return the recorded prices for the symbol up to the current position", func() {
+			player, _ := replay.NewPlayer(replay.Config{Quotes: quotes}, replay.WithStart(time.Second))
+
+			output, err := player.GetPriceHistory(c.AssetQuote{Symbol: "AAPL"}, c.PriceHistoryRange1Day, c.PriceHistoryInterval5Minute)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+				"Symbol": Equal("AAPL"),
+				"Candles": HaveExactElements(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Time":  Equal(start),
+					"Close": Equal(150.0),
+				})),
+			}))
+		})
+
+	})
+
+})
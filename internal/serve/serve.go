@@ -0,0 +1,266 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+// shutdownTimeout is how long in flight scrapes are given to finish when the server stops
+const shutdownTimeout = 5 * time.Second
+
+// Options to configure serve behavior
+type Options struct {
+	MetricsAddress string // Address to serve Prometheus metrics on (e.g. ":9090")
+}
+
+// symbolKey identifies a symbol in the API of a quote source
+type symbolKey struct {
+	source c.QuoteSource
+	symbol string
+}
+
+// collector keeps the latest quotes for all groups and sets the assets of each group on the exporter when quotes are updated
+type collector struct {
+	ctx               c.Context
+	exporter          *metrics.Exporter
+	groupSymbols      []map[symbolKey]bool
+	assetQuotes       []c.AssetQuote
+	assetQuotesLookup map[string]int
+	mu                sync.Mutex
+}
+
+func newCollector(ctx c.Context, exporter *metrics.Exporter) *collector {
+
+	groupSymbols := make([]map[symbolKey]bool, 0, len(ctx.Groups))
+
+	for _, assetGroup := range ctx.Groups {
+		symbols := make(map[symbolKey]bool)
+
+		for _, symbolsBySource := range assetGroup.SymbolsBySource {
+			for _, symbol := range symbolsBySource.Symbols {
+				symbols[symbolKey{source: symbolsBySource.Source, symbol: strings.ToUpper(symbol)}] = true
+			}
+		}
+
+		groupSymbols = append(groupSymbols, symbols)
+	}
+
+	return &collector{
+		ctx:               ctx,
+		exporter:          exporter,
+		groupSymbols:      groupSymbols,
+		assetQuotesLookup: make(map[string]int),
+	}
+}
+
+// getConfigUpdateFns returns callbacks which update the exporter from the monitor
+func (co *collector) getConfigUpdateFns() mon.ConfigUpdateFns {
+	return mon.ConfigUpdateFns{
+		OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, _ int) {
+			co.setAssetQuote(symbol, assetQuote)
+		},
+		OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, _ int) {
+			co.setAssetGroupQuote(assetGroupQuote)
+		},
+		OnUpdateStreamStatus: co.exporter.SetStreamStatus,
+		OnError: func(_ error) {
+			co.exporter.RecordError()
+		},
+	}
+}
+
+// setAssetGroupQuote replaces the quotes for all groups
+func (co *collector) setAssetGroupQuote(assetGroupQuote c.AssetGroupQuote) {
+
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	// Skip quotes for currency rates received before the groups are set on the monitor
+	if len(assetGroupQuote.AssetGroup.SymbolsBySource) == 0 {
+		return
+	}
+
+	co.assetQuotes = assetGroupQuote.AssetQuotes
+	co.assetQuotesLookup = make(map[string]int, len(co.assetQuotes))
+
+	for i, assetQuote := range co.assetQuotes {
+		co.assetQuotesLookup[assetQuote.Symbol] = i
+		co.exporter.RecordUpdate(assetQuote.QuoteSource)
+	}
+
+	co.setAssets()
+}
+
+// setAssetQuote updates the quote for a symbol
+func (co *collector) setAssetQuote(symbol string, assetQuote c.AssetQuote) {
+
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	i, ok := co.assetQuotesLookup[symbol]
+	if !ok || i >= len(co.assetQuotes) || co.assetQuotes[i].Symbol != symbol {
+		return
+	}
+
+	co.assetQuotes[i] = assetQuote
+	co.exporter.RecordUpdate(assetQuote.QuoteSource)
+
+	co.setAssets()
+}
+
+// setAssets sets the assets of each group on the exporter from the quotes for the symbols in the group
+func (co *collector) setAssets() {
+
+	for i, assetGroup := range co.ctx.Groups {
+		assetQuotes := make([]c.AssetQuote, 0, len(co.groupSymbols[i]))
+
+		for _, assetQuote := range co.assetQuotes {
+			if co.groupSymbols[i][symbolKey{source: assetQuote.QuoteSource, symbol: strings.ToUpper(assetQuote.Meta.SymbolInSourceAPI)}] {
+				assetQuotes = append(assetQuotes, assetQuote)
+			}
+		}
+
+		assets, _ := asset.GetAssets(co.ctx, c.AssetGroupQuote{
+			AssetQuotes: assetQuotes,
+			AssetGroup:  assetGroup,
+		})
+
+		co.exporter.SetAssets(assetGroup.Name, assets)
+	}
+}
+
+// getAssetGroupAll returns a group with the symbols of every group so that a single monitor gets quotes for all groups
+func getAssetGroupAll(assetGroups []c.AssetGroup) c.AssetGroup {
+
+	symbolsBySource := make([]c.AssetGroupSymbolsBySource, 0)
+	indexBySource := make(map[c.QuoteSource]int)
+	seen := make(map[symbolKey]bool)
+
+	for _, assetGroup := range assetGroups {
+		for _, groupSymbolsBySource := range assetGroup.SymbolsBySource {
+			i, ok := indexBySource[groupSymbolsBySource.Source]
+			if !ok {
+				i = len(symbolsBySource)
+				indexBySource[groupSymbolsBySource.Source] = i
+				symbolsBySource = append(symbolsBySource, c.AssetGroupSymbolsBySource{Source: groupSymbolsBySource.Source})
+			}
+
+			for _, symbol := range groupSymbolsBySource.Symbols {
+				key := symbolKey{source: groupSymbolsBySource.Source, symbol: symbol}
+
+				if !seen[key] {
+					seen[key] = true
+					symbolsBySource[i].Symbols = append(symbolsBySource[i].Symbols, symbol)
+				}
+			}
+		}
+	}
+
+	return c.AssetGroup{SymbolsBySource: symbolsBySource}
+}
+
+func validateOptions(options *Options) error {
+
+	if options.MetricsAddress == "" {
+		return errors.New("invalid option: metrics address must be set (e.g. --metrics :9090)")
+	}
+
+	return nil
+}
+
+// Run gets quotes for all groups and serves metrics until interrupted
+func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, _ []string) {
+
+		cmdCtx := cmd.Context()
+		if cmdCtx == nil {
+			cmdCtx = context.Background()
+		}
+
+		if err := run(cmdCtx, dep, ctx, options); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+func run(cmdCtx context.Context, dep *c.Dependencies, ctx *c.Context, options *Options) error {
+
+	if err := validateOptions(options); err != nil {
+		return err
+	}
+
+	monitors, err := mon.NewMonitor(mon.NewConfigMonitor(*dep, *ctx))
+	if err != nil {
+		return err
+	}
+
+	exporter := metrics.NewExporter(metrics.Config{})
+	co := newCollector(*ctx, exporter)
+
+	err = monitors.SetOnUpdate(co.getConfigUpdateFns())
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", options.MetricsAddress)
+	if err != nil {
+		return fmt.Errorf("unable to serve metrics: %w", err)
+	}
+
+	serveCtx, cancel := signal.NotifyContext(cmdCtx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	chanServeError := make(chan error, 1)
+
+	go func() {
+		chanServeError <- server.Serve(listener)
+	}()
+
+	monitors.Start()
+	defer monitors.Stop()
+
+	// A source which fails to set symbols is counted as a monitor error so that it can be alerted on rather than stopping the server
+	err = monitors.SetAssetGroup(getAssetGroupAll(ctx.Groups), 0)
+	if err != nil {
+		exporter.RecordError()
+
+		if ctx.Config.Debug {
+			ctx.Logger.Println(err)
+		}
+	}
+
+	select {
+	case <-serveCtx.Done():
+	case err = <-chanServeError:
+		return fmt.Errorf("unable to serve metrics: %w", err)
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	return server.Shutdown(shutdownCtx)
+}
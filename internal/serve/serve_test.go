@@ -0,0 +1,218 @@
+package serve_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/spf13/cobra"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
+	"github.com/achannarasappa/ticker/v5/internal/serve"
+)
+
+func getStdout(fn func()) string {
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	fn()
+
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	return string(out)
+}
+
+// getFreeAddress returns a local address with a port which is not in use
+func getFreeAddress() string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func scrape(address string) string {
+	resp, err := http.Get("http://" + address + "/metrics") //nolint:noctx
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	return string(body)
+}
+
+var _ = Describe("Serve", func() {
+
+	var (
+		server            *ghttp.Server
+		inputContext      c.Context
+		inputDependencies c.Dependencies
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		server.RouteToHandler(http.MethodGet, "/v7/finance/quote",
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("fields") == "regularMarketPrice,currency" {
+					json.NewEncoder(w).Encode(currencyResponseFixture) //nolint:errcheck
+
+					return
+				}
+
+				json.NewEncoder(w).Encode(quoteFixture) //nolint:errcheck
+			},
+		)
+
+		inputDependencies = c.Dependencies{
+			MonitorYahooBaseURL:           server.URL(),
+			MonitorYahooSessionRootURL:    server.URL(),
+			MonitorYahooSessionCrumbURL:   server.URL(),
+			MonitorYahooSessionConsentURL: server.URL(),
+		}
+
+		inputContext = c.Context{
+			Groups: []c.AssetGroup{
+				{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{Source: c.QuoteSourceYahoo, Symbols: []string{"GOOG", "RBLX"}},
+					},
+					ConfigAssetGroup: c.ConfigAssetGroup{
+						Name: "default",
+						Lots: []c.Lot{
+							{Symbol: "GOOG", UnitCost: 1000, Quantity: 10},
+						},
+					},
+				},
+				{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{Source: c.QuoteSourceYahoo, Symbols: []string{"RBLX"}},
+					},
+					ConfigAssetGroup: c.ConfigAssetGroup{
+						Name: "retirement",
+						Lots: []c.Lot{
+							{Symbol: "RBLX", UnitCost: 50, Quantity: 10},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Run", func() {
+
+		It("should serve metrics for the symbols in each group until the command is canceled", func() {
+			address := getFreeAddress()
+			cmdCtx, cancel := context.WithCancel(context.Background())
+			cmd := &cobra.Command{}
+			cmd.SetContext(cmdCtx)
+			done := make(chan string)
+
+			go func() {
+				done <- getStdout(func() {
+					serve.Run(&inputDependencies, &inputContext, &serve.Options{MetricsAddress: address})(cmd, []string{})
+				})
+			}()
+
+			Eventually(func() string { return scrape(address) }).Should(ContainSubstring(`ticker_price{group="default",symbol="RBLX",source="yahoo",currency="USD"} 87.88`))
+
+			output := scrape(address)
+			Expect(output).To(ContainSubstring(`ticker_price{group="default",symbol="GOOG",source="yahoo",currency="USD"} 2838.42`))
+			Expect(output).To(ContainSubstring(`ticker_change_percent{group="default",symbol="GOOG",source="yahoo",currency="USD"} 10`))
+			Expect(output).To(ContainSubstring(`ticker_position_value{group="default",symbol="GOOG",source="yahoo",currency="USD"} 28384.2`))
+			Expect(output).To(ContainSubstring(`ticker_position_cost{group="default",symbol="GOOG",source="yahoo",currency="USD"} 10000`))
+			Expect(output).To(ContainSubstring(`ticker_price{group="retirement",symbol="RBLX",source="yahoo",currency="USD"} 87.88`))
+			Expect(output).To(ContainSubstring(`ticker_position_weight{group="retirement",symbol="RBLX",source="yahoo",currency="USD"} 100`))
+			Expect(output).NotTo(ContainSubstring(`ticker_position_value{group="default",symbol="RBLX"`))
+			Expect(output).NotTo(ContainSubstring(`group="retirement",symbol="GOOG"`))
+			Expect(output).To(ContainSubstring(`ticker_last_update_age_seconds{source="yahoo"}`))
+
+			cancel()
+
+			Eventually(done).Should(Receive(BeEmpty()))
+			Expect(scrape(address)).To(BeEmpty())
+		})
+
+		When("the metrics address is not set", func() {
+			It("should print an error", func() {
+				output := getStdout(func() {
+					serve.Run(&inputDependencies, &inputContext, &serve.Options{})(&cobra.Command{}, []string{})
+				})
+
+				Expect(output).To(Equal("invalid option: metrics address must be set (e.g. --metrics :9090)\n"))
+			})
+		})
+
+		When("the metrics address is in use", func() {
+			It("should print an error", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).NotTo(HaveOccurred())
+				defer listener.Close()
+
+				output := getStdout(func() {
+					serve.Run(&inputDependencies, &inputContext, &serve.Options{MetricsAddress: listener.Addr().String()})(&cobra.Command{}, []string{})
+				})
+
+				Expect(output).To(HavePrefix("unable to serve metrics: listen tcp " + listener.Addr().String()))
+			})
+		})
+
+	})
+
+})
+
+var currencyResponseFixture = unary.Response{
+	QuoteResponse: unary.ResponseQuoteResponse{
+		Quotes: []unary.ResponseQuote{
+			{
+				Currency: "USD",
+				Symbol:   "RBLX",
+			},
+			{
+				Currency: "USD",
+				Symbol:   "GOOG",
+			},
+		},
+	},
+}
+
+var quoteFixture = unary.Response{
+	QuoteResponse: unary.ResponseQuoteResponse{
+		Quotes: []unary.ResponseQuote{
+			{
+				ShortName:                  "Alphabet Inc.",
+				Symbol:                     "GOOG",
+				MarketState:                "REGULAR",
+				Currency:                   "USD",
+				RegularMarketPrice:         unary.ResponseFieldFloat{Raw: 2838.42, Fmt: "2838.42"},
+				RegularMarketChangePercent: unary.ResponseFieldFloat{Raw: 10.00, Fmt: "10.00"},
+				RegularMarketChange:        unary.ResponseFieldFloat{Raw: 283.84, Fmt: "283.84"},
+			},
+			{
+				ShortName:                  "Roblox Corporation",
+				Symbol:                     "RBLX",
+				MarketState:                "REGULAR",
+				Currency:                   "USD",
+				RegularMarketPrice:         unary.ResponseFieldFloat{Raw: 87.88, Fmt: "87.88"},
+				RegularMarketChangePercent: unary.ResponseFieldFloat{Raw: -10.00, Fmt: "-10.00"},
+				RegularMarketChange:        unary.ResponseFieldFloat{Raw: -8.79, Fmt: "-8.79"},
+			},
+		},
+	},
+}
@@ -14,9 +14,10 @@ import (
 
 // Model for summary section
 type Model struct {
-	width   int
-	summary asset.PositionSummary
-	styles  c.Styles
+	width       int
+	summary     asset.PositionSummary
+	styles      c.Styles
+	showReturns bool
 }
 
 type SetSummaryMsg asset.PositionSummary
@@ -24,8 +25,9 @@ type SetSummaryMsg asset.PositionSummary
 // NewModel returns a model with default values
 func NewModel(ctx c.Context) *Model {
 	return &Model{
-		width:  80,
-		styles: ctx.Reference.Styles,
+		width:       80,
+		styles:      ctx.Reference.Styles,
+		showReturns: ctx.Config.ShowReturns,
 	}
 }
 
@@ -58,6 +60,7 @@ func (m *Model) View() string {
 	}
 
 	textChange := m.styles.TextLabel("Day Change: ") + quoteChangeText(m.summary.DayChange.Amount, m.summary.DayChange.Percent, m.styles) +
+		benchmarkText(m.summary.Benchmark, m.styles) +
 		m.styles.TextLabel(" • ") +
 		m.styles.TextLabel("Change: ") + quoteChangeText(m.summary.TotalChange.Amount, m.summary.TotalChange.Percent, m.styles)
 	widthChange := ansi.PrintableRuneWidth(textChange)
@@ -68,26 +71,57 @@ func (m *Model) View() string {
 		m.styles.TextLabel("Cost: ") + m.styles.TextLabel(u.ConvertFloatToString(m.summary.Cost, false))
 	widthCost := ansi.PrintableRuneWidth(textValue)
 
+	cells := []grid.Cell{
+		{
+			Text:  textChange,
+			Width: widthChange,
+		},
+		{
+			Text:            textValue,
+			Width:           widthValue,
+			VisibleMinWidth: widthChange + widthValue,
+		},
+		{
+			Text:            textCost,
+			Width:           widthCost,
+			VisibleMinWidth: widthChange + widthValue + widthCost,
+		},
+	}
+
+	if m.summary.Realized != 0.0 {
+		textRealized := m.styles.TextLabel(" • ") +
+			m.styles.TextLabel("Realized: ") + realizedText(m.summary.Realized, m.styles)
+		widthRealized := ansi.PrintableRuneWidth(textRealized)
+
+		cells = append(cells, grid.Cell{
+			Text:            textRealized,
+			Width:           widthRealized,
+			VisibleMinWidth: widthChange + widthValue + widthCost + widthRealized,
+		})
+	}
+
+	if m.showReturns && m.summary.AnnualizedReturn != 0.0 {
+		textReturn := m.styles.TextLabel(" • ") +
+			m.styles.TextLabel("Annualized: ") + m.styles.TextPrice(m.summary.AnnualizedReturn, u.ConvertFloatToString(m.summary.AnnualizedReturn, false)+"%")
+		widthReturn := ansi.PrintableRuneWidth(textReturn)
+		widthCells := 0
+
+		for _, cell := range cells {
+			widthCells += cell.Width
+		}
+
+		cells = append(cells, grid.Cell{
+			Text:            textReturn,
+			Width:           widthReturn,
+			VisibleMinWidth: widthCells + widthReturn,
+		})
+	}
+
 	return grid.Render(grid.Grid{
 		Rows: []grid.Row{
 			{
 				Width: m.width,
-				Cells: []grid.Cell{
-					{
-						Text:  textChange,
-						Width: widthChange,
-					},
-					{
-						Text:            textValue,
-						Width:           widthValue,
-						VisibleMinWidth: widthChange + widthValue,
-					},
-					{
-						Text:            textCost,
-						Width:           widthCost,
-						VisibleMinWidth: widthChange + widthValue + widthCost,
-					},
-				},
+				Cells: cells,
 			},
 			{
 				Width: m.width,
@@ -112,3 +146,35 @@ func quoteChangeText(change float64, changePercent float64, styles c.Styles) str
 
 	return styles.TextPrice(changePercent, "↓ "+u.ConvertFloatToString(change, false)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
 }
+
+// benchmarkText returns the day change of the benchmark and of the positions relative to it to show next to the day change of the positions
+func benchmarkText(benchmark asset.Benchmark, styles c.Styles) string {
+	if benchmark.Symbol == "" {
+		return ""
+	}
+
+	return styles.TextLabel(" • ") +
+		styles.TextLabel(benchmark.Symbol+": ") + percentChangeText(benchmark.ChangePercent, styles) +
+		styles.TextLabel(" • ") +
+		styles.TextLabel("Relative: ") + percentChangeText(benchmark.RelativePercent, styles)
+}
+
+func percentChangeText(changePercent float64, styles c.Styles) string {
+	if changePercent == 0.0 {
+		return styles.TextLabel(u.ConvertFloatToString(changePercent, false) + "%")
+	}
+
+	if changePercent > 0.0 {
+		return styles.TextPrice(changePercent, "↑ "+u.ConvertFloatToString(changePercent, false)+"%")
+	}
+
+	return styles.TextPrice(changePercent, "↓ "+u.ConvertFloatToString(changePercent, false)+"%")
+}
+
+func realizedText(realized float64, styles c.Styles) string {
+	if realized > 0.0 {
+		return styles.TextPrice(realized, "↑ "+u.ConvertFloatToString(realized, false))
+	}
+
+	return styles.TextPrice(realized, "↓ "+u.ConvertFloatToString(realized, false))
+}
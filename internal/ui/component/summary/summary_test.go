@@ -75,6 +75,76 @@ var _ = Describe("Summary", func() {
 		})
 	})
 
+	When("there are realized gains", func() {
+		It("should render the realized gains after the cost", func() {
+			m := NewModel(ctxFixture)
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 140})
+			m, _ = m.Update(SetSummaryMsg(asset.PositionSummary{
+				Value: 10000,
+				Cost:  1000,
+				DayChange: c.PositionChange{
+					Amount:  100.0,
+					Percent: 10.0,
+				},
+				TotalChange: c.PositionChange{
+					Amount:  9000,
+					Percent: 1000.0,
+				},
+				Realized: 250.5,
+			}))
+			Expect(removeFormatting(m.View())).To(ContainSubstring("• Realized: ↑ 250.50"))
+		})
+	})
+
+	When("there is a benchmark", func() {
+		It("should render the benchmark and relative day change next to the day change", func() {
+			m := NewModel(ctxFixture)
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 160})
+			m, _ = m.Update(SetSummaryMsg(asset.PositionSummary{
+				Value: 10000,
+				Cost:  1000,
+				DayChange: c.PositionChange{
+					Amount:  100.0,
+					Percent: 1.0,
+				},
+				Benchmark: asset.Benchmark{
+					Symbol:          "SPY",
+					ChangePercent:   1.5,
+					RelativePercent: -0.5,
+				},
+			}))
+			Expect(removeFormatting(m.View())).To(ContainSubstring("Day Change: ↑ 100.00 (1.00%) • SPY: ↑ 1.50% • Relative: ↓ -0.50% • Change:"))
+		})
+	})
+
+	When("the annualized return is shown", func() {
+		It("should render the annualized return after the cost", func() {
+			ctx := ctxFixture
+			ctx.Config.ShowReturns = true
+			m := NewModel(ctx)
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 140})
+			m, _ = m.Update(SetSummaryMsg(asset.PositionSummary{
+				Value:            10000,
+				Cost:             1000,
+				AnnualizedReturn: 8.5,
+			}))
+			Expect(removeFormatting(m.View())).To(ContainSubstring("• Annualized: 8.50%"))
+		})
+
+		When("the option is not set", func() {
+			It("should not render the annualized return", func() {
+				m := NewModel(ctxFixture)
+				m, _ = m.Update(tea.WindowSizeMsg{Width: 140})
+				m, _ = m.Update(SetSummaryMsg(asset.PositionSummary{
+					Value:            10000,
+					Cost:             1000,
+					AnnualizedReturn: 8.5,
+				}))
+				Expect(removeFormatting(m.View())).ToNot(ContainSubstring("Annualized"))
+			})
+		})
+	})
+
 	When("no quotes are set", func() {
 		It("should render an empty summary", func() {
 			m := NewModel(ctxFixture)
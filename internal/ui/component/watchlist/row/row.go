@@ -22,8 +22,12 @@ const (
 	WidthPositionGutter = 2
 	WidthChangeStatic   = 12 // "↓ " + " (100.00%)" = 12 length
 	WidthRangeStatic    = 3  // " - " = 3 length
+	WidthSparkline      = 20
+	WidthReturn         = 10 // "-100.00%" and label "Annualized"
 )
 
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█") //nolint:gochecknoglobals
+
 var lastID int64 //nolint:gochecknoglobals
 
 type SetCellWidthsMsg struct {
@@ -40,6 +44,7 @@ type CellWidthsContainer struct {
 	WidthPosition         int
 	WidthPositionExtended int
 	WidthVolumeMarketCap  int
+	WidthQuoteDepth       int // Zero when no asset has a best bid and ask
 }
 
 type Config struct {
@@ -48,12 +53,20 @@ type Config struct {
 	ShowPositions         bool
 	ExtraInfoExchange     bool
 	ExtraInfoFundamentals bool
+	ShowSparkline         bool
+	ShowReturns           bool
 	Styles                c.Styles
 	Asset                 *c.Asset
 }
 
 type UpdateAssetMsg *c.Asset
 
+// SetSparklineMsg sets the prices, ordered from oldest to newest, drawn in the sparkline
+type SetSparklineMsg []float64
+
+// SetAlertMsg sets whether an alert has recently fired for the asset which highlights the symbol
+type SetAlertMsg bool
+
 type FrameMsg int
 
 // Model for watchlist row
@@ -67,6 +80,8 @@ type Model struct {
 	priceChangeSegment   string
 	priceNoChangeSegment string
 	priceChangeDirection int
+	sparkline            []float64
+	alert                bool
 }
 
 // New returns a model with default values
@@ -103,6 +118,16 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 		return m, nil
 
+	case SetSparklineMsg:
+		m.sparkline = msg
+
+		return m, nil
+
+	case SetAlertMsg:
+		m.alert = bool(msg)
+
+		return m, nil
+
 	case UpdateAssetMsg:
 
 		// If symbol has not changed and price has changed then start the price animation
@@ -237,10 +262,10 @@ func (m *Model) View() string {
 
 func (m *Model) buildCells() []grid.Cell {
 
-	if !m.config.ExtraInfoFundamentals && !m.config.ShowPositions {
+	if !m.config.ExtraInfoFundamentals && !m.config.ShowPositions && !m.config.ShowSparkline && !m.config.ShowReturns {
 
 		return []grid.Cell{
-			{Text: textName(m.config.Asset, m.config.Styles)},
+			{Text: textName(m.config.Asset, m.config.Styles, m.alert)},
 			{Text: textMarketState(m.config.Asset, m.config.Styles), Width: WidthMarketState, Align: grid.Right},
 			{Text: textQuote(m.config.Asset, m.config.Styles, m.priceStyle, m.priceNoChangeSegment, m.priceChangeSegment), Width: m.cellWidths.WidthQuote, Align: grid.Right},
 		}
@@ -248,7 +273,7 @@ func (m *Model) buildCells() []grid.Cell {
 	}
 
 	cellName := []grid.Cell{
-		{Text: textName(m.config.Asset, m.config.Styles), Width: WidthName},
+		{Text: textName(m.config.Asset, m.config.Styles, m.alert), Width: WidthName},
 		{Text: ""},
 		{Text: textMarketState(m.config.Asset, m.config.Styles), Width: WidthMarketState, Align: grid.Right},
 	}
@@ -258,6 +283,40 @@ func (m *Model) buildCells() []grid.Cell {
 	}
 	widthMinTerm := WidthName + WidthMarketState + m.cellWidths.WidthQuote + (3 * WidthGutter)
 
+	if m.config.ShowSparkline {
+		widthSparkline := widthMinTerm + WidthSparkline + WidthGutter
+
+		cells = append(
+			[]grid.Cell{
+				{
+					Text:            textSparkline(m.sparkline, WidthSparkline, m.config.Styles),
+					Width:           WidthSparkline,
+					Align:           grid.Right,
+					VisibleMinWidth: widthSparkline,
+				},
+			},
+			cells...,
+		)
+		widthMinTerm = widthSparkline
+	}
+
+	if m.config.ShowReturns {
+		widthReturn := widthMinTerm + WidthReturn + WidthGutter
+
+		cells = append(
+			[]grid.Cell{
+				{
+					Text:            textReturn(m.config.Asset, m.config.Styles),
+					Width:           WidthReturn,
+					Align:           grid.Right,
+					VisibleMinWidth: widthReturn,
+				},
+			},
+			cells...,
+		)
+		widthMinTerm = widthReturn
+	}
+
 	if m.config.ShowPositions {
 		widthHoldings := widthMinTerm + m.cellWidths.WidthPosition + (3 * WidthGutter) + m.cellWidths.WidthPositionExtended + WidthLabel
 
@@ -287,6 +346,27 @@ func (m *Model) buildCells() []grid.Cell {
 		widthMinTerm = widthHoldings
 	}
 
+	if m.config.ExtraInfoFundamentals && m.cellWidths.WidthQuoteDepth > 0 {
+		widthFundamentals := widthMinTerm + m.cellWidths.WidthQuoteExtended + m.cellWidths.WidthQuoteRange + m.cellWidths.WidthVolumeMarketCap
+		cells = append(
+			[]grid.Cell{
+				{
+					Text:            textQuoteDepthLabels(m.config.Asset, m.config.Styles),
+					Width:           WidthLabel,
+					Align:           grid.Right,
+					VisibleMinWidth: widthFundamentals + m.cellWidths.WidthQuoteDepth + (8 * WidthGutter) + (4 * WidthLabel),
+				},
+				{
+					Text:            textQuoteDepth(m.config.Asset, m.config.Styles),
+					Width:           m.cellWidths.WidthQuoteDepth,
+					Align:           grid.Right,
+					VisibleMinWidth: widthFundamentals + m.cellWidths.WidthQuoteDepth + (7 * WidthGutter) + (3 * WidthLabel),
+				},
+			},
+			cells...,
+		)
+	}
+
 	if m.config.ExtraInfoFundamentals {
 		cells = append(
 			[]grid.Cell{
@@ -340,12 +420,24 @@ func (m *Model) buildCells() []grid.Cell {
 
 }
 
-func textName(asset *c.Asset, styles c.Styles) string {
+func textName(asset *c.Asset, styles c.Styles, alert bool) string {
 
 	if len(asset.Name) > 20 {
 		asset.Name = asset.Name[:20]
 	}
 
+	if alert {
+		return styles.Alert(asset.Symbol) +
+			"\n" +
+			styles.TextLabel(asset.Name)
+	}
+
+	if asset.QuoteSource == c.QuoteSourceCash {
+		return styles.Cash(asset.Symbol) +
+			"\n" +
+			styles.TextLabel(asset.Name)
+	}
+
 	return styles.TextBold(asset.Symbol) +
 		"\n" +
 		styles.TextLabel(asset.Name)
@@ -378,6 +470,17 @@ func textPosition(asset *c.Asset, styles c.Styles) string {
 		positionChange
 }
 
+func textReturn(asset *c.Asset, styles c.Styles) string {
+
+	if asset.Position.AnnualizedReturn == 0.0 {
+		return ""
+	}
+
+	return styles.TextPrice(asset.Position.AnnualizedReturn, u.ConvertFloatToString(asset.Position.AnnualizedReturn, false)+"%") +
+		"\n" +
+		styles.TextLabel("Annualized")
+}
+
 func textQuoteExtended(asset *c.Asset, styles c.Styles) string {
 
 	if asset.Class == c.AssetClassFuturesContract && asset.QuoteFutures.IndexPrice == 0.0 {
@@ -523,6 +626,80 @@ func textVolumeMarketCapLabels(asset *c.Asset, styles c.Styles) string {
 		styles.TextLabel("Volume:")
 }
 
+func textQuoteDepth(asset *c.Asset, styles c.Styles) string {
+
+	if asset.QuoteDepth.BidPrice == 0.0 || asset.QuoteDepth.AskPrice == 0.0 {
+		return ""
+	}
+
+	return styles.Text(textQuoteDepthBidAsk(asset)) +
+		"\n" +
+		styles.Text(textQuoteDepthSpread(asset))
+}
+
+func textQuoteDepthLabels(asset *c.Asset, styles c.Styles) string {
+
+	if asset.QuoteDepth.BidPrice == 0.0 || asset.QuoteDepth.AskPrice == 0.0 {
+		return ""
+	}
+
+	return styles.TextLabel("Bid / Ask:") +
+		"\n" +
+		styles.TextLabel("Spread:")
+}
+
+func textQuoteDepthBidAsk(asset *c.Asset) string {
+	return u.ConvertFloatToString(asset.QuoteDepth.BidPrice, asset.Meta.IsVariablePrecision) +
+		" / " +
+		u.ConvertFloatToString(asset.QuoteDepth.AskPrice, asset.Meta.IsVariablePrecision)
+}
+
+func textQuoteDepthSpread(asset *c.Asset) string {
+	return u.ConvertFloatToString(asset.QuoteDepth.Spread, asset.Meta.IsVariablePrecision) +
+		" (" + u.ConvertFloatToString(asset.QuoteDepth.SpreadPercent, false) + "%)"
+}
+
+// textSparkline draws the price path with one block character per bucket of prices scaled between the lowest and highest price
+func textSparkline(prices []float64, width int, styles c.Styles) string {
+
+	if len(prices) < 2 {
+		return ""
+	}
+
+	// Use the last price in each bucket when there are more prices than characters
+	points := prices
+	if len(prices) > width {
+		points = make([]float64, width)
+		for i := range points {
+			points[i] = prices[((i+1)*len(prices)/width)-1]
+		}
+	}
+
+	low, high := points[0], points[0]
+	for _, point := range points {
+		low = min(low, point)
+		high = max(high, point)
+	}
+
+	var sb strings.Builder
+
+	for _, point := range points {
+		level := 0
+		if high > low {
+			level = int((point - low) / (high - low) * float64(len(sparklineBlocks)-1))
+		}
+		sb.WriteRune(sparklineBlocks[level])
+	}
+
+	changePercent := 0.0
+	if points[0] != 0.0 {
+		changePercent = (points[len(points)-1] - points[0]) / points[0] * 100
+	}
+
+	return styles.TextPrice(changePercent, sb.String()) +
+		"\n"
+}
+
 func textMarketState(asset *c.Asset, styles c.Styles) string {
 	if asset.Exchange.IsRegularTradingSession {
 		return styles.TextLabel(" ●  ")
@@ -6,6 +6,8 @@ import (
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	"github.com/achannarasappa/ticker/v5/internal/ui/component/watchlist/row"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -20,6 +22,8 @@ var styles = c.Styles{
 	TextLine:  func(v string) string { return v },
 	TextPrice: func(percent float64, text string) string { return text },
 	Tag:       func(v string) string { return v },
+	Alert:     func(v string) string { return "\x1b[7m" + v + "\x1b[0m" },
+	Cash:      func(v string) string { return "\x1b[4m" + v + "\x1b[0m" },
 }
 
 var _ = Describe("Row", func() {
@@ -291,6 +295,190 @@ var _ = Describe("Row", func() {
 
 		})
 
+		Describe("SetSparklineMsg", func() {
+
+			var inputRow *row.Model
+
+			BeforeEach(func() {
+				inputRow = row.New(row.Config{
+					ShowSparkline: true,
+					Styles:        styles,
+					Asset: &c.Asset{
+						Symbol: "AAPL",
+						QuotePrice: c.QuotePrice{
+							Price: 150.00,
+						},
+					},
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{
+					Width:      120,
+					CellWidths: row.CellWidthsContainer{WidthQuote: 20},
+				})
+			})
+
+			It("should draw the prices with a block character for each price", func() {
+				outputRow, cmd := inputRow.Update(row.SetSparklineMsg{1, 2, 3, 4, 5, 6, 7, 8})
+
+				Expect(cmd).To(BeNil())
+				Expect(outputRow.View()).To(ContainSubstring("▁▂▃▄▅▆▇█"))
+			})
+
+			When("there are more prices than characters in the sparkline", func() {
+				It("should draw the last price in each group of prices", func() {
+					prices := make([]float64, 0, 100)
+					for i := range 100 {
+						prices = append(prices, float64(i))
+					}
+
+					outputRow, _ := inputRow.Update(row.SetSparklineMsg(prices))
+					sparkline := regexp.MustCompile(`[▁▂▃▄▅▆▇█]+`).FindString(outputRow.View())
+
+					Expect([]rune(sparkline)).To(HaveLen(row.WidthSparkline))
+					Expect(sparkline).To(HavePrefix("▁"))
+					Expect(sparkline).To(HaveSuffix("█"))
+				})
+			})
+
+			When("the prices have not changed", func() {
+				It("should draw a flat line", func() {
+					outputRow, _ := inputRow.Update(row.SetSparklineMsg{5, 5, 5, 5})
+
+					Expect(outputRow.View()).To(ContainSubstring("▁▁▁▁"))
+				})
+			})
+
+			When("there are fewer than two prices", func() {
+				It("should not draw the sparkline", func() {
+					outputRow, _ := inputRow.Update(row.SetSparklineMsg{5})
+
+					Expect(outputRow.View()).NotTo(ContainSubstring("▁"))
+				})
+			})
+
+			When("the terminal is too narrow to show the sparkline", func() {
+				It("should hide the sparkline", func() {
+					inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{
+						Width:      60,
+						CellWidths: row.CellWidthsContainer{WidthQuote: 20},
+					})
+					outputRow, _ := inputRow.Update(row.SetSparklineMsg{1, 2, 3, 4, 5, 6, 7, 8})
+
+					Expect(outputRow.View()).NotTo(ContainSubstring("▁"))
+				})
+			})
+
+		})
+
+		Describe("SetAlertMsg", func() {
+
+			var inputRow *row.Model
+
+			BeforeEach(func() {
+				colorProfile := lipgloss.ColorProfile()
+				lipgloss.SetColorProfile(termenv.ANSI256)
+				DeferCleanup(lipgloss.SetColorProfile, colorProfile)
+
+				inputRow = row.New(row.Config{
+					Styles: styles,
+					Asset: &c.Asset{
+						Symbol: "AAPL",
+						Name:   "Apple Inc.",
+						QuotePrice: c.QuotePrice{
+							Price: 150.00,
+						},
+					},
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{
+					Width:      80,
+					CellWidths: row.CellWidthsContainer{WidthQuote: 20},
+				})
+			})
+
+			It("should highlight the symbol", func() {
+				outputRow, cmd := inputRow.Update(row.SetAlertMsg(true))
+
+				Expect(cmd).To(BeNil())
+				Expect(outputRow.View()).To(ContainSubstring("\x1b[7m"))
+				Expect(outputRow.View()).To(ContainSubstring("AAPL"))
+			})
+
+			When("the alert is cleared", func() {
+				It("should not highlight the symbol", func() {
+					outputRow, _ := inputRow.Update(row.SetAlertMsg(true))
+					outputRow, _ = outputRow.Update(row.SetAlertMsg(false))
+
+					Expect(outputRow.View()).NotTo(ContainSubstring("\x1b[7m"))
+				})
+			})
+
+		})
+
+		When("the asset is cash", func() {
+			It("should render the symbol with the cash style", func() {
+				colorProfile := lipgloss.ColorProfile()
+				lipgloss.SetColorProfile(termenv.ANSI256)
+				DeferCleanup(lipgloss.SetColorProfile, colorProfile)
+
+				inputRow := row.New(row.Config{
+					Styles: styles,
+					Asset: &c.Asset{
+						Symbol: "EUR.CASH",
+						Name:   "Cash",
+						Class:  c.AssetClassCash,
+						QuotePrice: c.QuotePrice{
+							Price: 1.00,
+						},
+						QuoteSource: c.QuoteSourceCash,
+					},
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{
+					Width:      80,
+					CellWidths: row.CellWidthsContainer{WidthQuote: 20},
+				})
+
+				Expect(inputRow.View()).To(ContainSubstring("\x1b[4mEUR.CASH\x1b[0m"))
+			})
+		})
+
+		When("the annualized return is shown", func() {
+
+			newRow := func(annualizedReturn float64) *row.Model {
+				inputRow := row.New(row.Config{
+					ShowReturns: true,
+					Styles:      styles,
+					Asset: &c.Asset{
+						Symbol: "AAPL",
+						QuotePrice: c.QuotePrice{
+							Price: 150.00,
+						},
+						Position: c.Position{
+							Quantity:         10,
+							AnnualizedReturn: annualizedReturn,
+						},
+					},
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{
+					Width:      120,
+					CellWidths: row.CellWidthsContainer{WidthQuote: 20},
+				})
+
+				return inputRow
+			}
+
+			It("should render the annualized return with a label", func() {
+				view := newRow(12.345).View()
+
+				Expect(view).To(ContainSubstring("12.35%"))
+				Expect(view).To(ContainSubstring("Annualized"))
+			})
+
+			When("there is no annualized return", func() {
+				It("should not render the label", func() {
+					Expect(newRow(0).View()).ToNot(ContainSubstring("Annualized"))
+				})
+			})
+		})
+
 	})
 
 })
@@ -18,6 +18,8 @@ type Config struct {
 	ShowPositions         bool
 	ExtraInfoExchange     bool
 	ExtraInfoFundamentals bool
+	ShowSparkline         bool
+	ShowReturns           bool
 	Sort                  string
 	Styles                c.Styles
 }
@@ -32,6 +34,8 @@ type Model struct {
 	cellWidths     row.CellWidthsContainer
 	rows           []*row.Model
 	rowsBySymbol   map[string]*row.Model
+	sparklines     map[string][]float64
+	alerts         map[string]bool
 }
 
 // Messages for replacing assets
@@ -43,6 +47,12 @@ type UpdateAssetsMsg []c.Asset
 // Messages for changing sort
 type ChangeSortMsg string
 
+// Messages for replacing the prices drawn in each sparkline by symbol
+type SetSparklinesMsg map[string][]float64
+
+// Messages for setting the symbols which have recently fired an alert
+type SetAlertsMsg map[string]bool
+
 // NewModel returns a model with default values
 func NewModel(config Config) *Model {
 	return &Model{
@@ -52,6 +62,8 @@ func NewModel(config Config) *Model {
 		assetsBySymbol: make(map[string]*c.Asset),
 		sorter:         s.NewSorter(config.Sort),
 		rowsBySymbol:   make(map[string]*row.Model),
+		sparklines:     make(map[string][]float64),
+		alerts:         make(map[string]bool),
 	}
 }
 
@@ -90,6 +102,8 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 					ExtraInfoExchange:     m.config.ExtraInfoExchange,
 					ExtraInfoFundamentals: m.config.ExtraInfoFundamentals,
 					ShowPositions:         m.config.ShowPositions,
+					ShowSparkline:         m.config.ShowSparkline,
+					ShowReturns:           m.config.ShowReturns,
 					Styles:                m.config.Styles,
 					Asset:                 asset,
 				}))
@@ -113,8 +127,25 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 			})
 		}
 
+		m.setRowSparklines()
+		m.setRowAlerts()
+
 		return m, tea.Batch(cmds...)
 
+	case SetSparklinesMsg:
+
+		m.sparklines = msg
+		m.setRowSparklines()
+
+		return m, nil
+
+	case SetAlertsMsg:
+
+		m.alerts = msg
+		m.setRowAlerts()
+
+		return m, nil
+
 	case tea.WindowSizeMsg:
 
 		m.width = msg.Width
@@ -160,6 +191,9 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+		m.setRowSparklines()
+		m.setRowAlerts()
+
 		return m, tea.Batch(cmds...)
 
 	}
@@ -182,6 +216,30 @@ func (m *Model) View() string {
 	return strings.Join(rows, "\n")
 
 }
+
+// setRowSparklines sets the sparkline on each row since rows are reused for different symbols when assets are sorted
+func (m *Model) setRowSparklines() {
+
+	if !m.config.ShowSparkline {
+		return
+	}
+
+	for i, asset := range m.assets {
+		if i < len(m.rows) {
+			m.rows[i], _ = m.rows[i].Update(row.SetSparklineMsg(m.sparklines[asset.Symbol]))
+		}
+	}
+}
+
+// setRowAlerts highlights the rows of symbols with recent alerts since rows are reused for different symbols when assets are sorted
+func (m *Model) setRowAlerts() {
+	for i, asset := range m.assets {
+		if i < len(m.rows) {
+			m.rows[i], _ = m.rows[i].Update(row.SetAlertMsg(m.alerts[asset.Symbol]))
+		}
+	}
+}
+
 func getCellWidths(assets []*c.Asset) row.CellWidthsContainer {
 
 	cellMaxWidths := row.CellWidthsContainer{}
@@ -191,6 +249,10 @@ func getCellWidths(assets []*c.Asset) row.CellWidthsContainer {
 
 		volumeMarketCapLength := len(u.ConvertFloatToString(asset.QuoteExtended.MarketCap, true))
 
+		if asset.Class == c.AssetClassFuturesContract {
+			volumeMarketCapLength = len(u.ConvertFloatToString(asset.QuoteFutures.OpenInterest, true))
+		}
+
 		if asset.QuoteExtended.FiftyTwoWeekHigh == 0.0 {
 			quoteLength = len(u.ConvertFloatToString(asset.QuotePrice.Price, asset.Meta.IsVariablePrecision))
 		}
@@ -210,6 +272,16 @@ func getCellWidths(assets []*c.Asset) row.CellWidthsContainer {
 			cellMaxWidths.WidthQuoteRange = row.WidthRangeStatic + (quoteLength * 2)
 		}
 
+		if asset.QuoteDepth.BidPrice != 0.0 && asset.QuoteDepth.AskPrice != 0.0 {
+			bidAskLength := len(u.ConvertFloatToString(asset.QuoteDepth.BidPrice, asset.Meta.IsVariablePrecision)) + len(" / ") + len(u.ConvertFloatToString(asset.QuoteDepth.AskPrice, asset.Meta.IsVariablePrecision))
+			spreadLength := len(u.ConvertFloatToString(asset.QuoteDepth.Spread, asset.Meta.IsVariablePrecision)) + len(" (%)") + len(u.ConvertFloatToString(asset.QuoteDepth.SpreadPercent, false))
+			quoteDepthLength := max(bidAskLength, spreadLength)
+
+			if quoteDepthLength > cellMaxWidths.WidthQuoteDepth {
+				cellMaxWidths.WidthQuoteDepth = quoteDepthLength
+			}
+		}
+
 		if asset.Position != (c.Position{}) {
 			positionLength := len(u.ConvertFloatToString(asset.Position.Value, asset.Meta.IsVariablePrecision))
 			positionQuantityLength := len(u.ConvertFloatToString(asset.Position.Quantity, asset.Meta.IsVariablePrecision))
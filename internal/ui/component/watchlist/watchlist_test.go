@@ -11,6 +11,8 @@ import (
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	. "github.com/achannarasappa/ticker/v5/internal/ui/component/watchlist"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func removeFormatting(text string) string {
@@ -31,6 +33,8 @@ var _ = Describe("Watchlist", func() {
 		TextLine:  func(v string) string { return v },
 		TextPrice: func(percent float64, text string) string { return text },
 		Tag:       func(v string) string { return v },
+		Alert:     func(v string) string { return "\x1b[7m" + v + "\x1b[0m" },
+		Cash:      func(v string) string { return "\x1b[4m" + v + "\x1b[0m" },
 	}
 
 	It("should render a watchlist", func() {
@@ -412,6 +416,75 @@ var _ = Describe("Watchlist", func() {
 
 		})
 
+		When("there is a best bid and ask", func() {
+			It("should render the bid, ask, and spread", func() {
+				m := NewModel(Config{
+					Styles:                stylesFixture,
+					ExtraInfoFundamentals: true,
+				})
+				m.Update(tea.WindowSizeMsg{Width: 200})
+				setAssetsMsg := []c.Asset{
+					{
+						Symbol: "ETH.X",
+						Name:   "Ethereum",
+						Class:  c.AssetClassCryptocurrency,
+						QuotePrice: c.QuotePrice{
+							Price:          1285.22,
+							PricePrevClose: 1310.79,
+							PriceOpen:      1310.79,
+							PriceDayHigh:   1313.8,
+							PriceDayLow:    1280.52,
+							Change:         -25.57,
+							ChangePercent:  -1.95,
+						},
+						QuoteDepth: c.QuoteDepth{
+							BidPrice:      1285.04,
+							AskPrice:      1285.27,
+							Spread:        0.23,
+							SpreadPercent: 0.0179,
+						},
+						Exchange: c.Exchange{
+							IsActive:                true,
+							IsRegularTradingSession: true,
+						},
+					},
+				}
+				m.Update(SetAssetsMsg(setAssetsMsg))
+
+				Expect(removeFormatting(m.View())).To(ContainSubstring("Bid / Ask:"))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("1285.04 / 1285.27"))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("Spread:"))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("0.23 (0.02%)"))
+			})
+
+			When("the window is too narrow", func() {
+				It("should not render the bid, ask, and spread", func() {
+					m := NewModel(Config{
+						Styles:                stylesFixture,
+						ExtraInfoFundamentals: true,
+					})
+					m.Update(tea.WindowSizeMsg{Width: 135})
+					setAssetsMsg := []c.Asset{
+						{
+							Symbol: "ETH.X",
+							Name:   "Ethereum",
+							QuotePrice: c.QuotePrice{
+								Price: 1285.22,
+							},
+							QuoteDepth: c.QuoteDepth{
+								BidPrice: 1285.04,
+								AskPrice: 1285.27,
+								Spread:   0.23,
+							},
+						},
+					}
+					m.Update(SetAssetsMsg(setAssetsMsg))
+
+					Expect(removeFormatting(m.View())).ToNot(ContainSubstring("Bid / Ask:"))
+				})
+			})
+		})
+
 		When("the asset is a futures contract", func() {
 			It("should render the underlying asset symbol", func() {
 				m := NewModel(Config{
@@ -436,14 +509,17 @@ var _ = Describe("Watchlist", func() {
 							IsRegularTradingSession: true,
 						},
 						QuoteFutures: c.QuoteFutures{
-							IndexPrice: 50312,
-							Basis:      10.0,
-							Expiry:     "5d 10h",
+							IndexPrice:   50312,
+							Basis:        10.0,
+							Expiry:       "5d 10h",
+							OpenInterest: 123456789,
 						},
 					},
 				}
 				m.Update(SetAssetsMsg(setAssetsMsg))
 
+				Expect(removeFormatting(m.View())).To(ContainSubstring("Open Interest:"))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("123.46 M"))
 				Expect(removeFormatting(m.View())).To(ContainSubstring("50312"))
 				Expect(removeFormatting(m.View())).To(ContainSubstring("5d 10h"))
 				Expect(removeFormatting(m.View())).To(ContainSubstring("10.00%"))
@@ -514,6 +590,123 @@ var _ = Describe("Watchlist", func() {
 		})
 	})
 
+	When("the option for a sparkline is set", func() {
+		var m *Model
+
+		BeforeEach(func() {
+			m = NewModel(Config{
+				Styles:        stylesFixture,
+				ShowSparkline: true,
+			})
+			m.Update(tea.WindowSizeMsg{Width: 120})
+			m.Update(SetSparklinesMsg{
+				"AAPL": {1, 2, 3},
+				"GOOG": {3, 2, 1},
+			})
+			m.Update(SetAssetsMsg([]c.Asset{
+				{
+					Symbol:     "GOOG",
+					Name:       "Google Inc.",
+					QuotePrice: c.QuotePrice{Price: 2523.53, Change: 32.02, ChangePercent: 1.35},
+				},
+				{
+					Symbol:     "AAPL",
+					Name:       "Apple Inc.",
+					QuotePrice: c.QuotePrice{Price: 150.00, Change: -5.00, ChangePercent: -3.33},
+				},
+			}))
+		})
+
+		It("should render the sparkline for each symbol", func() {
+			view := removeFormatting(m.View())
+
+			Expect(getLine(view, 0)).To(ContainSubstring("GOOG"))
+			Expect(getLine(view, 0)).To(ContainSubstring("█▄▁"))
+			Expect(getLine(view, 2)).To(ContainSubstring("AAPL"))
+			Expect(getLine(view, 2)).To(ContainSubstring("▁▄█"))
+		})
+
+		When("the sort order changes", func() {
+			It("should move the sparkline with the symbol", func() {
+				m.Update(ChangeSortMsg("alpha"))
+				view := removeFormatting(m.View())
+
+				Expect(getLine(view, 0)).To(ContainSubstring("AAPL"))
+				Expect(getLine(view, 0)).To(ContainSubstring("▁▄█"))
+				Expect(getLine(view, 2)).To(ContainSubstring("GOOG"))
+				Expect(getLine(view, 2)).To(ContainSubstring("█▄▁"))
+			})
+		})
+
+		When("the sparklines are updated", func() {
+			It("should render the updated sparkline", func() {
+				m.Update(SetSparklinesMsg{
+					"AAPL": {1, 2, 3, 1},
+				})
+				view := removeFormatting(m.View())
+
+				Expect(getLine(view, 0)).NotTo(ContainSubstring("▁"))
+				Expect(getLine(view, 2)).To(ContainSubstring("▁▄█▁"))
+			})
+		})
+	})
+
+	When("alerts are set", func() {
+		var m *Model
+
+		BeforeEach(func() {
+			colorProfile := lipgloss.ColorProfile()
+			lipgloss.SetColorProfile(termenv.ANSI256)
+			DeferCleanup(lipgloss.SetColorProfile, colorProfile)
+
+			m = NewModel(Config{
+				Styles: stylesFixture,
+			})
+			m.Update(tea.WindowSizeMsg{Width: 80})
+			m.Update(SetAlertsMsg{"AAPL": true})
+			m.Update(SetAssetsMsg([]c.Asset{
+				{
+					Symbol:     "GOOG",
+					Name:       "Google Inc.",
+					QuotePrice: c.QuotePrice{Price: 2523.53, Change: 32.02, ChangePercent: 1.35},
+				},
+				{
+					Symbol:     "AAPL",
+					Name:       "Apple Inc.",
+					QuotePrice: c.QuotePrice{Price: 150.00, Change: -5.00, ChangePercent: -3.33},
+				},
+			}))
+		})
+
+		It("should highlight the symbols with alerts", func() {
+			view := m.View()
+
+			Expect(getLine(view, 0)).To(ContainSubstring("GOOG"))
+			Expect(getLine(view, 0)).NotTo(ContainSubstring("\x1b[7m"))
+			Expect(getLine(view, 2)).To(ContainSubstring("AAPL"))
+			Expect(getLine(view, 2)).To(ContainSubstring("\x1b[7m"))
+		})
+
+		When("the sort order changes", func() {
+			It("should move the highlight with the symbol", func() {
+				m.Update(ChangeSortMsg("alpha"))
+				view := m.View()
+
+				Expect(getLine(view, 0)).To(ContainSubstring("AAPL"))
+				Expect(getLine(view, 0)).To(ContainSubstring("\x1b[7m"))
+				Expect(getLine(view, 2)).NotTo(ContainSubstring("\x1b[7m"))
+			})
+		})
+
+		When("the alerts are cleared", func() {
+			It("should not highlight any symbols", func() {
+				m.Update(SetAlertsMsg{})
+
+				Expect(m.View()).NotTo(ContainSubstring("\x1b[7m"))
+			})
+		})
+	})
+
 	When("the option for extra holding information is set", func() {
 		It("should render extra holding information", func() {
 			m := NewModel(Config{
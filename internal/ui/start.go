@@ -1,8 +1,14 @@
 package ui
 
 import (
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/alert"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/history"
 	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+	"github.com/achannarasappa/ticker/v5/internal/notify"
+	"github.com/achannarasappa/ticker/v5/internal/replay"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -10,45 +16,83 @@ import (
 func Start(dep *c.Dependencies, ctx *c.Context, version string) func() error {
 	return func() error {
 
-		monitors, _ := mon.NewMonitor(mon.ConfigMonitor{
-			RefreshInterval: ctx.Config.RefreshInterval,
-			TargetCurrency:  ctx.Config.Currency,
-			Logger:          ctx.Logger,
-			ConfigMonitorsYahoo: mon.ConfigMonitorsYahoo{
-				BaseURL:           dep.MonitorYahooBaseURL,
-				SessionRootURL:    dep.MonitorYahooSessionRootURL,
-				SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
-				SessionConsentURL: dep.MonitorYahooSessionConsentURL,
-			},
-			ConfigMonitorPriceCoinbase: mon.ConfigMonitorPriceCoinbase{
-				BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
-				StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
-			},
-		})
+		configMonitor := mon.NewConfigMonitor(*dep, *ctx)
+		historyStore := newHistoryStore(dep, ctx)
+
+		if historyStore != nil {
+			configMonitor.Recorder = historyStore
+		}
+
+		if len(ctx.Config.Alerts) > 0 {
+			configMonitor.Alerter = alert.NewEvaluator(
+				alert.Config{Rules: ctx.Config.Alerts},
+				alert.WithCooldown(time.Duration(ctx.Config.AlertCooldown)*time.Second),
+			)
+		}
+
+		monitors, _ := mon.NewMonitor(configMonitor)
+
+		model := NewModel(*dep, *ctx, monitors, historyStore, version)
+
+		if len(ctx.Config.Notify.Rules) > 0 {
+			model.notifier = notify.NewNotifier(notify.NewConfigNotifier(*ctx))
+		}
+
+		p := tea.NewProgram(
+			model,
+			tea.WithMouseCellMotion(),
+			tea.WithAltScreen(),
+		)
+
+		err := monitors.SetOnUpdate(getConfigUpdateFns(p))
+
+		if err != nil {
+
+			return err
+		}
+
+		_, err = p.Run()
+
+		// Stop after the UI exits so that queued quotes are recorded to history
+		monitors.Stop()
+
+		return err
+	}
+
+}
+
+// StartReplay launches the command line interface with quotes played back from a recording in place of quote sources
+func StartReplay(dep *c.Dependencies, ctx *c.Context, options *replay.Options, version string) func() error {
+	return func() error {
+
+		quotes, err := history.ReadQuotes(dep.Fs, options.Path)
+
+		if err != nil {
+			return err
+		}
+
+		player, err := replay.NewPlayer(
+			replay.Config{Quotes: quotes},
+			replay.WithSpeed(options.Speed),
+			replay.WithStart(options.Start),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		defer player.Stop()
+
+		model := NewModel(*dep, *ctx, player, nil, version)
+		model.player = player
 
 		p := tea.NewProgram(
-			NewModel(*dep, *ctx, monitors, version),
+			model,
 			tea.WithMouseCellMotion(),
 			tea.WithAltScreen(),
 		)
 
-		var err error
-
-		err = monitors.SetOnUpdate(mon.ConfigUpdateFns{
-			OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, versionVector int) {
-				p.Send(SetAssetQuoteMsg{
-					symbol:        symbol,
-					assetQuote:    assetQuote,
-					versionVector: versionVector,
-				})
-			},
-			OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {
-				p.Send(SetAssetGroupQuoteMsg{
-					assetGroupQuote: assetGroupQuote,
-					versionVector:   versionVector,
-				})
-			},
-		})
+		err = player.SetOnUpdate(getConfigUpdateFns(p))
 
 		if err != nil {
 
@@ -61,3 +105,51 @@ func Start(dep *c.Dependencies, ctx *c.Context, version string) func() error {
 	}
 
 }
+
+// getConfigUpdateFns returns callbacks which send updated quotes to the UI
+func getConfigUpdateFns(p *tea.Program) mon.ConfigUpdateFns {
+	return mon.ConfigUpdateFns{
+		OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, versionVector int) {
+			p.Send(SetAssetQuoteMsg{
+				symbol:        symbol,
+				assetQuote:    assetQuote,
+				versionVector: versionVector,
+			})
+		},
+		OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {
+			p.Send(SetAssetGroupQuoteMsg{
+				assetGroupQuote: assetGroupQuote,
+				versionVector:   versionVector,
+			})
+		},
+		OnUpdateStreamStatus: func(streamStatus c.StreamStatusUpdate) {
+			p.Send(SetStreamStatusMsg(streamStatus))
+		},
+		OnAlert: func(a c.Alert) {
+			p.Send(SetAlertMsg(a))
+		},
+	}
+}
+
+// newHistoryStore creates a store to record quote history when enabled and removes or compacts old history in the background
+func newHistoryStore(dep *c.Dependencies, ctx *c.Context) *history.Store {
+
+	if !ctx.Config.History.Enabled {
+		return nil
+	}
+
+	historyStore := history.NewStore(
+		history.Config{Fs: dep.Fs},
+		history.WithRetention(time.Duration(ctx.Config.History.RetentionDays)*24*time.Hour),
+		history.WithCompactAfter(time.Duration(ctx.Config.History.CompactAfterDays)*24*time.Hour),
+		history.WithSnapshotInterval(time.Duration(ctx.Config.History.SnapshotInterval)*time.Second),
+	)
+
+	go func() {
+		if err := historyStore.Compact(); err != nil && ctx.Config.Debug {
+			ctx.Logger.Println(err)
+		}
+	}()
+
+	return historyStore
+}
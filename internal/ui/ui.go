@@ -2,13 +2,17 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"sync"
 	"time"
 
 	grid "github.com/achannarasappa/term-grid"
 	"github.com/achannarasappa/ticker/v5/internal/asset"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
-	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+	"github.com/achannarasappa/ticker/v5/internal/history"
+	"github.com/achannarasappa/ticker/v5/internal/notify"
+	"github.com/achannarasappa/ticker/v5/internal/replay"
 	"github.com/achannarasappa/ticker/v5/internal/ui/component/summary"
 	"github.com/achannarasappa/ticker/v5/internal/ui/component/watchlist"
 	"github.com/achannarasappa/ticker/v5/internal/ui/component/watchlist/row"
@@ -30,8 +34,24 @@ var (
 
 const (
 	footerHeight = 1
+	// sparklinePricesMax is the maximum number of prices kept for each sparkline
+	sparklinePricesMax = 500
+	// replaySeekStep is how far the position in a recording moves when seeking
+	replaySeekStep = time.Minute
+	// replayHelpText lists the keys which control playback when replaying a recording
+	replayHelpText  = " space: pause ←/→: seek +/-: speed"
+	replayHelpWidth = 34
+	// alertDisplayDuration is how long a row stays highlighted and the footer shows the banner after an alert fires
+	alertDisplayDuration = 5 * time.Minute
 )
 
+// Monitor is the source of asset quotes shown in the UI
+type Monitor interface {
+	Start()
+	SetAssetGroup(assetGroup c.AssetGroup, versionVector int) error
+	GetPriceHistory(assetQuote c.AssetQuote, historyRange c.PriceHistoryRange, interval c.PriceHistoryInterval) (c.PriceHistory, error)
+}
+
 // Model for UI
 type Model struct {
 	ctx                c.Context
@@ -47,11 +67,19 @@ type Model struct {
 	watchlist          *watchlist.Model
 	summary            *summary.Model
 	lastUpdateTime     string
+	streamStatuses     map[c.QuoteSource]c.StreamStatus
+	sparklines         map[string][]float64
+	sparklinesSeeded   map[string]bool
+	alerts             map[string]time.Time
+	alertLatest        c.Alert
 	groupSelectedIndex int
 	groupMaxIndex      int
 	groupSelectedName  string
 	currentSort        string
-	monitors           *mon.Monitor
+	monitors           Monitor
+	player             *replay.Player
+	historyStore       *history.Store
+	notifier           *notify.Notifier
 	mu                 sync.RWMutex
 	version            string
 	latestVersion      string
@@ -78,8 +106,17 @@ type SetAssetGroupQuoteMsg struct {
 	versionVector   int
 }
 
+type SetStreamStatusMsg c.StreamStatusUpdate
+
+type SetAlertMsg c.Alert
+
+type SetPriceHistoryMsg struct {
+	symbol       string
+	priceHistory c.PriceHistory
+}
+
 // NewModel is the constructor for UI model
-func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, version string) *Model {
+func NewModel(dep c.Dependencies, ctx c.Context, monitors Monitor, historyStore *history.Store, version string) *Model {
 
 	groupMaxIndex := len(ctx.Groups) - 1
 
@@ -92,6 +129,10 @@ func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, version
 		assets:            make([]c.Asset, 0),
 		assetQuotes:       make([]c.AssetQuote, 0),
 		assetQuotesLookup: make(map[string]int),
+		streamStatuses:    make(map[c.QuoteSource]c.StreamStatus),
+		sparklines:        make(map[string][]float64),
+		sparklinesSeeded:  make(map[string]bool),
+		alerts:            make(map[string]time.Time),
 		positionSummary:   asset.PositionSummary{},
 		watchlist: watchlist.NewModel(watchlist.Config{
 			Sort:                  ctx.Config.Sort,
@@ -99,6 +140,8 @@ func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, version
 			ShowPositions:         ctx.Config.ShowPositions,
 			ExtraInfoExchange:     ctx.Config.ExtraInfoExchange,
 			ExtraInfoFundamentals: ctx.Config.ExtraInfoFundamentals,
+			ShowSparkline:         ctx.Config.ShowSparkline,
+			ShowReturns:           ctx.Config.ShowReturns,
 			Styles:                ctx.Reference.Styles,
 		}),
 		summary:            summary.NewModel(ctx),
@@ -107,6 +150,7 @@ func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, version
 		groupSelectedName:  "       ",
 		currentSort:        ctx.Config.Sort,
 		monitors:           monitors,
+		historyStore:       historyStore,
 		version:            version,
 		releasesURL:        dep.GitHubReleasesURL,
 		fs:                 dep.Fs,
@@ -115,14 +159,14 @@ func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, version
 
 // Init is the initialization hook for bubbletea
 func (m *Model) Init() tea.Cmd {
-	(*m.monitors).Start()
+	m.monitors.Start()
 
 	// Start renderer and set symbols in parallel
 	return tea.Batch(
 		tick(0),
 		updateCheckTick(),
 		func() tea.Msg {
-			err := (*m.monitors).SetAssetGroup(m.ctx.Groups[m.groupSelectedIndex], m.versionVector)
+			err := m.monitors.SetAssetGroup(m.ctx.Groups[m.groupSelectedIndex], m.versionVector)
 
 			if m.ctx.Config.Debug && err != nil {
 				m.ctx.Logger.Println(err)
@@ -143,6 +187,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
+		// Keys which control playback are only handled when replaying a recording
+		if m.player != nil && updateReplay(m.player, msg.String()) {
+			return m, nil
+		}
+
 		switch msg.String() {
 
 		case "tab", "shift+tab":
@@ -252,6 +301,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 		}
 
 		// Update watchlist and summary components
+		m.watchlist, _ = m.watchlist.Update(watchlist.SetSparklinesMsg(m.sparklines))
+		m.watchlist, _ = m.watchlist.Update(watchlist.SetAlertsMsg(m.getActiveAlerts()))
 		m.watchlist, cmd = m.watchlist.Update(watchlist.SetAssetsMsg(m.assets))
 		m.summary, _ = m.summary.Update(summary.SetSummaryMsg(m.positionSummary))
 
@@ -290,9 +341,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 			m.assetQuotesLookup[assetQuote.Symbol] = i
 		}
 
+		m.recordSnapshot()
+		m.notify()
+
 		m.groupSelectedName = m.ctx.Groups[m.groupSelectedIndex].Name
 
-		return m, nil
+		return m, m.seedSparklines(m.assetQuotes)
 
 	case SetAssetQuoteMsg:
 
@@ -324,6 +378,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 		// Update the asset quote and generate a new position summary
 		m.assetQuotes[i] = msg.assetQuote
 
+		if m.ctx.Config.ShowSparkline {
+			m.sparklines[msg.symbol] = appendSparklinePrice(m.sparklines[msg.symbol], msg.assetQuote.QuotePrice.Price)
+		}
+
 		assetGroupQuote := c.AssetGroupQuote{
 			AssetQuotes: m.assetQuotes,
 			AssetGroup:  m.ctx.Groups[m.groupSelectedIndex],
@@ -333,6 +391,42 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 
 		m.assets = assets
 		m.positionSummary = positionSummary
+		m.recordSnapshot()
+		m.notify()
+
+		return m, nil
+
+	case SetStreamStatusMsg:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.streamStatuses[msg.Source] = msg.Status
+
+		return m, nil
+
+	case SetAlertMsg:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.alerts[msg.Symbol] = msg.Time
+		m.alertLatest = c.Alert(msg)
+
+		m.watchlist, _ = m.watchlist.Update(watchlist.SetAlertsMsg(m.getActiveAlerts()))
+
+		return m, bell()
+
+	case SetPriceHistoryMsg:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		// Prices received while the price history was being retrieved are more recent than the price history
+		prices := make([]float64, 0, len(msg.priceHistory.Candles)+len(m.sparklines[msg.symbol]))
+		for _, candle := range msg.priceHistory.Candles {
+			prices = append(prices, candle.Close)
+		}
+		prices = append(prices, m.sparklines[msg.symbol]...)
+
+		m.sparklines[msg.symbol] = prices[max(0, len(prices)-sparklinePricesMax):]
 
 		return m, nil
 
@@ -378,11 +472,11 @@ func (m *Model) View() string {
 
 	return viewSummary +
 		m.viewport.View() + "\n" +
-		footer(m.viewport.Width, m.lastUpdateTime, m.groupSelectedName, m.currentSort, m.latestVersion)
+		footer(m.viewport.Width, m.lastUpdateTime, m.groupSelectedName, m.currentSort, m.latestVersion, m.getStatusText(), m.getAlertText(), m.ctx.Reference.Styles.Alert, m.player != nil)
 
 }
 
-func footer(width int, time string, groupSelectedName string, currentSort string, latestVersion string) string {
+func footer(width int, time string, groupSelectedName string, currentSort string, latestVersion string, statusText string, alertText string, styleAlert c.StyleFn, replaying bool) string {
 
 	if width < 80 {
 		return styleLogo(" ticker ")
@@ -410,29 +504,215 @@ func footer(width int, time string, groupSelectedName string, currentSort string
 	if latestVersion != "" {
 		rightText = "↑ " + latestVersion + " available"
 	}
+	if statusText != "" {
+		rightText = statusText
+	}
+
+	styleRightText := styleHelp
+	if alertText != "" {
+		rightText = "🔔 " + alertText + " "
+		styleRightText = styleAlert
+	}
 
 	// Calculate minimum width for sort help text to appear
 	// Longest sort text is "s: change sort (change)" = 24 characters
 	// Minimum width needed: logo(8) + max group(14) + base help(52) + sort help(24) + time(12) = 110
-	const sortHelpMinWidth = 114
+	sortHelpMinWidth := 114
+
+	cells := []grid.Cell{
+		{Text: styleLogo(" ticker "), Width: 8},
+		{Text: styleGroup(" " + groupSelectedName + " "), Width: len(groupSelectedName) + 2, VisibleMinWidth: 95},
+		{Text: styleHelp(baseHelpText), Width: 52},
+	}
+
+	// Replay controls are shown ahead of the sort help text so that they remain visible on narrower terminals
+	if replaying {
+		cells = append(cells, grid.Cell{Text: styleHelp(replayHelpText), Width: replayHelpWidth, VisibleMinWidth: sortHelpMinWidth})
+		sortHelpMinWidth += replayHelpWidth
+	}
+
+	cells = append(cells,
+		grid.Cell{Text: styleHelp(sortHelpText), Width: len(sortHelpText), VisibleMinWidth: sortHelpMinWidth},
+		grid.Cell{Text: styleRightText(rightText), Align: grid.Right},
+	)
 
 	return grid.Render(grid.Grid{
 		Rows: []grid.Row{
 			{
 				Width: width,
-				Cells: []grid.Cell{
-					{Text: styleLogo(" ticker "), Width: 8},
-					{Text: styleGroup(" " + groupSelectedName + " "), Width: len(groupSelectedName) + 2, VisibleMinWidth: 95},
-					{Text: styleHelp(baseHelpText), Width: 52},
-					{Text: styleHelp(sortHelpText), Width: len(sortHelpText), VisibleMinWidth: sortHelpMinWidth},
-					{Text: styleHelp(rightText), Align: grid.Right},
-				},
+				Cells: cells,
 			},
 		},
 	})
 
 }
 
+// getStatusText returns the playback state when replaying a recording and otherwise the state of streaming sources
+func (m *Model) getStatusText() string {
+
+	if m.player != nil {
+		return getReplayStatusText(m.player.Status())
+	}
+
+	return getStreamStatusText(m.streamStatuses)
+}
+
+// getActiveAlerts removes alerts which are no longer displayed and returns the symbols with alerts which are
+func (m *Model) getActiveAlerts() map[string]bool {
+
+	activeAlerts := make(map[string]bool)
+
+	for symbol, firedAt := range m.alerts {
+		if time.Since(firedAt) > alertDisplayDuration {
+			delete(m.alerts, symbol)
+
+			continue
+		}
+
+		activeAlerts[symbol] = true
+	}
+
+	return activeAlerts
+}
+
+// getAlertText returns the message for the latest alert while it is displayed
+func (m *Model) getAlertText() string {
+
+	if m.alertLatest.Message == "" || time.Since(m.alertLatest.Time) > alertDisplayDuration {
+		return ""
+	}
+
+	return m.alertLatest.Message
+}
+
+// getReplayStatusText returns the playback state and the time in the recording
+func getReplayStatusText(status replay.Status) string {
+
+	state := "▶ " + strconv.FormatFloat(status.Speed, 'f', -1, 64) + "x"
+
+	if status.Paused {
+		state = "⏸ paused"
+	}
+
+	if status.Done {
+		state = "■ end"
+	}
+
+	return state + "  " + status.Position.Local().Format("2006-01-02 15:04:05")
+}
+
+// updateReplay pauses, changes the speed of, or seeks the recording being replayed for a key press and returns false if
+// the key does not control playback
+func updateReplay(player *replay.Player, key string) bool {
+
+	switch key {
+	case " ":
+		player.TogglePause()
+	case "+", "=":
+		player.SetSpeed(player.Status().Speed * 2)
+	case "-":
+		player.SetSpeed(player.Status().Speed / 2)
+	case "left":
+		player.Seek(-replaySeekStep)
+	case "right":
+		player.Seek(replaySeekStep)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// getStreamStatusText returns a warning when any streaming source is not connected so that stale prices are not mistaken for live prices
+func getStreamStatusText(streamStatuses map[c.QuoteSource]c.StreamStatus) string {
+	text := ""
+
+	for _, status := range streamStatuses {
+		switch status {
+		case c.StreamStatusReconnecting:
+			return "⚠ live prices reconnecting"
+		case c.StreamStatusDisconnected:
+			text = "⚠ live prices disconnected"
+		case c.StreamStatusConnected:
+		}
+	}
+
+	return text
+}
+
+// recordSnapshot records the position summary of the selected group when history is enabled
+func (m *Model) recordSnapshot() {
+
+	if m.historyStore == nil {
+		return
+	}
+
+	err := m.historyStore.RecordSnapshot(m.ctx.Groups[m.groupSelectedIndex].Name, m.positionSummary)
+
+	if m.ctx.Config.Debug && err != nil {
+		m.ctx.Logger.Println(err)
+	}
+}
+
+// notify delivers notifications for rules met by the assets in the selected group when notifications are configured
+func (m *Model) notify() {
+
+	if m.notifier == nil {
+		return
+	}
+
+	m.notifier.Notify(m.assetQuotes, m.assets, m.positionSummary)
+}
+
+// seedSparklines requests the intraday price history once for each symbol which does not yet have a sparkline
+func (m *Model) seedSparklines(assetQuotes []c.AssetQuote) tea.Cmd {
+
+	if !m.ctx.Config.ShowSparkline {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0)
+
+	for _, assetQuote := range assetQuotes {
+		if m.sparklinesSeeded[assetQuote.Symbol] {
+			continue
+		}
+
+		m.sparklinesSeeded[assetQuote.Symbol] = true
+
+		cmds = append(cmds, func() tea.Msg {
+			priceHistory, err := m.monitors.GetPriceHistory(assetQuote, c.PriceHistoryRange1Day, c.PriceHistoryInterval5Minute)
+
+			if err != nil {
+				if m.ctx.Config.Debug {
+					m.ctx.Logger.Println(err)
+				}
+
+				return nil
+			}
+
+			return SetPriceHistoryMsg{
+				symbol:       assetQuote.Symbol,
+				priceHistory: priceHistory,
+			}
+		})
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// appendSparklinePrice adds a live price to a sparkline when the price has changed and drops the oldest prices beyond the maximum
+func appendSparklinePrice(prices []float64, price float64) []float64 {
+
+	if price == 0.0 || (len(prices) > 0 && prices[len(prices)-1] == price) {
+		return prices
+	}
+
+	prices = append(prices, price)
+
+	return prices[max(0, len(prices)-sparklinePricesMax):]
+}
+
 func getVerticalMargin(config c.Config) int {
 	if config.ShowSummary {
 		return 2
@@ -456,6 +736,15 @@ func tick(versionVector int) tea.Cmd {
 	})
 }
 
+// bell rings the terminal bell
+func bell() tea.Cmd {
+	return func() tea.Msg {
+		fmt.Fprint(os.Stdout, "\a")
+
+		return nil
+	}
+}
+
 // Send a new tick message immediately
 func tickImmediate(versionVector int) tea.Cmd {
 
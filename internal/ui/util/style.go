@@ -131,6 +131,16 @@ func GetColorScheme(colorScheme c.ConfigColorScheme) c.Styles {
 			getColorOrDefault(colorScheme.BackgroundTag, "#303030"),
 			false,
 		),
+		Alert: NewStyle(
+			getColorOrDefault(colorScheme.TextAlert, "#080808"),
+			getColorOrDefault(colorScheme.BackgroundAlert, "#ffaf00"),
+			true,
+		),
+		Cash: NewStyle(
+			getColorOrDefault(colorScheme.TextCash, "#5fd7af"),
+			"",
+			true,
+		),
 	}
 
 }
@@ -33,3 +33,17 @@ func NewTestServer(messages []string) *httptest.Server {
 		}
 	}))
 }
+
+// NewTestServerWithHandler creates a new test WebSocket server which passes each connection
+// and the request used to open it to the handler
+func NewTestServerWithHandler(handler func(conn *websocket.Conn, r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		handler(conn, r)
+	}))
+}